@@ -0,0 +1,330 @@
+package gofb2
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// nameParticles are lower case parts of surnames kept as is
+var nameParticles = map[string]bool{
+	"de": true, "da": true, "di": true, "du": true, "del": true, "della": true,
+	"la": true, "le": true, "van": true, "von": true, "der": true, "den": true,
+	"ten": true, "ter": true, "zu": true, "y": true, "bin": true, "ibn": true,
+	"al": true, "el": true,
+}
+
+// fieldValue return trimmed value of text field with collapsed spaces
+func fieldValue(f *TextField) string {
+	if f == nil {
+		return ""
+	}
+	return strings.TrimSpace(collapseSpace(f.Value))
+}
+
+// setField set value of text field keeping its attributes, empty value
+// remove field
+func setField(f **TextField, name, value string) {
+	switch {
+	case value == "":
+		*f = nil
+	case *f == nil:
+		*f = textField(name, value)
+	default:
+		(*f).Value = value
+	}
+}
+
+// isInitial report whether word is an initial like "L", "L." or "Dzh."
+func isInitial(w string) bool {
+	r, _ := utf8.DecodeRuneInString(w)
+	if !unicode.IsLetter(r) {
+		return false
+	}
+	n := utf8.RuneCountInString(w)
+	if strings.HasSuffix(w, ".") {
+		return n <= 4 && unicode.IsUpper(r) && !strings.Contains(w[:len(w)-1], ".")
+	}
+	return n == 1
+}
+
+// splitInitials split glued initials like "L.N." to words
+func splitInitials(words []string) []string {
+	var res []string
+	for _, w := range words {
+		parts := strings.SplitAfter(w, ".")
+		if len(parts) > 2 && parts[len(parts)-1] == "" {
+			glued := true
+			for _, p := range parts[:len(parts)-1] {
+				glued = glued && isInitial(p)
+			}
+			if glued {
+				res = append(res, parts[:len(parts)-1]...)
+				continue
+			}
+		}
+		res = append(res, w)
+	}
+	return res
+}
+
+func allInitials(words []string) bool {
+	for _, w := range words {
+		if !isInitial(w) {
+			return false
+		}
+	}
+	return len(words) > 0
+}
+
+// nameWord fix case of all caps or lower case word and trailing dots
+func nameWord(w string) string {
+	if isInitial(w) {
+		w = strings.TrimSuffix(w, ".")
+		r, size := utf8.DecodeRuneInString(w)
+		return string(unicode.ToUpper(r)) + w[size:] + "."
+	}
+	w = strings.TrimRight(w, ".")
+	if isParticle(w) {
+		return strings.ToLower(w)
+	}
+	if strings.ToUpper(w) != w && strings.ToLower(w) != w {
+		return w
+	}
+	// title case of every part of compound name like "Saltykov-Shchedrin"
+	var b strings.Builder
+	upper := true
+	for _, r := range w {
+		if upper {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			b.WriteRune(unicode.ToLower(r))
+		}
+		upper = r == '-' || r == '\'' || r == '’'
+	}
+	return b.String()
+}
+
+// isParticle report whether word is a name particle in lower case or all caps
+func isParticle(w string) bool {
+	if lower := strings.ToLower(w); lower == w || strings.ToUpper(w) == w && !isInitial(w) {
+		return nameParticles[lower]
+	}
+	return false
+}
+
+func nameWords(words []string) string {
+	res := make([]string, len(words))
+	for i, w := range words {
+		res[i] = nameWord(w)
+	}
+	return strings.Join(res, " ")
+}
+
+// Normalize fix names of author: full name in one field is split,
+// swapped first and last names are exchanged, glued initials are
+// separated, all caps and lower case names are capitalized and trailing
+// dots are removed
+func (a *Author) Normalize() {
+	first := splitInitials(strings.Fields(fieldValue(a.FirstName)))
+	middle := splitInitials(strings.Fields(fieldValue(a.MiddleName)))
+	last := splitInitials(strings.Fields(fieldValue(a.LastName)))
+
+	if len(middle) == 0 && (len(first) == 0) != (len(last) == 0) {
+		// full name in one field
+		name := strings.Join(append(first, last...), " ")
+		if i := strings.IndexByte(name, ','); i >= 0 {
+			last = strings.Fields(name[:i])
+			first = splitInitials(strings.Fields(name[i+1:]))
+		} else if words := append(first, last...); len(words) > 1 {
+			if allInitials(words[1:]) && !isInitial(words[0]) {
+				// "Tolstoy L. N."
+				last, first = words[:1], words[1:]
+			} else {
+				last, first = words[len(words)-1:], words[:len(words)-1]
+			}
+		}
+		if len(first) > 1 {
+			middle = first[1:]
+			first = first[:1]
+		}
+	} else if len(first) > 1 && len(middle) == 0 && allInitials(first) {
+		middle = first[1:]
+		first = first[:1]
+	}
+	if allInitials(last) && len(first) > 0 && !allInitials(first) {
+		// "Tolstoy" as first name and "L. N." as last name
+		given := append(append([]string{}, last...), middle...)
+		last = first
+		first, middle = given[:1], given[1:]
+	}
+	// particles like "van" of "Ludwig van Beethoven" belong to last name
+	given := &middle
+	if len(middle) == 0 {
+		given = &first
+	}
+	for n := len(*given); n > 0 && len(last) > 0 && isParticle((*given)[n-1]); n-- {
+		last = append([]string{(*given)[n-1]}, last...)
+		*given = (*given)[:n-1]
+	}
+	setField(&a.FirstName, "first-name", nameWords(first))
+	setField(&a.MiddleName, "middle-name", nameWords(middle))
+	setField(&a.LastName, "last-name", nameWords(last))
+	if a.Nickname != nil {
+		a.Nickname.Value = fieldValue(a.Nickname)
+	}
+}
+
+// SortName return name like "Last, First Middle", nickname is returned
+// for author without names
+func (a *Author) SortName() string {
+	given := strings.TrimSpace(fieldValue(a.FirstName) + " " + fieldValue(a.MiddleName))
+	switch last := fieldValue(a.LastName); {
+	case last != "" && given != "":
+		return last + ", " + given
+	case last != "":
+		return last
+	case given != "":
+		return given
+	}
+	return fieldValue(a.Nickname)
+}
+
+// ShortName return name with initials like "F. M. Last"
+func (a *Author) ShortName() string {
+	last := fieldValue(a.LastName)
+	if last == "" {
+		return authorName(a)
+	}
+	var parts []string
+	for _, w := range strings.Fields(fieldValue(a.FirstName) + " " + fieldValue(a.MiddleName)) {
+		parts = append(parts, initials(w))
+	}
+	return strings.Join(append(parts, last), " ")
+}
+
+// initials return initials of name, compound names give "J.-P."
+func initials(w string) string {
+	if isInitial(w) {
+		return nameWord(w)
+	}
+	var parts []string
+	for _, p := range strings.Split(w, "-") {
+		if r, _ := utf8.DecodeRuneInString(p); unicode.IsLetter(r) {
+			parts = append(parts, string(unicode.ToUpper(r))+".")
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Key return key to sort and find duplicates of authors: last, first
+// and middle names in lower case, "ё" is replaced by "е" and punctuation
+// is removed
+func (a *Author) Key() string {
+	name := fieldValue(a.LastName) + " " + fieldValue(a.FirstName) + " " + fieldValue(a.MiddleName)
+	if strings.TrimSpace(name) == "" {
+		name = fieldValue(a.Nickname)
+	}
+	return foldName(name)
+}
+
+func foldName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		switch {
+		case r == 'ё':
+			return 'е'
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return r
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// latin is a transliteration of Cyrillic letters of Russian, Ukrainian
+// and Belarusian alphabets
+var latin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+// Transliterate return text with Cyrillic letters replaced by Latin ones,
+// other characters are kept
+func Transliterate(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		l, ok := latin[unicode.ToLower(r)]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		upperPrev := i > 0 && unicode.IsUpper(runes[i-1])
+		upperNext := i+1 < len(runes) && unicode.IsUpper(runes[i+1])
+		lowerNext := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		switch {
+		case !unicode.IsUpper(r) || l == "":
+			b.WriteString(l)
+		case upperNext || upperPrev && !lowerNext:
+			// word in all caps
+			b.WriteString(strings.ToUpper(l))
+		default:
+			b.WriteString(strings.ToUpper(l[:1]) + l[1:])
+		}
+	}
+	return b.String()
+}
+
+// NormalizeAuthors normalize authors and translators of title-info and
+// src-title-info and authors of document-info, duplicates are removed
+// and their home pages and emails are added to the first author
+func NormalizeAuthors(d *Description) {
+	for _, ti := range []*TitleInfo{d.TitleInfo, d.SrcTitleInfo} {
+		if ti != nil {
+			ti.Authors = normalizeAuthors(ti.Authors)
+			ti.Translators = normalizeAuthors(ti.Translators)
+		}
+	}
+	if d.DocumentInfo != nil {
+		d.DocumentInfo.Authors = normalizeAuthors(d.DocumentInfo.Authors)
+	}
+}
+
+func normalizeAuthors(authors []*Author) []*Author {
+	var res []*Author
+	keys := map[string]*Author{}
+	for _, a := range authors {
+		a.Normalize()
+		key := a.Key()
+		first, ok := keys[key]
+		if !ok || key == "" {
+			keys[key] = a
+			res = append(res, a)
+			continue
+		}
+		first.HomePages = appendMissing(first.HomePages, a.HomePages...)
+		first.Emails = appendMissing(first.Emails, a.Emails...)
+		if first.ID == "" {
+			first.ID = a.ID
+		}
+	}
+	return res
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, l := range list {
+			found = found || l == v
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,89 @@
+package gofb2
+
+import "testing"
+
+func newAuthor(first, middle, last string) *Author {
+	a := &Author{}
+	setField(&a.FirstName, "first-name", first)
+	setField(&a.MiddleName, "middle-name", middle)
+	setField(&a.LastName, "last-name", last)
+	return a
+}
+
+func TestAuthorNormalize(t *testing.T) {
+	tests := []struct {
+		first, middle, last string
+		want                string
+		short               string
+	}{
+		{"Лев Николаевич Толстой", "", "", "Толстой, Лев Николаевич", "Л. Н. Толстой"},
+		{"", "", "Толстой Л.Н.", "Толстой, Л. Н.", "Л. Н. Толстой"},
+		{"Толстой", "", "Л. Н.", "Толстой, Л. Н.", "Л. Н. Толстой"},
+		{"ФЁДОР", "", "достоевский", "Достоевский, Фёдор", "Ф. Достоевский"},
+		{"Jean-Paul Sartre", "", "", "Sartre, Jean-Paul", "J.-P. Sartre"},
+		{"Ludwig van Beethoven", "", "", "van Beethoven, Ludwig", "L. van Beethoven"},
+		{"Ludwig van", "", "Beethoven", "van Beethoven, Ludwig", "L. van Beethoven"},
+		{"", "", "Beethoven, Ludwig van", "van Beethoven, Ludwig", "L. van Beethoven"},
+		{"LUDWIG VAN BEETHOVEN", "", "", "van Beethoven, Ludwig", "L. van Beethoven"},
+		{"Van", "", "Morrison", "Morrison, Van", "V. Morrison"},
+		{"Leonardo da Vinci", "", "", "da Vinci, Leonardo", "L. da Vinci"},
+	}
+	for _, tt := range tests {
+		a := newAuthor(tt.first, tt.middle, tt.last)
+		a.Normalize()
+		if got := a.SortName(); got != tt.want {
+			t.Errorf("%q %q %q normalized to %q, want %q", tt.first, tt.middle, tt.last, got, tt.want)
+		}
+		if got := a.ShortName(); got != tt.short {
+			t.Errorf("short name of %q is %q, want %q", tt.want, got, tt.short)
+		}
+	}
+}
+
+func TestAuthorKey(t *testing.T) {
+	a := newAuthor("Фёдор", "Михайлович", "Достоевский")
+	b := newAuthor("федор", "михайлович", "ДОСТОЕВСКИЙ.")
+	if a.Key() != b.Key() || a.Key() != "достоевский федор михайлович" {
+		t.Errorf("keys %q and %q, want %q", a.Key(), b.Key(), "достоевский федор михайлович")
+	}
+	nick := &Author{Nickname: textField("nickname", "Козьма-Прутков")}
+	if got := nick.Key(); got != "козьма прутков" {
+		t.Errorf("key of nickname is %q", got)
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Щедрин", "Shchedrin"},
+		{"ЩЕДРИН", "SHCHEDRIN"},
+		{"Юрий Ёлкин", "Yuriy Elkin"},
+		{"Їжак і Єнот", "Yizhak i Yenot"},
+		{"объём, 2-й", "obem, 2-y"},
+		{"Tolstoy", "Tolstoy"},
+	}
+	for _, tt := range tests {
+		if got := Transliterate(tt.in); got != tt.want {
+			t.Errorf("%q transliterated to %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeAuthors(t *testing.T) {
+	d := &Description{TitleInfo: &TitleInfo{}}
+	a := newAuthor("Фёдор", "", "Достоевский")
+	a.HomePages = []string{"http://a"}
+	b := newAuthor("федор ДОСТОЕВСКИЙ", "", "")
+	b.HomePages = []string{"http://a", "http://b"}
+	b.Emails = []string{"f@d"}
+	b.ID = "fd"
+	c := newAuthor("Лев", "", "Толстой")
+	d.TitleInfo.Authors = []*Author{a, b, c}
+	NormalizeAuthors(d)
+	authors := d.TitleInfo.Authors
+	if len(authors) != 2 || authors[0] != a || authors[1] != c {
+		t.Fatalf("authors = %v", authorNames(authors))
+	}
+	if len(a.HomePages) != 2 || len(a.Emails) != 1 || a.ID != "fd" {
+		t.Errorf("duplicate is not merged: %v %v %q", a.HomePages, a.Emails, a.ID)
+	}
+}
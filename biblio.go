@@ -0,0 +1,479 @@
+package gofb2
+
+import (
+	"encoding/json"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// bibRecord is a book description prepared for bibliographic formats
+type bibRecord struct {
+	id, title, lang      string
+	srcTitle, srcLang    string
+	authors, translators []*Author
+	genres, keywords     []string
+	annotation           string
+	publisher, city      string
+	year                 int
+	// written are the first and the last years of writing from title-info,
+	// they are not years of publication
+	written []int
+	isbns   []ISBN
+	series  []SeriesEntry
+}
+
+func newBibRecord(d *Description) *bibRecord {
+	if d == nil {
+		d = &Description{}
+	}
+	m := NewMetadata(&FictionBook{Description: d})
+	r := &bibRecord{
+		title:      m.Title(),
+		lang:       m.Lang(),
+		genres:     m.Genres(),
+		keywords:   m.Keywords(),
+		annotation: m.Annotation(),
+		publisher:  m.Publisher(),
+		year:       m.Year(),
+		isbns:      m.ISBNs(),
+		series:     m.Series(),
+		id:         m.DocumentID(),
+	}
+	r.srcTitle, r.srcLang = originalTitle(d)
+	if ti := d.TitleInfo; ti != nil {
+		r.authors, r.translators = ti.Authors, ti.Translators
+		if ti.Date != nil {
+			if dr, ok := ti.Date.Range(); ok {
+				r.written = []int{dr.From.Year()}
+				if to := dr.To.End().Year(); to != dr.From.Year() {
+					r.written = append(r.written, to)
+				}
+			}
+		}
+	}
+	if pi := d.PublishInfo; pi != nil {
+		r.city = fieldValue(pi.City)
+		if r.title == "" {
+			r.title = fieldValue(pi.BookName)
+		}
+	}
+	if r.id == "" {
+		r.id = hashUUID([]byte(r.title))
+	}
+	return r
+}
+
+// originalTitle return title and language of original book
+// of translation
+func originalTitle(d *Description) (title, lang string) {
+	if d.SrcTitleInfo != nil {
+		title, lang = fieldValue(d.SrcTitleInfo.BookTitle), strings.TrimSpace(d.SrcTitleInfo.Lang)
+	}
+	if lang == "" && d.TitleInfo != nil {
+		lang = strings.TrimSpace(d.TitleInfo.SrcLang)
+	}
+	return title, lang
+}
+
+func (r *bibRecord) yearString() string {
+	if r.year == 0 {
+		return ""
+	}
+	return strconv.Itoa(r.year)
+}
+
+func writeString(w io.Writer, s string) error {
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+// WriteDublinCore write description to w as Dublin Core XML record
+// in OAI-PMH oai_dc format
+func WriteDublinCore(w io.Writer, d *Description) error {
+	r := newBibRecord(d)
+	var b strings.Builder
+	meta := newMeta(&b)
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	meta("dc:title", r.title)
+	for _, a := range r.authors {
+		meta("dc:creator", a.SortName())
+	}
+	for _, a := range r.translators {
+		meta("dc:contributor", a.SortName())
+	}
+	for _, g := range r.genres {
+		if info, ok := LookupGenre(g); ok {
+			g = info.En
+		}
+		meta("dc:subject", g)
+	}
+	for _, k := range r.keywords {
+		meta("dc:subject", k)
+	}
+	meta("dc:description", r.annotation)
+	meta("dc:publisher", r.publisher)
+	meta("dc:date", r.yearString())
+	meta("dc:type", "Text")
+	meta("dc:identifier", r.id)
+	for _, isbn := range r.isbns {
+		meta("dc:identifier", "urn:isbn:"+string(isbn))
+	}
+	if r.srcLang != "" {
+		meta("dc:source", r.srcTitle, "xml:lang", r.srcLang)
+	} else {
+		meta("dc:source", r.srcTitle)
+	}
+	meta("dc:language", r.lang)
+	for _, s := range r.series {
+		meta("dc:relation", s.String())
+	}
+	b.WriteString("</oai_dc:dc>\n")
+	return writeString(w, b.String())
+}
+
+// WriteOPFMetadata write description to w as metadata element of OPF
+// package document, it is the same metadata as in EPUB
+func WriteOPFMetadata(w io.Writer, d *Description) error {
+	r := newBibRecord(d)
+	var b strings.Builder
+	meta := newMeta(&b)
+	b.WriteString(`<metadata xmlns="http://www.idpf.org/2007/opf" xmlns:dc="http://purl.org/dc/elements/1.1/">` + "\n")
+	meta("dc:identifier", r.id, "id", "bookid")
+	meta("dc:title", r.title)
+	meta("dc:language", r.lang)
+	opfDescription(meta, d)
+	b.WriteString("</metadata>\n")
+	return writeString(w, b.String())
+}
+
+var bibtexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"{", `\{`,
+	"}", `\}`,
+	"&", `\&`,
+	"%", `\%`,
+	"$", `\$`,
+	"#", `\#`,
+	"_", `\_`,
+	"~", `\textasciitilde{}`,
+	"^", `\textasciicircum{}`,
+)
+
+// bibtexName return name like "Last, First Middle", nickname is braced
+// to keep it as is
+func bibtexName(a *Author) string {
+	if fieldValue(a.LastName) == "" && fieldValue(a.FirstName) == "" {
+		return "{" + bibtexEscaper.Replace(fieldValue(a.Nickname)) + "}"
+	}
+	return bibtexEscaper.Replace(a.SortName())
+}
+
+// bibtexKey return citation key like "tolstoy1869war"
+func (r *bibRecord) bibtexKey() string {
+	var name, word string
+	if len(r.authors) > 0 {
+		name = r.authors[0].SortName()
+	}
+	for _, w := range strings.Fields(foldName(Transliterate(r.title))) {
+		if len([]rune(w)) > 3 {
+			word = w
+			break
+		}
+	}
+	key := strings.Fields(foldName(Transliterate(name)))
+	if len(key) > 1 {
+		key = key[:1]
+	}
+	key = append(key, r.yearString(), word)
+	res := strings.Map(func(c rune) rune {
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
+			return c
+		}
+		return -1
+	}, strings.Join(key, ""))
+	if res == "" {
+		return "book"
+	}
+	return res
+}
+
+// WriteBibTeX write description to w as BibTeX book entry, translator,
+// origtitle, origlanguage and origdate fields are biblatex ones
+func WriteBibTeX(w io.Writer, d *Description) error {
+	r := newBibRecord(d)
+	var b strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			b.WriteString("  " + name + " = {" + value + "},\n")
+		}
+	}
+	names := func(authors []*Author) string {
+		var res []string
+		for _, a := range authors {
+			if authorName(a) != "" {
+				res = append(res, bibtexName(a))
+			}
+		}
+		return strings.Join(res, " and ")
+	}
+	b.WriteString("@book{" + r.bibtexKey() + ",\n")
+	field("author", names(r.authors))
+	field("title", bibtexEscaper.Replace(r.title))
+	field("translator", names(r.translators))
+	field("origtitle", bibtexEscaper.Replace(r.srcTitle))
+	field("origlanguage", r.srcLang)
+	field("language", r.lang)
+	field("publisher", bibtexEscaper.Replace(r.publisher))
+	field("address", bibtexEscaper.Replace(r.city))
+	field("year", r.yearString())
+	var written []string
+	for _, y := range r.written {
+		written = append(written, strconv.Itoa(y))
+	}
+	field("origdate", strings.Join(written, "/"))
+	if len(r.isbns) > 0 {
+		field("isbn", r.isbns[0].String())
+	}
+	if len(r.series) > 0 {
+		field("series", bibtexEscaper.Replace(r.series[0].Name()))
+		field("number", r.series[0].Number)
+	}
+	field("keywords", bibtexEscaper.Replace(strings.Join(r.keywords, ", ")))
+	field("abstract", bibtexEscaper.Replace(strings.ReplaceAll(r.annotation, "\n", " ")))
+	b.WriteString("}\n")
+	return writeString(w, b.String())
+}
+
+// WriteRIS write description to w as RIS record of book
+func WriteRIS(w io.Writer, d *Description) error {
+	r := newBibRecord(d)
+	var b strings.Builder
+	tag := func(name, value string) {
+		if value = strings.TrimSpace(strings.ReplaceAll(value, "\n", " ")); value != "" {
+			b.WriteString(name + "  - " + value + "\r\n")
+		}
+	}
+	tag("TY", "BOOK")
+	tag("ID", r.id)
+	tag("TI", r.title)
+	for _, a := range r.authors {
+		tag("AU", a.SortName())
+	}
+	for _, a := range r.translators {
+		tag("A4", a.SortName())
+	}
+	tag("OP", r.srcTitle)
+	tag("LA", r.lang)
+	tag("PB", r.publisher)
+	tag("CY", r.city)
+	tag("PY", r.yearString())
+	for _, isbn := range r.isbns {
+		tag("SN", isbn.String())
+	}
+	if len(r.series) > 0 {
+		tag("T2", r.series[0].Name())
+		tag("SV", r.series[0].Number)
+	}
+	for _, k := range r.keywords {
+		tag("KW", k)
+	}
+	tag("AB", r.annotation)
+	b.WriteString("ER  - \r\n")
+	return writeString(w, b.String())
+}
+
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+type cslItem struct {
+	ID               string    `json:"id"`
+	Type             string    `json:"type"`
+	Title            string    `json:"title,omitempty"`
+	Author           []cslName `json:"author,omitempty"`
+	Translator       []cslName `json:"translator,omitempty"`
+	OriginalTitle    string    `json:"original-title,omitempty"`
+	Language         string    `json:"language,omitempty"`
+	Publisher        string    `json:"publisher,omitempty"`
+	PublisherPlace   string    `json:"publisher-place,omitempty"`
+	Issued           *cslDate  `json:"issued,omitempty"`
+	OriginalDate     *cslDate  `json:"original-date,omitempty"`
+	ISBN             string    `json:"ISBN,omitempty"`
+	CollectionTitle  string    `json:"collection-title,omitempty"`
+	CollectionNumber string    `json:"collection-number,omitempty"`
+	Abstract         string    `json:"abstract,omitempty"`
+	Keyword          string    `json:"keyword,omitempty"`
+}
+
+func cslNames(authors []*Author) []cslName {
+	var res []cslName
+	for _, a := range authors {
+		last := fieldValue(a.LastName)
+		given := strings.TrimSpace(fieldValue(a.FirstName) + " " + fieldValue(a.MiddleName))
+		switch {
+		case last != "":
+			res = append(res, cslName{Family: last, Given: given})
+		case authorName(a) != "":
+			res = append(res, cslName{Literal: authorName(a)})
+		}
+	}
+	return res
+}
+
+// WriteCSLJSON write description to w as CSL-JSON array with one item,
+// CSL has no variable for language of original
+func WriteCSLJSON(w io.Writer, d *Description) error {
+	r := newBibRecord(d)
+	item := cslItem{
+		ID:             r.id,
+		Type:           "book",
+		Title:          r.title,
+		Author:         cslNames(r.authors),
+		Translator:     cslNames(r.translators),
+		OriginalTitle:  r.srcTitle,
+		Language:       r.lang,
+		Publisher:      r.publisher,
+		PublisherPlace: r.city,
+		Abstract:       r.annotation,
+		Keyword:        strings.Join(r.keywords, ", "),
+	}
+	if r.year != 0 {
+		item.Issued = &cslDate{DateParts: [][]int{{r.year}}}
+	}
+	if len(r.written) > 0 {
+		item.OriginalDate = &cslDate{}
+		for _, y := range r.written {
+			item.OriginalDate.DateParts = append(item.OriginalDate.DateParts, []int{y})
+		}
+	}
+	var isbns []string
+	for _, isbn := range r.isbns {
+		isbns = append(isbns, isbn.String())
+	}
+	item.ISBN = strings.Join(isbns, " ")
+	if len(r.series) > 0 {
+		item.CollectionTitle = r.series[0].Name()
+		item.CollectionNumber = r.series[0].Number
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode([]cslItem{item})
+}
+
+// marcLangs are MARC codes of languages
+var marcLangs = map[string]string{
+	"ru": "rus", "uk": "ukr", "be": "bel", "en": "eng", "de": "ger",
+	"fr": "fre", "es": "spa", "it": "ita", "pt": "por", "pl": "pol",
+	"cs": "cze", "sk": "slo", "bg": "bul", "sr": "srp", "nl": "dut",
+	"sv": "swe", "fi": "fin", "el": "gre", "tr": "tur", "kk": "kaz",
+	"ja": "jpn", "zh": "chi", "la": "lat",
+}
+
+// marcLang return MARC code of language, "und" for unknown language
+func marcLang(lang string) string {
+	lang = baseLang(strings.TrimSpace(lang))
+	if code, ok := marcLangs[lang]; ok {
+		return code
+	}
+	if len(lang) == 3 && strings.Trim(lang, "abcdefghijklmnopqrstuvwxyz") == "" {
+		return lang
+	}
+	return "und"
+}
+
+type marcWriter struct {
+	b strings.Builder
+}
+
+func (m *marcWriter) control(tag, value string) {
+	if value != "" {
+		m.b.WriteString(`<controlfield tag="` + tag + `">` + html.EscapeString(value) + "</controlfield>\n")
+	}
+}
+
+// field write data field, subfields are code-value pairs, empty
+// subfields are omitted
+func (m *marcWriter) field(tag, ind string, subfields ...string) {
+	var s strings.Builder
+	for i := 0; i+1 < len(subfields); i += 2 {
+		if subfields[i+1] != "" {
+			s.WriteString(`<subfield code="` + subfields[i] + `">` + html.EscapeString(subfields[i+1]) + "</subfield>")
+		}
+	}
+	if s.Len() > 0 {
+		m.b.WriteString(`<datafield tag="` + tag + `" ind1="` + ind[:1] + `" ind2="` + ind[1:] + `">` + s.String() + "</datafield>\n")
+	}
+}
+
+func (m *marcWriter) name(tag string, a *Author, relator string) {
+	ind := "1 "
+	if fieldValue(a.LastName) == "" {
+		// forename or nickname
+		ind = "0 "
+	}
+	m.field(tag, ind, "a", a.SortName(), "e", relator)
+}
+
+// WriteMARCXML write description to w as MARC 21 bibliographic record
+// in MARCXML
+func WriteMARCXML(w io.Writer, d *Description) error {
+	r := newBibRecord(d)
+	m := &marcWriter{}
+	m.b.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<record xmlns="http://www.loc.gov/MARC21/slim">
+<leader>00000nam a2200000 i 4500</leader>
+`)
+	m.control("001", r.id)
+	// fixed length data: date type and year, language
+	date := "nuuuu"
+	if r.year > 0 && r.year < 10000 {
+		date = "s" + strconv.Itoa(r.year + 10000)[1:]
+	}
+	m.control("008", "      "+date+"    xx "+strings.Repeat(" ", 11)+"000 | "+marcLang(r.lang)+" d")
+	for _, isbn := range r.isbns {
+		m.field("020", "  ", "a", string(isbn))
+	}
+	if r.srcLang != "" {
+		m.field("041", "1 ", "a", marcLang(r.lang), "h", marcLang(r.srcLang))
+	}
+	if len(r.authors) > 0 {
+		m.name("100", r.authors[0], "author")
+	}
+	if r.srcTitle != "" {
+		m.field("240", "10", "a", r.srcTitle)
+	}
+	ind := "00"
+	if len(r.authors) > 0 {
+		ind = "10"
+	}
+	m.field("245", ind, "a", r.title, "c", strings.Join(authorNames(r.authors), ", "))
+	m.field("264", " 1", "a", r.city, "b", r.publisher, "c", r.yearString())
+	for _, s := range r.series {
+		m.field("490", "0 ", "a", s.Name(), "v", s.Number)
+	}
+	m.field("520", "  ", "a", strings.ReplaceAll(r.annotation, "\n", " "))
+	for _, k := range r.keywords {
+		m.field("653", "  ", "a", k)
+	}
+	if len(r.authors) > 1 {
+		for _, a := range r.authors[1:] {
+			m.name("700", a, "author")
+		}
+	}
+	for _, a := range r.translators {
+		m.name("700", a, "translator")
+	}
+	m.b.WriteString("</record>\n")
+	return writeString(w, m.b.String())
+}
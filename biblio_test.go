@@ -0,0 +1,203 @@
+package gofb2
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const biblioBook = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0">
+<description><title-info><genre>prose_classic</genre>
+<author><first-name>Лев</first-name><middle-name>Николаевич</middle-name><last-name>Толстой</last-name></author>
+<book-title>Война и мир</book-title><annotation><p>Роман-эпопея.</p></annotation><keywords>война, история</keywords>
+<date>1863–1869</date><lang>ru</lang>
+<sequence name="Эпопея" number="1"/></title-info>
+<document-info><id>tolstoy-war-and-peace</id><version>1</version></document-info>
+<publish-info><publisher>Эксмо</publisher><city>Москва</city><year>2005</year><isbn>5-699-12014-9</isbn></publish-info>
+</description></FictionBook>`
+
+var biblioFormats = []struct {
+	name  string
+	write func(io.Writer, *Description) error
+	want  string
+}{
+	{"DublinCore", WriteDublinCore, `<?xml version="1.0" encoding="utf-8"?>
+<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>Война и мир</dc:title>
+<dc:creator>Толстой, Лев Николаевич</dc:creator>
+<dc:subject>Classics Prose</dc:subject>
+<dc:subject>война</dc:subject>
+<dc:subject>история</dc:subject>
+<dc:description>Роман-эпопея.</dc:description>
+<dc:publisher>Эксмо</dc:publisher>
+<dc:date>2005</dc:date>
+<dc:type>Text</dc:type>
+<dc:identifier>tolstoy-war-and-peace</dc:identifier>
+<dc:identifier>urn:isbn:9785699120147</dc:identifier>
+<dc:language>ru</dc:language>
+<dc:relation>Эпопея #1</dc:relation>
+</oai_dc:dc>
+`},
+	{"OPFMetadata", WriteOPFMetadata, `<metadata xmlns="http://www.idpf.org/2007/opf" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:identifier id="bookid">tolstoy-war-and-peace</dc:identifier>
+<dc:title>Война и мир</dc:title>
+<dc:language>ru</dc:language>
+<dc:creator id="creator1">Лев Николаевич Толстой</dc:creator>
+<meta refines="#creator1" property="role" scheme="marc:relators">aut</meta>
+<meta refines="#creator1" property="file-as">Толстой, Лев</meta>
+<dc:subject>prose_classic</dc:subject>
+<dc:subject id="subject1">Classics Prose</dc:subject>
+<meta refines="#subject1" property="authority">BISAC</meta>
+<meta refines="#subject1" property="term">FIC004000</meta>
+<dc:subject id="subject2">Classics Prose</dc:subject>
+<meta refines="#subject2" property="authority">THEMA</meta>
+<meta refines="#subject2" property="term">FBC</meta>
+<dc:description>Роман-эпопея.</dc:description>
+<meta property="belongs-to-collection" id="collection1">Эпопея</meta>
+<meta refines="#collection1" property="collection-type">series</meta>
+<meta refines="#collection1" property="group-position">1</meta>
+<dc:publisher>Эксмо</dc:publisher>
+<dc:identifier id="isbn">urn:isbn:9785699120147</dc:identifier>
+<dc:date>2005</dc:date>
+</metadata>
+`},
+	{"BibTeX", WriteBibTeX, `@book{tolstoy2005voyna,
+  author = {Толстой, Лев Николаевич},
+  title = {Война и мир},
+  language = {ru},
+  publisher = {Эксмо},
+  address = {Москва},
+  year = {2005},
+  origdate = {1863/1869},
+  isbn = {978-5-699-12014-7},
+  series = {Эпопея},
+  number = {1},
+  keywords = {война, история},
+  abstract = {Роман-эпопея.},
+}
+`},
+	{"RIS", WriteRIS, `TY  - BOOK
+ID  - tolstoy-war-and-peace
+TI  - Война и мир
+AU  - Толстой, Лев Николаевич
+LA  - ru
+PB  - Эксмо
+CY  - Москва
+PY  - 2005
+SN  - 978-5-699-12014-7
+T2  - Эпопея
+SV  - 1
+KW  - война
+KW  - история
+AB  - Роман-эпопея.
+ER  - 
+`},
+	{"CSLJSON", WriteCSLJSON, `[
+  {
+    "id": "tolstoy-war-and-peace",
+    "type": "book",
+    "title": "Война и мир",
+    "author": [
+      {
+        "family": "Толстой",
+        "given": "Лев Николаевич"
+      }
+    ],
+    "language": "ru",
+    "publisher": "Эксмо",
+    "publisher-place": "Москва",
+    "issued": {
+      "date-parts": [
+        [
+          2005
+        ]
+      ]
+    },
+    "original-date": {
+      "date-parts": [
+        [
+          1863
+        ],
+        [
+          1869
+        ]
+      ]
+    },
+    "ISBN": "978-5-699-12014-7",
+    "collection-title": "Эпопея",
+    "collection-number": "1",
+    "abstract": "Роман-эпопея.",
+    "keyword": "война, история"
+  }
+]
+`},
+	{"MARCXML", WriteMARCXML, `<?xml version="1.0" encoding="utf-8"?>
+<record xmlns="http://www.loc.gov/MARC21/slim">
+<leader>00000nam a2200000 i 4500</leader>
+<controlfield tag="001">tolstoy-war-and-peace</controlfield>
+<controlfield tag="008">      s2005    xx            000 | rus d</controlfield>
+<datafield tag="020" ind1=" " ind2=" "><subfield code="a">9785699120147</subfield></datafield>
+<datafield tag="100" ind1="1" ind2=" "><subfield code="a">Толстой, Лев Николаевич</subfield><subfield code="e">author</subfield></datafield>
+<datafield tag="245" ind1="1" ind2="0"><subfield code="a">Война и мир</subfield><subfield code="c">Лев Николаевич Толстой</subfield></datafield>
+<datafield tag="264" ind1=" " ind2="1"><subfield code="a">Москва</subfield><subfield code="b">Эксмо</subfield><subfield code="c">2005</subfield></datafield>
+<datafield tag="490" ind1="0" ind2=" "><subfield code="a">Эпопея</subfield><subfield code="v">1</subfield></datafield>
+<datafield tag="520" ind1=" " ind2=" "><subfield code="a">Роман-эпопея.</subfield></datafield>
+<datafield tag="653" ind1=" " ind2=" "><subfield code="a">война</subfield></datafield>
+<datafield tag="653" ind1=" " ind2=" "><subfield code="a">история</subfield></datafield>
+</record>
+`},
+}
+
+// writeBiblio return record of format, RIS lines end with CRLF
+func writeBiblio(t *testing.T, write func(io.Writer, *Description) error, d *Description, ris bool) string {
+	t.Helper()
+	var b strings.Builder
+	if err := write(&b, d); err != nil {
+		t.Fatal(err)
+	}
+	s := b.String()
+	if ris {
+		if strings.Count(s, "\n") != strings.Count(s, "\r\n") {
+			t.Errorf("RIS lines do not end with CRLF")
+		}
+		s = strings.ReplaceAll(s, "\r\n", "\n")
+	}
+	return s
+}
+
+func TestBiblio(t *testing.T) {
+	d := parseBook(t, biblioBook).Description
+	for _, f := range biblioFormats {
+		if got := writeBiblio(t, f.write, d, f.name == "RIS"); got != f.want {
+			t.Errorf("%s:\n%s\nwant:\n%s", f.name, got, f.want)
+		}
+	}
+}
+
+func TestBiblioNoYear(t *testing.T) {
+	// date of writing is not a year of publication
+	d := parseBook(t, strings.Replace(biblioBook, "<year>2005</year>", "", 1)).Description
+	for _, f := range biblioFormats {
+		got := writeBiblio(t, f.write, d, f.name == "RIS")
+		for _, s := range []string{"2005", "<dc:date>", "year =", "PY  -", `"issued"`, `<subfield code="c">1863`} {
+			if strings.Contains(got, s) {
+				t.Errorf("%s: %q in\n%s", f.name, s, got)
+			}
+		}
+	}
+	marc := writeBiblio(t, WriteMARCXML, d, false)
+	if !strings.Contains(marc, `<controlfield tag="008">      nuuuu    xx            000 | rus d</controlfield>`) {
+		t.Errorf("008 of book without year:\n%s", marc)
+	}
+}
+
+func TestMARC008(t *testing.T) {
+	for _, d := range []*Description{parseBook(t, biblioBook).Description, nil} {
+		marc := writeBiblio(t, WriteMARCXML, d, false)
+		start := strings.Index(marc, `<controlfield tag="008">`) + len(`<controlfield tag="008">`)
+		end := strings.Index(marc[start:], "<")
+		if n := len([]rune(marc[start : start+end])); n != 40 {
+			t.Errorf("008 has %d characters: %q", n, marc[start:start+end])
+		}
+	}
+}
@@ -0,0 +1,583 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BookBuilder construct FictionBook step by step:
+//
+//	fb := NewBook().Title("Book").Author("Leo Tolstoy").Lang("en").
+//		Section("Chapter 1").P("Text with ", Emphasis("style"), Note("note")).
+//		Section("Chapter 2").P("More text").
+//		Build()
+//
+// Sections get generated ids, notes and images given by data become
+// notes body sections and binaries. Missing required description fields
+// are filled by Build, errors like duplicate ids are reported by Err.
+type BookBuilder struct {
+	fb       *FictionBook
+	ids      map[string]bool
+	counters map[string]int
+	date     time.Time
+	creator  string
+	// err is the first error of building, like duplicate id
+	err error
+}
+
+// SectionBuilder add content to section of book
+type SectionBuilder struct {
+	book    *BookBuilder
+	parent  *SectionBuilder
+	section *Section
+	// tail is an untitled section for content added after subsections
+	tail *Section
+}
+
+// NewBook return builder of empty book
+func NewBook() *BookBuilder {
+	fb := &FictionBook{}
+	fb.SetXMLName(xml.Name{Local: "FictionBook"})
+	fb.Description = &Description{}
+	fb.Description.SetXMLName(xml.Name{Local: "description"})
+	ti := &TitleInfo{}
+	ti.SetXMLName(xml.Name{Local: "title-info"})
+	fb.Description.TitleInfo = ti
+	fb.Body = &Body{}
+	fb.Body.SetXMLName(xml.Name{Local: "body"})
+	return &BookBuilder{fb: fb, ids: map[string]bool{}, counters: map[string]int{}}
+}
+
+func (b *BookBuilder) titleInfo() *TitleInfo {
+	return b.fb.Description.TitleInfo
+}
+
+// newID return unique id with prefix and next number
+func (b *BookBuilder) newID(prefix string) string {
+	for {
+		b.counters[prefix]++
+		id := prefix + strconv.Itoa(b.counters[prefix])
+		if !b.ids[id] {
+			b.ids[id] = true
+			return id
+		}
+	}
+}
+
+// Title set book title
+func (b *BookBuilder) Title(title string) *BookBuilder {
+	b.titleInfo().BookTitle = textField("book-title", title)
+	return b
+}
+
+// Author add author, name is "First Middle Last" or "Last, First Middle"
+func (b *BookBuilder) Author(name string) *BookBuilder {
+	ti := b.titleInfo()
+	ti.Authors = append(ti.Authors, personAuthor("author", name, name))
+	return b
+}
+
+// Translator add translator, name format is the same as for Author
+func (b *BookBuilder) Translator(name string) *BookBuilder {
+	ti := b.titleInfo()
+	ti.Translators = append(ti.Translators, personAuthor("translator", name, name))
+	return b
+}
+
+// Genre add genres
+func (b *BookBuilder) Genre(genres ...string) *BookBuilder {
+	ti := b.titleInfo()
+	for _, genre := range genres {
+		g := &Genre{Genre: genre}
+		g.SetXMLName(xml.Name{Local: "genre"})
+		ti.Genres = append(ti.Genres, g)
+	}
+	return b
+}
+
+// Lang set book language
+func (b *BookBuilder) Lang(lang string) *BookBuilder {
+	b.titleInfo().Lang = lang
+	return b
+}
+
+// Keywords set comma separated keywords
+func (b *BookBuilder) Keywords(keywords ...string) *BookBuilder {
+	b.titleInfo().Keywords = textField("keywords", strings.Join(keywords, ", "))
+	return b
+}
+
+// Date set date when book was written
+func (b *BookBuilder) Date(date time.Time) *BookBuilder {
+	d := &Date{Value: &XMLDate{Time: date}, StrValue: date.Format(dateFormat)}
+	d.SetXMLName(xml.Name{Local: "date"})
+	b.titleInfo().Date = d
+	return b
+}
+
+// DocumentDate set date of document used by Build, default is current time
+func (b *BookBuilder) DocumentDate(date time.Time) *BookBuilder {
+	b.date = date
+	return b
+}
+
+// DocumentAuthor set creator of FB2 file used by Build, default is
+// program name
+func (b *BookBuilder) DocumentAuthor(name string) *BookBuilder {
+	b.creator = name
+	return b
+}
+
+// Sequence add series of book with number like "2" or "1.5", empty
+// number is not set
+func (b *BookBuilder) Sequence(name, number string) *BookBuilder {
+	s := &Sequence{Name: name}
+	s.SetXMLName(xml.Name{Local: "sequence"})
+	if number != "" {
+		n, err := ParseSequenceNumber(number)
+		if err != nil && b.err == nil {
+			b.err = err
+		}
+		if err == nil {
+			s.SetNumber(n)
+		}
+	}
+	ti := b.titleInfo()
+	ti.Sequences = append(ti.Sequences, s)
+	return b
+}
+
+// Annotation add annotation paragraphs, paragraph is a string, a *P or
+// []interface{} with content of P
+func (b *BookBuilder) Annotation(paragraphs ...interface{}) *BookBuilder {
+	ti := b.titleInfo()
+	if ti.Annotation == nil {
+		ti.Annotation = &Annotation{}
+		ti.Annotation.SetXMLName(xml.Name{Local: "annotation"})
+	}
+	for _, p := range b.paragraphs("p", paragraphs) {
+		ti.Annotation.appendContent(p)
+	}
+	return b
+}
+
+// Cover set cover page image
+func (b *BookBuilder) Cover(contentType string, data []byte) *BookBuilder {
+	c := &Coverpage{}
+	c.SetXMLName(xml.Name{Local: "coverpage"})
+	c.Image = &InlineImage{XlinkHref: "#" + b.binary("cover", contentType, data)}
+	c.Image.SetXMLName(xml.Name{Local: "image"})
+	b.titleInfo().Coverpage = c
+	return b
+}
+
+// binary add binary and return its id
+func (b *BookBuilder) binary(prefix, contentType string, data []byte) string {
+	ext := strings.TrimSuffix(strings.TrimPrefix(contentType, "image/"), "+xml")
+	if ext == "jpeg" {
+		ext = "jpg"
+	}
+	id := b.newID(prefix)
+	if ext != "" && ext != contentType {
+		id += "." + ext
+		b.ids[id] = true
+	}
+	bin := &Binary{ID: id, ContentType: contentType, Value: data}
+	bin.SetXMLName(xml.Name{Local: "binary"})
+	b.fb.Binary = append(b.fb.Binary, bin)
+	return id
+}
+
+// Section add top level section with title, empty title means untitled section
+func (b *BookBuilder) Section(title string) *SectionBuilder {
+	s := b.newSection(title)
+	b.fb.Body.Sections = append(b.fb.Body.Sections, s)
+	return &SectionBuilder{book: b, section: s}
+}
+
+func (b *BookBuilder) newSection(title string) *Section {
+	s := newSection()
+	s.ID = b.newID("section")
+	if title != "" {
+		s.Title = textTitle(title)
+	}
+	return s
+}
+
+// Build return constructed book with completed description
+func (b *BookBuilder) Build() *FictionBook {
+	var text strings.Builder
+	if t := b.titleInfo().BookTitle; t != nil {
+		text.WriteString(t.Value)
+	}
+	walk(bodyChildren(b.fb.Body), func(c Contenter) bool {
+		if cd, ok := c.(CharData); ok {
+			text.Write(cd)
+		}
+		return true
+	})
+	fillDescription(b.fb.Description, b.date, b.creator, []byte(text.String()))
+	return b.fb
+}
+
+// Err return the first error of building like duplicate section id
+func (b *BookBuilder) Err() error {
+	return b.err
+}
+
+// noteRef is a footnote created by Note, builder replace it with link
+type noteRef struct {
+	Link
+	note []interface{}
+}
+
+// imageRef is an inline image created by InlineImg, builder replace it
+// with image linked to binary
+type imageRef struct {
+	InlineImage
+	contentType string
+	data        []byte
+}
+
+// Note return footnote reference for P, content is the text of note
+func Note(content ...interface{}) Contenter {
+	return &noteRef{note: content}
+}
+
+// InlineImg return inline image for P with data stored in binary
+func InlineImg(contentType string, data []byte, alt string) Contenter {
+	return &imageRef{InlineImage: InlineImage{Alt: alt}, contentType: contentType, data: data}
+}
+
+// inlines convert strings and nodes to inline content
+func inlines(content []interface{}) []Contenter {
+	var res []Contenter
+	for _, c := range content {
+		switch e := c.(type) {
+		case string:
+			res = append(res, CharData(e))
+		case []byte:
+			res = append(res, CharData(e))
+		case Contenter:
+			res = append(res, e)
+		default:
+			res = append(res, CharData(fmt.Sprint(e)))
+		}
+	}
+	return res
+}
+
+func style(name string, content []interface{}) *StyleType {
+	s := &StyleType{}
+	s.SetXMLName(xml.Name{Local: name})
+	s.Content = inlines(content)
+	return s
+}
+
+// Strong return strong text
+func Strong(content ...interface{}) *StyleType {
+	return style("strong", content)
+}
+
+// Emphasis return emphasized text
+func Emphasis(content ...interface{}) *StyleType {
+	return style("emphasis", content)
+}
+
+// Strikethrough return strikethrough text
+func Strikethrough(content ...interface{}) *StyleType {
+	return style("strikethrough", content)
+}
+
+// Sub return subscript text
+func Sub(content ...interface{}) *StyleType {
+	return style("sub", content)
+}
+
+// Sup return superscript text
+func Sup(content ...interface{}) *StyleType {
+	return style("sup", content)
+}
+
+// Code return code text
+func Code(content ...interface{}) *StyleType {
+	return style("code", content)
+}
+
+// Styled return text with named style from stylesheet
+func Styled(name string, content ...interface{}) *NamedStyleType {
+	s := &NamedStyleType{Name: name}
+	s.SetXMLName(xml.Name{Local: "style"})
+	s.Content = inlines(content)
+	return s
+}
+
+// Hyperlink return link to href, use "#id" for links inside book
+func Hyperlink(href string, content ...interface{}) *Link {
+	l := &Link{XlinkHref: href}
+	l.SetXMLName(xml.Name{Local: "a"})
+	l.Content = inlines(content)
+	return l
+}
+
+// resolve replace notes and images of inline content, styles inside
+// links are converted to link styles
+func (b *BookBuilder) resolve(cont []Contenter, inLink bool) []Contenter {
+	res := make([]Contenter, 0, len(cont))
+	for _, c := range cont {
+		switch e := c.(type) {
+		case *noteRef:
+			id := b.newID("n")
+			s := newSection()
+			s.ID = id
+			p := newP("p")
+			// notes inside note are added first
+			p.Content = b.resolve(inlines(e.note), false)
+			s.appendContent(p)
+			if b.fb.NotesBody == nil {
+				b.fb.NotesBody = &NotesBody{Name: "notes"}
+				b.fb.NotesBody.SetXMLName(xml.Name{Local: "body"})
+			}
+			b.fb.NotesBody.Sections = append(b.fb.NotesBody.Sections, s)
+			// number of note is its position in notes body, ids may skip
+			// numbers used by other nodes
+			n := strconv.Itoa(len(b.fb.NotesBody.Sections))
+			s.Title = textTitle(n)
+			l := Hyperlink("#"+id, "["+n+"]")
+			l.Type = "note"
+			if inLink {
+				res = append(res, l.Content...)
+			} else {
+				res = append(res, l)
+			}
+		case *imageRef:
+			i := &InlineImage{XlinkHref: "#" + b.binary("image", e.contentType, e.data), Alt: e.Alt}
+			i.SetXMLName(xml.Name{Local: "image"})
+			res = append(res, i)
+		case *Link:
+			e.Content = b.resolve(e.Content, true)
+			res = append(res, e)
+		case *StyleType:
+			if inLink {
+				s := &StyleLinkType{}
+				s.SetXMLName(e.GetXMLName())
+				s.Content = b.resolve(e.Content, true)
+				res = append(res, s)
+				continue
+			}
+			e.Content = b.resolve(e.Content, false)
+			res = append(res, e)
+		case *NamedStyleType:
+			if inLink {
+				s := &StyleLinkType{}
+				s.SetXMLName(xml.Name{Local: "style"})
+				s.Content = b.resolve(e.Content, true)
+				res = append(res, s)
+				continue
+			}
+			e.Content = b.resolve(e.Content, false)
+			res = append(res, e)
+		default:
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// paragraphs return paragraph for every item, item is a string, a *P
+// or a slice of P content
+func (b *BookBuilder) paragraphs(name string, items []interface{}) []*P {
+	var res []*P
+	for _, item := range items {
+		p, ok := item.(*P)
+		if !ok {
+			p = newP(name)
+			if content, ok := item.([]interface{}); ok {
+				p.Content = inlines(content)
+			} else {
+				p.Content = inlines([]interface{}{item})
+			}
+		}
+		p.Content = b.resolve(p.Content, false)
+		res = append(res, p)
+	}
+	return res
+}
+
+// Node return built section
+func (s *SectionBuilder) Node() *Section {
+	return s.section
+}
+
+// Book return builder of book
+func (s *SectionBuilder) Book() *BookBuilder {
+	return s.book
+}
+
+// Build return constructed book, see BookBuilder.Build
+func (s *SectionBuilder) Build() *FictionBook {
+	return s.book.Build()
+}
+
+// ID set section id instead of generated one, id used by other node
+// is an error reported by Err and section keep its id
+func (s *SectionBuilder) ID(id string) *SectionBuilder {
+	if id == s.section.ID {
+		return s
+	}
+	if id == "" || s.book.ids[id] {
+		if s.book.err == nil {
+			s.book.err = fmt.Errorf("invalid or duplicate section id %q", id)
+		}
+		return s
+	}
+	delete(s.book.ids, s.section.ID)
+	s.section.ID = id
+	s.book.ids[id] = true
+	return s
+}
+
+// Err return the first error of building, see BookBuilder.Err
+func (s *SectionBuilder) Err() error {
+	return s.book.err
+}
+
+// Section add sibling section after current one
+func (s *SectionBuilder) Section(title string) *SectionBuilder {
+	if s.parent == nil {
+		return s.book.Section(title)
+	}
+	return s.parent.Subsection(title)
+}
+
+// Subsection add nested section, content added before goes to
+// untitled first subsection
+func (s *SectionBuilder) Subsection(title string) *SectionBuilder {
+	sec := s.section
+	if len(sec.Content) > 0 {
+		first := newSection()
+		first.Content = sec.Content
+		sec.Content = nil
+		sec.Sections = append(sec.Sections, first)
+	}
+	s.tail = nil
+	child := s.book.newSection(title)
+	sec.Sections = append(sec.Sections, child)
+	return &SectionBuilder{book: s.book, parent: s, section: child}
+}
+
+// End return builder of parent section or nil for top level section
+func (s *SectionBuilder) End() *SectionBuilder {
+	return s.parent
+}
+
+// add append block to section, content after subsections goes to
+// untitled section
+func (s *SectionBuilder) add(c Contenter) *SectionBuilder {
+	if len(s.section.Sections) == 0 {
+		s.section.appendContent(c)
+		return s
+	}
+	if s.tail == nil {
+		s.tail = newSection()
+		s.section.Sections = append(s.section.Sections, s.tail)
+	}
+	s.tail.appendContent(c)
+	return s
+}
+
+// P add paragraph, content items are strings or inline nodes created by
+// Strong, Emphasis, Hyperlink, Note, InlineImg and others
+func (s *SectionBuilder) P(content ...interface{}) *SectionBuilder {
+	return s.add(s.book.paragraphs("p", []interface{}{content})[0])
+}
+
+// Subtitle add subtitle, content is the same as for P
+func (s *SectionBuilder) Subtitle(content ...interface{}) *SectionBuilder {
+	return s.add(s.book.paragraphs("subtitle", []interface{}{content})[0])
+}
+
+// EmptyLine add empty line
+func (s *SectionBuilder) EmptyLine() *SectionBuilder {
+	el := &EmptyLine{}
+	el.SetXMLName(xml.Name{Local: "empty-line"})
+	return s.add(el)
+}
+
+// Image add image with data stored in binary
+func (s *SectionBuilder) Image(contentType string, data []byte, alt string) *SectionBuilder {
+	i := &Image{XlinkHref: "#" + s.book.binary("image", contentType, data), Alt: alt}
+	i.SetXMLName(xml.Name{Local: "image"})
+	return s.add(i)
+}
+
+// Epigraph add section epigraph, paragraphs are the same as for Cite
+func (s *SectionBuilder) Epigraph(author string, paragraphs ...interface{}) *SectionBuilder {
+	ep := &Epigraph{}
+	ep.SetXMLName(xml.Name{Local: "epigraph"})
+	for _, p := range s.book.paragraphs("p", paragraphs) {
+		ep.appendContent(p)
+	}
+	if author != "" {
+		ep.TextAuthor = s.book.paragraphs("text-author", []interface{}{author})
+	}
+	s.section.Epigraphs = append(s.section.Epigraphs, ep)
+	return s
+}
+
+// Cite add citation, paragraph is a string, a *P or []interface{} with
+// content of P, empty author is omitted
+func (s *SectionBuilder) Cite(author string, paragraphs ...interface{}) *SectionBuilder {
+	c := &Cite{}
+	c.SetXMLName(xml.Name{Local: "cite"})
+	for _, p := range s.book.paragraphs("p", paragraphs) {
+		c.appendContent(p)
+	}
+	if author != "" {
+		c.TextAuthor = s.book.paragraphs("text-author", []interface{}{author})
+	}
+	return s.add(c)
+}
+
+// Poem add poem with stanzas of verse lines, empty title is omitted
+func (s *SectionBuilder) Poem(title string, stanzas ...[]string) *SectionBuilder {
+	p := newPoem()
+	if title != "" {
+		p.Title = textTitle(title)
+	}
+	for _, lines := range stanzas {
+		st := newStanza()
+		for _, l := range lines {
+			v := newP("v")
+			v.Content = []Contenter{CharData(l)}
+			st.V = append(st.V, v)
+		}
+		p.appendContent(st)
+	}
+	return s.add(p)
+}
+
+// Table add table, cells of the first row are headers
+func (s *SectionBuilder) Table(rows ...[]string) *SectionBuilder {
+	t := &Table{}
+	t.SetXMLName(xml.Name{Local: "table"})
+	for i, row := range rows {
+		tr := &TR{}
+		tr.SetXMLName(xml.Name{Local: "tr"})
+		for _, cell := range row {
+			td := &TD{}
+			if i == 0 {
+				td.SetXMLName(xml.Name{Local: "th"})
+			} else {
+				td.SetXMLName(xml.Name{Local: "td"})
+			}
+			td.Content = []Contenter{CharData(cell)}
+			tr.appendContent(td)
+		}
+		t.TR = append(t.TR, tr)
+	}
+	return s.add(t)
+}
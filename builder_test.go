@@ -0,0 +1,63 @@
+package gofb2
+
+import "testing"
+
+func TestBuildDocumentInfo(t *testing.T) {
+	fb := NewBook().Title("War and Peace").Author("Leo Tolstoy").
+		Section("Chapter 1").P("Text").Book().Build()
+	di := fb.Description.DocumentInfo
+	if di == nil || len(di.Authors) != 1 {
+		t.Fatalf("document-info = %+v", di)
+	}
+	if got := authorName(di.Authors[0]); got != programName {
+		t.Errorf("document author = %q, want %q", got, programName)
+	}
+	fb.Description.TitleInfo.Authors[0].LastName.Value = "Tolstoi"
+	if got := authorName(di.Authors[0]); got != programName {
+		t.Errorf("document author changed with book author: %q", got)
+	}
+	if di.ID == "" || di.Date == nil || di.ProgramUsed == nil {
+		t.Errorf("incomplete document-info %+v", di)
+	}
+
+	fb = NewBook().Title("Book").DocumentAuthor("Ivan Petrov").Build()
+	if a := fb.Description.DocumentInfo.Authors[0]; fieldValue(a.LastName) != "Petrov" {
+		t.Errorf("document author = %q", authorName(a))
+	}
+}
+
+func TestBuildNotes(t *testing.T) {
+	b := NewBook().Title("Book").Section("A").ID("n2").
+		P("One", Note("first"), " two", Note("second"))
+	fb := b.Build()
+	if err := b.Err(); err != nil {
+		t.Fatal(err)
+	}
+	notes := fb.NotesBody.Sections
+	if len(notes) != 2 {
+		t.Fatalf("notes = %d", len(notes))
+	}
+	// number follow notes body even when id "n2" is taken
+	for i, want := range []struct{ id, title string }{{"n1", "1"}, {"n3", "2"}} {
+		if notes[i].ID != want.id || titleText(notes[i].Title) != want.title {
+			t.Errorf("note %d = %s %q, want %s %q", i, notes[i].ID, titleText(notes[i].Title), want.id, want.title)
+		}
+	}
+	if got := contentText(fb.Body.Sections[0].Content); got != "One[1] two[2]" {
+		t.Errorf("text = %q", got)
+	}
+}
+
+func TestBuildDuplicateID(t *testing.T) {
+	b := NewBook().Section("A").ID("a").Section("B").ID("a")
+	if b.Err() == nil {
+		t.Error("no error for duplicate id")
+	}
+	fb := b.Build()
+	if fb.Body.Sections[1].ID == "a" {
+		t.Error("duplicate id is set")
+	}
+	if err := NewBook().Section("A").ID("a").ID("a").Err(); err != nil {
+		t.Errorf("error for the same id: %v", err)
+	}
+}
@@ -60,6 +60,29 @@ type Image struct {
 	emptyContent
 }
 
+func (i *Image) attrCallback(attr xml.Attr) error {
+	switch attr.Name.Local {
+	case "type":
+		i.XlinkType = attr.Value
+	case "href":
+		i.XlinkHref = attr.Value
+	case "alt":
+		i.Alt = attr.Value
+	case "title":
+		i.Title = attr.Value
+	case "id":
+		i.ID = attr.Value
+	default:
+		return i.emptyContent.attrCallback(attr)
+	}
+	return nil
+}
+
+// UnmarshalXML unmarshal XML to Image
+func (i *Image) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return NewParser(i).Parse(d, start)
+}
+
 // P https://github.com/gribuser/fb2/blob/14b5fcc6/FictionBook.xsd#L293
 // A basic paragraph, may include simple formatting inside
 type P struct {
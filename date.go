@@ -0,0 +1,161 @@
+package gofb2
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateRange is a period between two dates, From and To are equal
+// for a single date
+type DateRange struct {
+	From, To XMLDate
+}
+
+// dateRe match "12.05.1999", "12 May 1999", "May 1999", "1863–67",
+// "1999-05-12", "1999-05-12T10:00:00Z", "1999-05" and "1999"
+var dateRe = regexp.MustCompile(`(?i)\b(\d{1,2})\.(\d{1,2})\.(\d{4})\b` +
+	`|(?:^|[^\pL\d])(?:(\d{1,2})\s+)?(` + monthNames + `)\.?\s+(\d{4})\b` +
+	`|\b(\d{4})[–—](\d{2})\b` +
+	`|\b(\d{4})(?:-(\d{2})(?:-(\d{2})(T\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:\d{2})?)?)?)?\b`)
+
+// monthNames are English and Russian month names and their abbreviations
+const monthNames = `january|february|march|april|may|june|july|august|september|october|november|december` +
+	`|jan|feb|mar|apr|jun|jul|aug|sept?|oct|nov|dec` +
+	`|январ[ьяе]|феврал[ьяе]|март[ае]?|апрел[ьяе]|ма[йяе]|июн[ьяе]|июл[ьяе]|август[ае]?` +
+	`|сентябр[ьяе]|октябр[ьяе]|ноябр[ьяе]|декабр[ьяе]` +
+	`|янв|фев|февр|мар|апр|июн|июл|авг|сент?|окт|нояб?|дек`
+
+// monthStems are beginnings of month names in lower case
+var monthStems = []string{
+	"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	"янв", "фев", "мар", "апр", "ма", "июн", "июл", "авг", "сен", "окт", "ноя", "дек",
+}
+
+// ParseDateRange find dates in text like "1863-1869", "1863–1869 гг.",
+// "4 march 2005" or "2005-03-04" and return range from the earliest
+// to the latest one
+func ParseDateRange(s string) (DateRange, bool) {
+	var r DateRange
+	found := false
+	for _, m := range dateRe.FindAllStringSubmatch(s, -1) {
+		for _, d := range matchDates(m) {
+			if !found || d.Compare(r.From) < 0 {
+				r.From = d
+			}
+			if !found || d.End().After(r.To.End()) {
+				r.To = d
+			}
+			found = true
+		}
+	}
+	return r, found
+}
+
+// matchDates return dates of submatches of dateRe, "1863-67" and
+// "1863–67" are ranges of two years
+func matchDates(m []string) []XMLDate {
+	num := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	var year, month, day int
+	precision := PrecisionDay
+	switch {
+	case m[3] != "":
+		year, month, day = num(m[3]), num(m[2]), num(m[1])
+	case m[6] != "":
+		year, day = num(m[6]), num(m[4])
+		name := strings.ToLower(m[5])
+		for i, stem := range monthStems {
+			if strings.HasPrefix(name, stem) {
+				month = i%12 + 1
+				break
+			}
+		}
+		if day == 0 {
+			precision = PrecisionMonth
+		}
+	case m[7] != "":
+		return yearRange(num(m[7]), num(m[8]))
+	case m[12] != "":
+		if d, err := ParseDate(m[9] + "-" + m[10] + "-" + m[11] + m[12]); err == nil {
+			return []XMLDate{d}
+		}
+		// time without seconds and with zone, day is used
+		year, month, day = num(m[9]), num(m[10]), num(m[11])
+	default:
+		year, month, day = num(m[9]), num(m[10]), num(m[11])
+		if month > 12 && day == 0 {
+			// "1863-67" is a range of years
+			return yearRange(year, month)
+		}
+		switch {
+		case month == 0:
+			precision = PrecisionYear
+		case day == 0:
+			precision = PrecisionMonth
+		}
+	}
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Month() != time.Month(month) || t.Day() != day {
+		return nil
+	}
+	return []XMLDate{{Time: t, Precision: precision}}
+}
+
+// yearRange return range of years like "1863-67", end is two last digits
+func yearRange(year, end int) []XMLDate {
+	end += year / 100 * 100
+	if end < year {
+		return nil
+	}
+	return []XMLDate{yearDate(year), yearDate(end)}
+}
+
+func yearDate(year int) XMLDate {
+	return XMLDate{Time: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), Precision: PrecisionYear}
+}
+
+// String return date or ISO 8601 interval like "1863/1869"
+func (r DateRange) String() string {
+	if r.From == r.To {
+		return r.From.String()
+	}
+	return r.From.String() + "/" + r.To.String()
+}
+
+// Contains report whether time is in range
+func (r DateRange) Contains(t time.Time) bool {
+	return !t.Before(r.From.Time) && !t.After(r.To.End())
+}
+
+// ContainsYear report whether range overlaps year
+func (r DateRange) ContainsYear(year int) bool {
+	return r.From.Year() <= year && year <= r.To.End().Year()
+}
+
+// Range return period of date from its text, a value is used when
+// text is not a range
+func (d *Date) Range() (DateRange, bool) {
+	r, ok := ParseDateRange(d.StrValue)
+	if d.Value != nil && (!ok || r.From == r.To) {
+		return DateRange{From: *d.Value, To: *d.Value}, true
+	}
+	return r, ok
+}
+
+// Year return year of date value or of its text, 0 for unknown date
+func (d *Date) Year() int {
+	if r, ok := d.Range(); ok {
+		return r.From.Year()
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+package gofb2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in        string
+		want      string
+		precision DatePrecision
+	}{
+		{"1999", "1999", PrecisionYear},
+		{" 1999-05 ", "1999-05", PrecisionMonth},
+		{"1999-05-12", "1999-05-12", PrecisionDay},
+		{"1999-05-12+03:00", "1999-05-12", PrecisionDay},
+		{"2005-03-04T10:00:00+03:00", "2005-03-04T10:00:00+03:00", PrecisionTime},
+		{"2005-03-04T10:00:00", "2005-03-04T10:00:00Z", PrecisionTime},
+		{"2005-03-04 10:00:00", "2005-03-04T10:00:00Z", PrecisionTime},
+		{"2005-03-04T10:00:00.25Z", "2005-03-04T10:00:00.25Z", PrecisionTime},
+	}
+	for _, tt := range tests {
+		d, err := ParseDate(tt.in)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", tt.in, err)
+			continue
+		}
+		if d.String() != tt.want || d.Precision != tt.precision {
+			t.Errorf("ParseDate(%q) = %s %d, want %s %d", tt.in, d, d.Precision, tt.want, tt.precision)
+		}
+	}
+	for _, s := range []string{"", "May 1999", "1999-13-01", "12.05.1999"} {
+		if _, err := ParseDate(s); err == nil {
+			t.Errorf("ParseDate(%q) is valid", s)
+		}
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"1863-1869", "1863/1869"},
+		{"1863–1869 гг.", "1863/1869"},
+		{"1863-67", "1863/1867"},
+		{"1863–67", "1863/1867"},
+		{"4 march 2005", "2005-03-04"},
+		{"March 2005", "2005-03"},
+		{"12.05.1999", "1999-05-12"},
+		{"ок. 4 мая 2005", "2005-05-04"},
+		{"в марте 2005", "2005-03"},
+		{"Sept. 2005", "2005-09"},
+		{"дек. 2005", "2005-12"},
+		{"Marathon 2012", "2012"},
+		{"Маяк 1999", "1999"},
+		{"Decade 1990", "1990"},
+		{"Mayday 1999", "1999"},
+		{"2005-03-04", "2005-03-04"},
+		{"2005-03-04T10:00:00+03:00", "2005-03-04T10:00:00+03:00"},
+		{"2005-03-04T10:00+03:00", "2005-03-04"},
+		{"written 1999, published 2001-05", "1999/2001-05"},
+	}
+	for _, tt := range tests {
+		r, ok := ParseDateRange(tt.in)
+		if !ok || r.String() != tt.want {
+			t.Errorf("ParseDateRange(%q) = %s %v, want %s", tt.in, r, ok, tt.want)
+		}
+	}
+	for _, s := range []string{"", "no date", "31.02.2005", "1869-63", "1869–63"} {
+		if r, ok := ParseDateRange(s); ok {
+			t.Errorf("ParseDateRange(%q) = %s", s, r)
+		}
+	}
+
+	r, _ := ParseDateRange("1863-1869")
+	if !r.ContainsYear(1869) || r.ContainsYear(1870) {
+		t.Error("ContainsYear")
+	}
+	if !r.Contains(time.Date(1869, 12, 31, 23, 0, 0, 0, time.UTC)) {
+		t.Error("Contains")
+	}
+}
+
+func TestXMLDateCompare(t *testing.T) {
+	year, _ := ParseDate("2005")
+	month, _ := ParseDate("2005-01")
+	day, _ := ParseDate("2005-01-01")
+	moment, _ := ParseDate("2005-01-01T00:00:00Z")
+	dates := []XMLDate{year, month, day, moment}
+	for i := range dates {
+		for j := range dates {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := dates[i].Compare(dates[j]); got != want {
+				t.Errorf("%s compare %s = %d, want %d", dates[i], dates[j], got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,612 @@
+package gofb2
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ChangeType is a kind of change between two versions of book
+type ChangeType string
+
+// Types of changes
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeChanged ChangeType = "changed"
+	ChangeMoved   ChangeType = "moved"
+)
+
+// Change is a difference between two versions of book
+type Change struct {
+	Type ChangeType
+	// Path is a location of changed node in the new version or in the old
+	// one for removed nodes, like "description/title-info/book-title" or
+	// "body/section[2]#ch2/p[3]"
+	Path string
+	// Old and New are values or texts of node, for moved section they
+	// are old and new paths
+	Old, New string
+}
+
+// Diff return changes from book a to book b: changed fields of description,
+// added, removed and moved sections, edited paragraphs and binaries.
+// Sections are matched by id, then by title, by text of untitled sections
+// and by position. Nil book is compared as empty one.
+func Diff(a, b *FictionBook) []Change {
+	if a == nil {
+		a = &FictionBook{}
+	}
+	if b == nil {
+		b = &FictionBook{}
+	}
+	var changes []Change
+	changes = append(changes, diffDescription(a.Description, b.Description)...)
+	changes = append(changes, diffBodies("body", mainSections(a), mainSections(b))...)
+	changes = append(changes, diffBodies("notes", noteSections(a), noteSections(b))...)
+	changes = append(changes, diffBinaries(a.Binary, b.Binary)...)
+	return changes
+}
+
+func mainSections(fb *FictionBook) []*Section {
+	if fb.Body == nil {
+		return nil
+	}
+	return fb.Body.Sections
+}
+
+func noteSections(fb *FictionBook) []*Section {
+	if fb.NotesBody == nil {
+		return nil
+	}
+	return fb.NotesBody.Sections
+}
+
+// diffDescription compare flattened fields of descriptions
+func diffDescription(a, b *Description) []Change {
+	fa, fb := map[string]string{}, map[string]string{}
+	if a != nil {
+		fa = flattenNode(a)
+	}
+	if b != nil {
+		fb = flattenNode(b)
+	}
+	var paths []string
+	for p := range fa {
+		paths = append(paths, p)
+	}
+	for p := range fb {
+		if _, ok := fa[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	var changes []Change
+	for _, p := range paths {
+		va, inA := fa[p]
+		vb, inB := fb[p]
+		switch {
+		case !inA:
+			changes = append(changes, Change{Type: ChangeAdded, Path: p, New: vb})
+		case !inB:
+			changes = append(changes, Change{Type: ChangeRemoved, Path: p, Old: va})
+		case va != vb:
+			changes = append(changes, Change{Type: ChangeChanged, Path: p, Old: va, New: vb})
+		}
+	}
+	return changes
+}
+
+// flattenNode return values of node fields by paths built from tag names,
+// text of mixed content is flattened to one value
+func flattenNode(n Node) map[string]string {
+	res := map[string]string{}
+	data, err := json.Marshal(n)
+	if err != nil {
+		return res
+	}
+	var v interface{}
+	if json.Unmarshal(data, &v) != nil {
+		return res
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		kind, _ := m[jsonKindField].(string)
+		flattenJSON(m, kind, res)
+	}
+	return res
+}
+
+func flattenJSON(v interface{}, path string, res map[string]string) {
+	switch e := v.(type) {
+	case map[string]interface{}:
+		if content, ok := e["content"].([]interface{}); ok {
+			res[path] = collapseSpace(jsonText(content))
+		}
+		for k, child := range e {
+			switch k {
+			case jsonKindField, "content":
+				continue
+			case "value", "genre":
+				// text of node
+				flattenJSON(child, path, res)
+				continue
+			}
+			switch c := child.(type) {
+			case []interface{}:
+				for i, item := range c {
+					name := kebabCase(k)
+					if m, ok := item.(map[string]interface{}); ok {
+						if kind, ok := m[jsonKindField].(string); ok {
+							name = kind
+						}
+					}
+					flattenJSON(item, fmt.Sprintf("%s/%s[%d]", path, name, i+1), res)
+				}
+			case map[string]interface{}:
+				name, ok := c[jsonKindField].(string)
+				if !ok {
+					name = kebabCase(k)
+				}
+				flattenJSON(c, path+"/"+name, res)
+			default:
+				flattenJSON(c, path+"/"+kebabCase(k), res)
+			}
+		}
+	case nil:
+	default:
+		res[path] = strings.TrimSpace(fmt.Sprint(e))
+	}
+}
+
+// kebabCase convert JSON name like "srcURLs" to "src-urls"
+func kebabCase(name string) string {
+	var b strings.Builder
+	prevUpper := true
+	for _, r := range name {
+		upper := unicode.IsUpper(r)
+		if upper && !prevUpper {
+			b.WriteByte('-')
+		}
+		b.WriteRune(unicode.ToLower(r))
+		prevUpper = upper
+	}
+	return b.String()
+}
+
+// jsonText return text of JSON content array
+func jsonText(content []interface{}) string {
+	var b strings.Builder
+	for _, c := range content {
+		switch e := c.(type) {
+		case string:
+			b.WriteString(e)
+		case map[string]interface{}:
+			inner, _ := e["content"].([]interface{})
+			b.WriteString(" " + jsonText(inner) + " ")
+		}
+	}
+	return b.String()
+}
+
+// diffSection is a section with its position in body
+type diffSection struct {
+	s      *Section
+	parent *diffSection
+	path   string
+	// index among sibling sections
+	index int
+}
+
+// flattenSections return sections in document order with their paths
+func flattenSections(root string, sections []*Section) []*diffSection {
+	var res []*diffSection
+	var visit func(parent *diffSection, path string, sections []*Section)
+	visit = func(parent *diffSection, path string, sections []*Section) {
+		for i, s := range sections {
+			// titles may repeat and contain "/", so sections are named by
+			// position and id
+			name := "section[" + strconv.Itoa(i+1) + "]"
+			if s.ID != "" {
+				name += "#" + s.ID
+			}
+			ds := &diffSection{s: s, parent: parent, path: path + "/" + name, index: i}
+			res = append(res, ds)
+			visit(ds, ds.path, s.Sections)
+		}
+	}
+	visit(nil, root, sections)
+	return res
+}
+
+// matchSections return pairs of sections of a and b matched by id, by title,
+// by text of untitled section and by position
+func matchSections(a, b []*diffSection) map[*diffSection]*diffSection {
+	match := map[*diffSection]*diffSection{}
+	used := map[*diffSection]bool{}
+	pair := func(x, y *diffSection) {
+		match[x] = y
+		used[y] = true
+	}
+	ids := map[string]*diffSection{}
+	for _, y := range b {
+		if y.s.ID != "" {
+			ids[y.s.ID] = y
+		}
+	}
+	for _, x := range a {
+		if y, ok := ids[x.s.ID]; ok && x.s.ID != "" && !used[y] {
+			pair(x, y)
+		}
+	}
+	sameParent := func(x, y *diffSection) bool {
+		if x.parent == nil || y.parent == nil {
+			return x.parent == nil && y.parent == nil
+		}
+		return match[x.parent] == y.parent
+	}
+	matchBy := func(key func(*diffSection) string) {
+		for _, x := range a {
+			k := key(x)
+			if _, ok := match[x]; ok || k == "" {
+				continue
+			}
+			var found *diffSection
+			for _, y := range b {
+				if used[y] || key(y) != k {
+					continue
+				}
+				if sameParent(x, y) {
+					found = y
+					break
+				}
+				if found == nil {
+					found = y
+				}
+			}
+			if found != nil {
+				pair(x, found)
+			}
+		}
+	}
+	matchBy(func(ds *diffSection) string { return titleText(ds.s.Title) })
+	// untitled sections with the same text
+	matchBy(func(ds *diffSection) string {
+		if titleText(ds.s.Title) != "" {
+			return ""
+		}
+		return sectionText(ds.s)
+	})
+	// untitled sections at the same position
+	for _, x := range a {
+		if _, ok := match[x]; ok {
+			continue
+		}
+		for _, y := range b {
+			if !used[y] && y.index == x.index && sameParent(x, y) && titleText(x.s.Title) == titleText(y.s.Title) {
+				pair(x, y)
+				break
+			}
+		}
+	}
+	return match
+}
+
+// movedSections return sections of a that changed parent or order
+// among siblings
+func movedSections(a []*diffSection, match map[*diffSection]*diffSection) map[*diffSection]bool {
+	moved := map[*diffSection]bool{}
+	siblings := map[*diffSection][]*diffSection{}
+	for _, x := range a {
+		y, ok := match[x]
+		if !ok {
+			continue
+		}
+		if x.parent == nil && y.parent != nil || x.parent != nil && match[x.parent] != y.parent {
+			moved[x] = true
+			continue
+		}
+		siblings[y.parent] = append(siblings[y.parent], x)
+	}
+	for _, list := range siblings {
+		// sections of a in order of a, keep the longest run ordered in b
+		order := make([]int, len(list))
+		for i, x := range list {
+			order[i] = match[x].index
+		}
+		for _, i := range outOfOrder(order) {
+			moved[list[i]] = true
+		}
+	}
+	return moved
+}
+
+// outOfOrder return indexes of values not in the longest increasing
+// subsequence
+func outOfOrder(values []int) []int {
+	n := len(values)
+	length, prev := make([]int, n), make([]int, n)
+	best := -1
+	for i := range values {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if values[j] < values[i] && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if best < 0 || length[i] > length[best] {
+			best = i
+		}
+	}
+	keep := map[int]bool{}
+	for i := best; i >= 0; i = prev[i] {
+		keep[i] = true
+	}
+	var res []int
+	for i := range values {
+		if !keep[i] {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func diffBodies(root string, a, b []*Section) []Change {
+	fa, fb := flattenSections(root, a), flattenSections(root, b)
+	match := matchSections(fa, fb)
+	moved := movedSections(fa, match)
+	matched := map[*diffSection]bool{}
+	for _, y := range match {
+		matched[y] = true
+	}
+	var changes []Change
+	for _, x := range fa {
+		y, ok := match[x]
+		if !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Path: x.path, Old: sectionText(x.s)})
+			continue
+		}
+		if moved[x] {
+			changes = append(changes, Change{Type: ChangeMoved, Path: y.path, Old: x.path, New: y.path})
+		}
+		if ta, tb := titleText(x.s.Title), titleText(y.s.Title); ta != tb {
+			changes = append(changes, Change{Type: ChangeChanged, Path: y.path + "/title", Old: ta, New: tb})
+		}
+		changes = append(changes, diffBlocks(x.path, y.path, sectionBlocks(x.s), sectionBlocks(y.s))...)
+	}
+	for _, y := range fb {
+		if !matched[y] {
+			changes = append(changes, Change{Type: ChangeAdded, Path: y.path, New: sectionText(y.s)})
+		}
+	}
+	return changes
+}
+
+// sectionBlocks return epigraphs, image, annotation and content of section
+func sectionBlocks(s *Section) []Contenter {
+	var res []Contenter
+	for _, c := range children(s) {
+		switch c.(type) {
+		case *Title, *Section:
+			continue
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+// sectionText return text of section without subsections
+func sectionText(s *Section) string {
+	return contentText(sectionBlocks(s))
+}
+
+// blockKey return kind and text of block used to compare blocks
+func blockKey(c Contenter) string {
+	switch e := c.(type) {
+	case *Image:
+		return "image:" + e.XlinkHref
+	case *EmptyLine:
+		return "empty-line:"
+	}
+	return c.GetXMLName().Local + ":" + contentText(children(c))
+}
+
+// blockPaths return paths of blocks like "p[2]" numbered by kind
+func blockPaths(path string, blocks []Contenter) []string {
+	counts := map[string]int{}
+	res := make([]string, len(blocks))
+	for i, c := range blocks {
+		kind := c.GetXMLName().Local
+		counts[kind]++
+		res[i] = path + "/" + kind + "[" + strconv.Itoa(counts[kind]) + "]"
+	}
+	return res
+}
+
+// diffOp is an operation of edit script: keep, delete or insert
+type diffOp struct {
+	op   byte
+	a, b int
+}
+
+// diffKeys return edit script from a to b as sequence of
+// '=' (keep), '-' (delete a) and '+' (insert b) operations
+func diffKeys(a, b []string) []diffOp {
+	var ops []diffOp
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		ops = append(ops, diffOp{'=', prefix, prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(ma)*len(mb) > 4000000 {
+		// too long to compare, replace all
+		for i := range ma {
+			ops = append(ops, diffOp{'-', prefix + i, -1})
+		}
+		for j := range mb {
+			ops = append(ops, diffOp{'+', -1, prefix + j})
+		}
+	} else {
+		// longest common subsequence of the middle
+		lcs := make([][]int32, len(ma)+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, len(mb)+1)
+		}
+		for i := len(ma) - 1; i >= 0; i-- {
+			for j := len(mb) - 1; j >= 0; j-- {
+				if ma[i] == mb[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else if lcs[i+1][j] >= lcs[i][j+1] {
+					lcs[i][j] = lcs[i+1][j]
+				} else {
+					lcs[i][j] = lcs[i][j+1]
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(ma) || j < len(mb) {
+			switch {
+			case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+				ops = append(ops, diffOp{'=', prefix + i, prefix + j})
+				i++
+				j++
+			case j == len(mb) || i < len(ma) && lcs[i+1][j] >= lcs[i][j+1]:
+				ops = append(ops, diffOp{'-', prefix + i, -1})
+				i++
+			default:
+				ops = append(ops, diffOp{'+', -1, prefix + j})
+				j++
+			}
+		}
+	}
+	for k := 0; k < suffix; k++ {
+		ops = append(ops, diffOp{'=', len(a) - suffix + k, len(b) - suffix + k})
+	}
+	return ops
+}
+
+// diffBlocks return changes of blocks of matched sections, deleted block
+// followed by inserted block of the same kind is an edit
+func diffBlocks(pathA, pathB string, a, b []Contenter) []Change {
+	keys := func(blocks []Contenter) []string {
+		res := make([]string, len(blocks))
+		for i, c := range blocks {
+			res[i] = blockKey(c)
+		}
+		return res
+	}
+	pa, pb := blockPaths(pathA, a), blockPaths(pathB, b)
+	ops := diffKeys(keys(a), keys(b))
+	var changes []Change
+	for k := 0; k < len(ops); k++ {
+		op := ops[k]
+		switch op.op {
+		case '-':
+			// pair with insertion of the same kind in this run of edits
+			paired := false
+			for l := k + 1; l < len(ops) && ops[l].op != '='; l++ {
+				if ops[l].op == '+' && a[op.a].GetXMLName().Local == b[ops[l].b].GetXMLName().Local {
+					nb := b[ops[l].b]
+					changes = append(changes, Change{Type: ChangeChanged, Path: pb[ops[l].b], Old: contentText([]Contenter{a[op.a]}), New: contentText([]Contenter{nb})})
+					ops[l].op = '*'
+					paired = true
+					break
+				}
+			}
+			if !paired {
+				changes = append(changes, Change{Type: ChangeRemoved, Path: pa[op.a], Old: contentText([]Contenter{a[op.a]})})
+			}
+		case '+':
+			changes = append(changes, Change{Type: ChangeAdded, Path: pb[op.b], New: contentText([]Contenter{b[op.b]})})
+		}
+	}
+	return changes
+}
+
+func binaryHash(b *Binary) string {
+	return fmt.Sprintf("%s %x", b.ContentType, sha1.Sum(b.Value))
+}
+
+func diffBinaries(a, b []*Binary) []Change {
+	index := func(binaries []*Binary) map[string]*Binary {
+		res := map[string]*Binary{}
+		for _, bin := range binaries {
+			res[bin.ID] = bin
+		}
+		return res
+	}
+	ia, ib := index(a), index(b)
+	var changes []Change
+	for _, x := range a {
+		path := "binary/" + x.ID
+		y, ok := ib[x.ID]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Type: ChangeRemoved, Path: path, Old: binaryHash(x)})
+		case binaryHash(x) != binaryHash(y):
+			changes = append(changes, Change{Type: ChangeChanged, Path: path, Old: binaryHash(x), New: binaryHash(y)})
+		}
+	}
+	for _, y := range b {
+		if _, ok := ia[y.ID]; !ok {
+			changes = append(changes, Change{Type: ChangeAdded, Path: "binary/" + y.ID, New: binaryHash(y)})
+		}
+	}
+	return changes
+}
+
+// WriteDiff write human readable report of changes
+func WriteDiff(w io.Writer, changes []Change) error {
+	h := &diffWriter{w: w}
+	for _, c := range changes {
+		switch c.Type {
+		case ChangeAdded:
+			h.write("+ ", c.Path, "\n")
+			writeDiffText(h, "  + ", c.New)
+		case ChangeRemoved:
+			h.write("- ", c.Path, "\n")
+			writeDiffText(h, "  - ", c.Old)
+		case ChangeMoved:
+			h.write("> ", c.Path, " (moved from ", c.Old, ")\n")
+		default:
+			h.write("~ ", c.Path, "\n")
+			writeDiffText(h, "  - ", c.Old)
+			writeDiffText(h, "  + ", c.New)
+		}
+	}
+	return h.err
+}
+
+type diffWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (h *diffWriter) write(s ...string) {
+	for _, str := range s {
+		if h.err != nil {
+			return
+		}
+		_, h.err = io.WriteString(h.w, str)
+	}
+}
+
+// writeDiffText write text shortened to one line
+func writeDiffText(h *diffWriter, prefix, s string) {
+	if s == "" {
+		return
+	}
+	if r := []rune(s); len(r) > 200 {
+		s = string(r[:200]) + "…"
+	}
+	h.write(prefix, s, "\n")
+}
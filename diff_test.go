@@ -0,0 +1,83 @@
+package gofb2
+
+import (
+	"strings"
+	"testing"
+)
+
+const diffBase = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0">
+<description><title-info><book-title>Book</book-title><lang>en</lang></title-info></description>
+<body>
+<section id="a"><title><p>Chapter</p></title><p>one</p><p>two</p><p>three</p></section>
+<section id="b"><title><p>Chapter</p></title><p>four</p></section>
+<section><title><p>A/B</p></title><p>five</p></section>
+</body>
+</FictionBook>`
+
+const diffChanged = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0">
+<description><title-info><book-title>New book</book-title><lang>en</lang></title-info></description>
+<body>
+<section id="b"><title><p>Chapter</p></title><p>four</p></section>
+<section id="a"><title><p>Chapter</p></title><p>one</p><p>2</p><p>three</p><p>six</p></section>
+</body>
+</FictionBook>`
+
+func TestDiff(t *testing.T) {
+	changes := Diff(parseBook(t, diffBase), parseBook(t, diffChanged))
+	var got []string
+	for _, c := range changes {
+		got = append(got, string(c.Type)+" "+c.Path+" "+c.Old+" "+c.New)
+	}
+	want := []string{
+		"changed description/title-info/book-title Book New book",
+		"changed body/section[2]#a/p[2] two 2",
+		"added body/section[2]#a/p[4]  six",
+		"moved body/section[1]#b body/section[2]#b body/section[1]#b",
+		"removed body/section[3] five ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	var b strings.Builder
+	if err := WriteDiff(&b, changes); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "~ body/section[2]#a/p[2]\n  - two\n  + 2\n") {
+		t.Errorf("report:\n%s", b.String())
+	}
+}
+
+func TestDiffEqual(t *testing.T) {
+	if changes := Diff(parseBook(t, diffBase), parseBook(t, diffBase)); len(changes) != 0 {
+		t.Errorf("changes of equal books: %v", changes)
+	}
+}
+
+func TestDiffNil(t *testing.T) {
+	fb := parseBook(t, diffBase)
+	added := Diff(nil, fb)
+	removed := Diff(fb, nil)
+	if len(added) == 0 || len(added) != len(removed) {
+		t.Fatalf("got %d added and %d removed changes", len(added), len(removed))
+	}
+	for i := range added {
+		if added[i].Type != ChangeAdded || removed[i].Type != ChangeRemoved || added[i].Path != removed[i].Path {
+			t.Errorf("change %d: %v and %v", i, added[i], removed[i])
+		}
+	}
+	if changes := Diff(nil, nil); len(changes) != 0 {
+		t.Errorf("changes of nil books: %v", changes)
+	}
+}
+
+func TestDiffKeys(t *testing.T) {
+	ops := diffKeys(strings.Split("abcd", ""), strings.Split("acxd", ""))
+	var script []byte
+	for _, op := range ops {
+		script = append(script, op.op)
+	}
+	if string(script) != "=-=+=" {
+		t.Errorf("script = %s", script)
+	}
+}
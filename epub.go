@@ -0,0 +1,647 @@
+package gofb2
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EPUBOptions describe EPUB export
+type EPUBOptions struct {
+	// SplitDepth is a depth of sections that are written to separate files,
+	// 1 by default, i.e. every top level section is a chapter
+	SplitDepth int
+
+	// Modified is a modification time of the publication, current time by default
+	Modified time.Time
+}
+
+const (
+	epubDir       = "OEBPS"
+	epubStyle     = "style.css"
+	epubNav       = "nav.xhtml"
+	epubNotes     = "notes.xhtml"
+	epubTitlePage = "title.xhtml"
+
+	epubDefaultStyle = `.title-page, h1, h2, h3, h4, h5, h6 { text-align: center; }
+.epigraph { margin-left: 30%; font-style: italic; }
+.text-author { text-align: right; font-style: italic; }
+.subtitle { text-align: center; font-weight: bold; }
+.stanza { margin: 1em 0 1em 2em; }
+.stanza p { margin: 0; text-indent: 0; }
+.image { text-align: center; }
+.image img { max-width: 100%; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid; padding: 0.2em; }
+`
+)
+
+// WriteEPUB convert book to EPUB 3 and write it to w
+func WriteEPUB(w io.Writer, fb *FictionBook, opts EPUBOptions) error {
+	if fb == nil {
+		return fmt.Errorf("no book to write")
+	}
+	if opts.SplitDepth <= 0 {
+		opts.SplitDepth = 1
+	}
+	if opts.Modified.IsZero() {
+		opts.Modified = time.Now()
+	}
+	e := &epubWriter{
+		fb:       fb,
+		opts:     opts,
+		zip:      zip.NewWriter(w),
+		binaries: binaryIndex(fb),
+		images:   map[string]string{},
+		files:    map[string]string{},
+		sections: map[*Section]string{},
+		anchors:  map[*Section]string{},
+	}
+	e.plan()
+	e.planTOC()
+	e.planImages()
+	e.mimetype()
+	e.container()
+	e.write(epubStyle, func(w io.Writer) error {
+		_, err := io.WriteString(w, e.stylesheet())
+		return err
+	})
+	for _, ch := range e.chapters {
+		e.chapter(ch)
+	}
+	e.notes()
+	e.nav()
+	e.packImages()
+	e.opf()
+	if e.err != nil {
+		return e.err
+	}
+	return e.zip.Close()
+}
+
+// epubChapter is a single xhtml file of publication
+type epubChapter struct {
+	file    string
+	title   string
+	section *Section
+	level   int
+	// whole section with children, or only its head and content
+	whole bool
+}
+
+type epubItem struct {
+	id, href, mediaType, properties string
+}
+
+type epubWriter struct {
+	fb       *FictionBook
+	opts     EPUBOptions
+	zip      *zip.Writer
+	err      error
+	binaries map[string]*Binary
+	images   map[string]string
+	files    map[string]string
+	chapters []*epubChapter
+	toc      *TableOfContents
+	// files by section and generated anchors of sections without id
+	sections map[*Section]string
+	anchors  map[*Section]string
+	manifest []epubItem
+	spine    []string
+}
+
+func (e *epubWriter) write(name string, fn func(io.Writer) error) {
+	if e.err != nil {
+		return
+	}
+	w, err := e.zip.Create(path.Join(epubDir, name))
+	if err != nil {
+		e.err = err
+		return
+	}
+	e.err = fn(w)
+}
+
+func (e *epubWriter) mimetype() {
+	w, err := e.zip.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		e.err = err
+		return
+	}
+	_, e.err = io.WriteString(w, "application/epub+zip")
+}
+
+func (e *epubWriter) container() {
+	if e.err != nil {
+		return
+	}
+	w, err := e.zip.Create("META-INF/container.xml")
+	if err != nil {
+		e.err = err
+		return
+	}
+	_, e.err = io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles>
+<rootfile full-path="`+epubDir+`/content.opf" media-type="application/oebps-package+xml"/>
+</rootfiles>
+</container>
+`)
+}
+
+func (e *epubWriter) stylesheet() string {
+	var b strings.Builder
+	b.WriteString(epubDefaultStyle)
+	for _, s := range e.fb.Stylesheet {
+		if s.Type == "text/css" {
+			b.Write(s.Value)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// plan split book to chapters and assign file names to ids
+func (e *epubWriter) plan() {
+	body := e.fb.Body
+	if body == nil {
+		body = &Body{}
+	}
+	if body.Title != nil || body.Image != nil || len(body.Epigraphs) > 0 {
+		ch := &epubChapter{file: epubTitlePage, title: e.bookTitle()}
+		e.chapters = append(e.chapters, ch)
+		e.mapIDs(bodyChildren(&Body{Image: body.Image, Title: body.Title, Epigraphs: body.Epigraphs}), ch.file)
+	}
+	n := 0
+	var split func(s *Section, level int)
+	split = func(s *Section, level int) {
+		n++
+		ch := &epubChapter{
+			file:    fmt.Sprintf("chapter-%03d.xhtml", n),
+			title:   titleText(s.Title),
+			section: s,
+			level:   level,
+			whole:   level-1 >= e.opts.SplitDepth || len(s.Sections) == 0,
+		}
+		e.chapters = append(e.chapters, ch)
+		e.sections[s] = ch.file
+		if s.ID != "" {
+			e.files[s.ID] = ch.file
+		}
+		if ch.whole {
+			e.mapIDs(children(s), ch.file)
+			return
+		}
+		head := &Section{Title: s.Title, Epigraphs: s.Epigraphs, Image: s.Image, Annotation: s.Annotation}
+		head.Content = s.Content
+		e.mapIDs(children(head), ch.file)
+		for _, cs := range s.Sections {
+			split(cs, level+1)
+		}
+	}
+	for _, s := range body.Sections {
+		split(s, 2)
+	}
+	if e.fb.NotesBody != nil {
+		e.mapIDs(bodyChildren(&e.fb.NotesBody.Body), epubNotes)
+	}
+}
+
+// planTOC build table of contents with links to chapter files
+func (e *epubWriter) planTOC() {
+	e.toc = TOC(e.fb)
+	e.toc.walk(func(t *TOCEntry) {
+		t.File = e.sections[t.Section]
+		if t.Section.ID != "" {
+			return
+		}
+		if t.File == epubNotes {
+			// notes without id are written without element to link to
+			t.Anchor = ""
+			return
+		}
+		e.anchors[t.Section] = t.Anchor
+	})
+	for _, ch := range e.chapters {
+		if ch.section == nil {
+			title := &TOCEntry{Title: ch.title, Depth: 1, File: ch.file}
+			e.toc.Entries = append([]*TOCEntry{title}, e.toc.Entries...)
+		}
+	}
+}
+
+func (e *epubWriter) mapIDs(cont []Contenter, file string) {
+	walk(cont, func(c Contenter) bool {
+		if id := nodeID(c); id != "" {
+			e.files[id] = file
+		}
+		if s, ok := c.(*Section); ok {
+			e.sections[s] = file
+		}
+		return true
+	})
+}
+
+func (e *epubWriter) bookTitle() string {
+	d := e.fb.Description
+	if d != nil && d.TitleInfo != nil && d.TitleInfo.BookTitle != nil {
+		return strings.TrimSpace(d.TitleInfo.BookTitle.Value)
+	}
+	if e.fb.Body != nil {
+		return titleText(e.fb.Body.Title)
+	}
+	return ""
+}
+
+func (e *epubWriter) lang() string {
+	d := e.fb.Description
+	if d != nil && d.TitleInfo != nil && d.TitleInfo.Lang != "" {
+		return strings.TrimSpace(d.TitleInfo.Lang)
+	}
+	return "und"
+}
+
+func (e *epubWriter) xhtml(file, title string, properties string, fn func(h *htmlWriter)) {
+	e.manifest = append(e.manifest, epubItem{
+		id:         "x-" + strings.TrimSuffix(file, ".xhtml"),
+		href:       file,
+		mediaType:  "application/xhtml+xml",
+		properties: properties,
+	})
+	e.write(file, func(w io.Writer) error {
+		h := &htmlWriter{
+			w:        w,
+			binaries: e.binaries,
+			images:   e.images,
+			files:    e.files,
+			anchors:  e.anchors,
+			file:     file,
+			epub:     true,
+		}
+		lang := html.EscapeString(e.lang())
+		h.write(`<?xml version="1.0" encoding="utf-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="`, lang, `" xml:lang="`, lang, `">
+<head>
+<meta charset="utf-8"/>
+<title>`)
+		h.text(title)
+		h.write("</title>\n", `<link rel="stylesheet" type="text/css" href="`, epubStyle, "\"/>\n</head>\n<body>\n")
+		fn(h)
+		h.write("</body>\n</html>\n")
+		return h.err
+	})
+}
+
+func (e *epubWriter) chapter(ch *epubChapter) {
+	e.spine = append(e.spine, "x-"+strings.TrimSuffix(ch.file, ".xhtml"))
+	e.xhtml(ch.file, ch.title, "", func(h *htmlWriter) {
+		if ch.section == nil {
+			body := e.fb.Body
+			h.write(`<div class="title-page">`, "\n")
+			if body.Image != nil {
+				h.image(body.Image)
+			}
+			if body.Title != nil {
+				h.title(body.Title, 1)
+			}
+			for _, ep := range body.Epigraphs {
+				h.epigraph(ep)
+			}
+			h.write("</div>\n")
+			return
+		}
+		if ch.whole {
+			h.section(ch.section, ch.level)
+			return
+		}
+		h.sectionStart(ch.section, ch.level)
+		h.blocks(ch.section.Content)
+		h.sectionEnd()
+	})
+}
+
+func (e *epubWriter) notes() {
+	nb := e.fb.NotesBody
+	if nb == nil {
+		return
+	}
+	e.spine = append(e.spine, "x-notes")
+	title := titleText(nb.Title)
+	if title == "" {
+		title = "Notes"
+	}
+	e.xhtml(epubNotes, title, "", func(h *htmlWriter) {
+		h.write(`<section epub:type="footnotes">`, "\n")
+		if nb.Title != nil {
+			h.title(nb.Title, 1)
+		}
+		var notes func([]*Section)
+		notes = func(sections []*Section) {
+			for _, s := range sections {
+				if s.ID == "" {
+					if s.Title != nil {
+						h.title(s.Title, 2)
+					}
+					h.blocks(s.Content)
+					notes(s.Sections)
+					continue
+				}
+				h.open("aside", "id", s.ID, "epub:type", "footnote")
+				h.write("\n")
+				if s.Title != nil {
+					h.open("p", "class", "note-title")
+					h.write(html.EscapeString(titleText(s.Title)))
+					h.close("p")
+					h.write("\n")
+				}
+				h.blocks(s.Content)
+				h.close("aside")
+				h.write("\n")
+				notes(s.Sections)
+			}
+		}
+		notes(nb.Sections)
+		h.write("</section>\n")
+	})
+}
+
+func (e *epubWriter) nav() {
+	e.xhtml(epubNav, e.bookTitle(), "nav", func(h *htmlWriter) {
+		h.write(`<nav epub:type="toc" id="toc">`, "\n<h1>")
+		h.text(e.bookTitle())
+		h.write("</h1>\n")
+		e.toc.list(h, e.toc.Entries)
+		h.write("</nav>\n")
+	})
+}
+
+// planImages assign file names to binaries
+func (e *epubWriter) planImages() {
+	cover := e.coverID()
+	names := map[string]bool{}
+	for i, b := range e.fb.Binary {
+		name := "images/" + uniqueName(binaryFileName(b.ID, i+1), names)
+		e.images[b.ID] = name
+		item := epubItem{
+			id:        "img-" + strconv.Itoa(i+1),
+			href:      name,
+			mediaType: binaryMediaType(b),
+		}
+		if b.ID == cover {
+			item.properties = "cover-image"
+		}
+		e.manifest = append(e.manifest, item)
+	}
+}
+
+func (e *epubWriter) packImages() {
+	for _, b := range e.fb.Binary {
+		value := b.Value
+		e.write(e.images[b.ID], func(w io.Writer) error {
+			_, err := w.Write(value)
+			return err
+		})
+	}
+}
+
+func (e *epubWriter) coverID() string {
+	d := e.fb.Description
+	if d == nil || d.TitleInfo == nil || d.TitleInfo.Coverpage == nil || d.TitleInfo.Coverpage.Image == nil {
+		return ""
+	}
+	id, _ := localID(d.TitleInfo.Coverpage.Image.XlinkHref)
+	return id
+}
+
+// binaryMediaType return content type of binary, guessed by id if missing
+func binaryMediaType(b *Binary) string {
+	if b.ContentType != "" {
+		return b.ContentType
+	}
+	if t := mime.TypeByExtension(strings.ToLower(path.Ext(b.ID))); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// identifier return unique identifier of publication
+func (e *epubWriter) identifier() string {
+	d := e.fb.Description
+	if d != nil && d.DocumentInfo != nil && strings.TrimSpace(d.DocumentInfo.ID) != "" {
+		return strings.TrimSpace(d.DocumentInfo.ID)
+	}
+	return hashUUID([]byte(e.bookTitle()))
+}
+
+// hashUUID return name based urn:uuid for data
+func hashUUID(data []byte) string {
+	h := sha1.Sum(data)
+	// version 5 and RFC 4122 variant
+	h[6] = h[6]&0x0f | 0x50
+	h[8] = h[8]&0x3f | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
+
+// metaFunc write metadata element, attrs are name-value pairs and
+// element with empty value is omitted
+type metaFunc func(tag, value string, attrs ...string)
+
+func newMeta(m *strings.Builder) metaFunc {
+	return func(tag, value string, attrs ...string) {
+		if value == "" {
+			return
+		}
+		m.WriteString("<" + tag)
+		for i := 0; i+1 < len(attrs); i += 2 {
+			m.WriteString(" " + attrs[i] + `="` + html.EscapeString(attrs[i+1]) + `"`)
+		}
+		m.WriteString(">" + html.EscapeString(value) + "</" + tag + ">\n")
+	}
+}
+
+func (e *epubWriter) opf() {
+	var m strings.Builder
+	meta := newMeta(&m)
+	meta("dc:identifier", e.identifier(), "id", "bookid")
+	meta("dc:title", e.bookTitle())
+	meta("dc:language", e.lang())
+	meta("meta", e.opts.Modified.UTC().Format("2006-01-02T15:04:05Z"), "property", "dcterms:modified")
+	opfDescription(meta, e.fb.Description)
+	for _, item := range e.manifest {
+		if item.properties == "cover-image" {
+			m.WriteString(`<meta name="cover" content="` + item.id + "\"/>\n")
+		}
+	}
+
+	e.write("content.opf", func(w io.Writer) error {
+		var b strings.Builder
+		b.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="bookid" xml:lang="` + html.EscapeString(e.lang()) + `">
+<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+		b.WriteString(m.String())
+		b.WriteString("</metadata>\n<manifest>\n")
+		b.WriteString(`<item id="css" href="` + epubStyle + "\" media-type=\"text/css\"/>\n")
+		for _, item := range e.manifest {
+			b.WriteString(`<item id="` + item.id + `" href="` + html.EscapeString(item.href) + `" media-type="` + html.EscapeString(item.mediaType) + `"`)
+			if item.properties != "" {
+				b.WriteString(` properties="` + item.properties + `"`)
+			}
+			b.WriteString("/>\n")
+		}
+		b.WriteString("</manifest>\n<spine>\n")
+		for _, id := range e.spine {
+			b.WriteString(`<itemref idref="` + id + "\"/>\n")
+		}
+		b.WriteString("</spine>\n</package>\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	})
+}
+
+// opfDescription write OPF metadata of title-info, source title-info and
+// publish-info, identifier, title and language are written by caller
+func opfDescription(meta metaFunc, d *Description) {
+	if d == nil {
+		return
+	}
+	if ti := d.TitleInfo; ti != nil {
+		n := 0
+		creators := func(authors []*Author, role string) {
+			for _, a := range authors {
+				name := authorName(a)
+				if name == "" {
+					continue
+				}
+				n++
+				id := "creator" + strconv.Itoa(n)
+				tag := "dc:creator"
+				if role != "aut" {
+					tag = "dc:contributor"
+				}
+				meta(tag, name, "id", id)
+				meta("meta", role, "refines", "#"+id, "property", "role", "scheme", "marc:relators")
+				if a.LastName != nil && strings.TrimSpace(a.LastName.Value) != "" {
+					fileAs := strings.TrimSpace(a.LastName.Value)
+					if a.FirstName != nil && strings.TrimSpace(a.FirstName.Value) != "" {
+						fileAs += ", " + strings.TrimSpace(a.FirstName.Value)
+					}
+					meta("meta", fileAs, "refines", "#"+id, "property", "file-as")
+				}
+			}
+		}
+		creators(ti.Authors, "aut")
+		creators(ti.Translators, "trl")
+		subjects := map[string]bool{}
+		for _, g := range ti.Genres {
+			meta("dc:subject", strings.TrimSpace(g.Genre))
+			info, ok := LookupGenre(g.Genre)
+			if !ok {
+				continue
+			}
+			// subjects of known genres by BISAC and Thema authorities
+			for _, s := range [][2]string{{"BISAC", info.BISAC}, {"THEMA", info.Thema}} {
+				if s[1] == "" || subjects[s[0]+s[1]] {
+					continue
+				}
+				subjects[s[0]+s[1]] = true
+				id := "subject" + strconv.Itoa(len(subjects))
+				meta("dc:subject", info.En, "id", id)
+				meta("meta", s[0], "refines", "#"+id, "property", "authority")
+				meta("meta", s[1], "refines", "#"+id, "property", "term")
+			}
+		}
+		if ti.Annotation != nil {
+			meta("dc:description", contentText(ti.Annotation.Content))
+		}
+		if ti.Date != nil && ti.Date.Value != nil {
+			meta("dc:date", ti.Date.Value.String())
+		}
+		n = 0
+		var collections func([]*Sequence)
+		collections = func(seqs []*Sequence) {
+			for _, s := range seqs {
+				if s.Name != "" {
+					n++
+					id := "collection" + strconv.Itoa(n)
+					meta("meta", s.Name, "property", "belongs-to-collection", "id", id)
+					meta("meta", "series", "refines", "#"+id, "property", "collection-type")
+					if n := s.NumberString(); n != "" {
+						meta("meta", n, "refines", "#"+id, "property", "group-position")
+					}
+				}
+				collections(s.Sequences)
+			}
+		}
+		collections(ti.Sequences)
+	}
+	// original title of translation
+	if title, lang := originalTitle(d); title != "" && lang != "" {
+		meta("dc:source", title, "xml:lang", lang)
+	} else {
+		meta("dc:source", title)
+	}
+	if pi := d.PublishInfo; pi != nil {
+		if pi.Publisher != nil {
+			meta("dc:publisher", strings.TrimSpace(pi.Publisher.Value))
+		}
+		isbns := pi.ISBNs()
+		for i, isbn := range isbns {
+			id := "isbn"
+			if i > 0 {
+				id += strconv.Itoa(i + 1)
+			}
+			meta("dc:identifier", "urn:isbn:"+string(isbn), "id", id)
+		}
+		if len(isbns) == 0 && pi.ISBN != nil {
+			meta("dc:identifier", "urn:isbn:"+strings.TrimSpace(pi.ISBN.Value), "id", "isbn")
+		}
+		if d.TitleInfo == nil || d.TitleInfo.Date == nil || d.TitleInfo.Date.Value == nil {
+			meta("dc:date", strings.TrimSpace(pi.Year))
+		}
+	}
+}
+
+// titleText return flattened text of title paragraphs
+func titleText(t *Title) string {
+	if t == nil {
+		return ""
+	}
+	var parts []string
+	for _, c := range t.Content {
+		if s := contentText([]Contenter{c}); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// contentText return text of nodes with collapsed whitespace
+func contentText(cont []Contenter) string {
+	var b strings.Builder
+	var text func([]Contenter)
+	text = func(cont []Contenter) {
+		for _, c := range cont {
+			switch e := c.(type) {
+			case CharData:
+				b.Write(e)
+			case *StyleType, *NamedStyleType, *Link, *StyleLinkType, *InlineImage:
+				text(c.GetContent())
+			default:
+				b.WriteByte(' ')
+				text(children(e))
+				b.WriteByte(' ')
+			}
+		}
+	}
+	text(cont)
+	return collapseSpace(b.String())
+}
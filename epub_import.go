@@ -0,0 +1,585 @@
+package gofb2
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type opfPackage struct {
+	UniqueIdentifier string `xml:"unique-identifier,attr"`
+	Metadata         struct {
+		Titles       []opfValue `xml:"title"`
+		Creators     []opfValue `xml:"creator"`
+		Contributors []opfValue `xml:"contributor"`
+		Languages    []string   `xml:"language"`
+		Subjects     []opfValue `xml:"subject"`
+		Descriptions []string   `xml:"description"`
+		Publishers   []string   `xml:"publisher"`
+		Dates        []opfValue `xml:"date"`
+		Identifiers  []opfValue `xml:"identifier"`
+		Metas        []opfMeta  `xml:"meta"`
+	} `xml:"metadata"`
+	Manifest []opfItem `xml:"manifest>item"`
+	Spine    struct {
+		Toc      string `xml:"toc,attr"`
+		Itemrefs []struct {
+			IDRef  string `xml:"idref,attr"`
+			Linear string `xml:"linear,attr"`
+		} `xml:"itemref"`
+	} `xml:"spine"`
+}
+
+// opfValue is a Dublin Core element, role, file-as and scheme are EPUB 2 attributes
+type opfValue struct {
+	ID     string `xml:"id,attr"`
+	Role   string `xml:"role,attr"`
+	FileAs string `xml:"file-as,attr"`
+	Scheme string `xml:"scheme,attr"`
+	Event  string `xml:"event,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type opfMeta struct {
+	Name     string `xml:"name,attr"`
+	Content  string `xml:"content,attr"`
+	Property string `xml:"property,attr"`
+	Refines  string `xml:"refines,attr"`
+	ID       string `xml:"id,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+// hasProperty report whether space separated properties of item contain
+// property
+func (item *opfItem) hasProperty(property string) bool {
+	for _, p := range strings.Fields(item.Properties) {
+		if p == property {
+			return true
+		}
+	}
+	return false
+}
+
+type ncxPoint struct {
+	Label  string     `xml:"navLabel>text"`
+	Src    string     `xml:"content>src,attr"`
+	Points []ncxPoint `xml:"navPoint"`
+}
+
+// OpenEPUB read EPUB file and convert it to FictionBook
+func OpenEPUB(name string) (*FictionBook, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ReadEPUB(f, st.Size())
+}
+
+// ReadEPUB convert EPUB 2 or 3 publication to FictionBook.
+// Metadata is taken from OPF package, every spine document becomes a top
+// level section split by headings, images are stored as binaries and
+// footnotes marked with epub:type go to the notes body.
+func ReadEPUB(r io.ReaderAt, size int64) (*FictionBook, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	e := &epubReader{files: map[string]*zip.File{}}
+	for _, f := range z.File {
+		e.files[f.Name] = f
+	}
+	return e.read()
+}
+
+type epubReader struct {
+	files    map[string]*zip.File
+	opfDir   string
+	pkg      opfPackage
+	items    map[string]opfItem
+	binaries map[string]string
+	ids      map[string]string
+	usedIDs  map[string]bool
+	fb       *FictionBook
+}
+
+func (e *epubReader) readFile(name string) ([]byte, error) {
+	f, ok := e.files[name]
+	if !ok {
+		return nil, fmt.Errorf("file %s not found in epub", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+func (e *epubReader) read() (*FictionBook, error) {
+	data, err := e.readFile("META-INF/container.xml")
+	if err != nil {
+		return nil, err
+	}
+	var c epubContainer
+	if err := xml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("error while parsing container: %s", err)
+	}
+	if len(c.Rootfiles) == 0 {
+		return nil, fmt.Errorf("no rootfile in container")
+	}
+	opfPath := c.Rootfiles[0].FullPath
+	e.opfDir = path.Dir(opfPath)
+	if data, err = e.readFile(opfPath); err != nil {
+		return nil, err
+	}
+	if err := xml.Unmarshal(data, &e.pkg); err != nil {
+		return nil, fmt.Errorf("error while parsing package: %s", err)
+	}
+	e.items = map[string]opfItem{}
+	for _, item := range e.pkg.Manifest {
+		e.items[item.ID] = item
+	}
+
+	e.fb = &FictionBook{}
+	e.fb.SetXMLName(xml.Name{Local: "FictionBook"})
+	e.readBinaries()
+
+	h := newHTMLImporter()
+	e.ids = map[string]string{}
+	e.usedIDs = map[string]bool{}
+	h.id = e.uniqueID
+	h.image = func(file, src string) string {
+		if id, ok := e.binaries[resolvePath(file, src)]; ok {
+			return "#" + id
+		}
+		return ""
+	}
+	titles := e.readTOC()
+	for _, ref := range e.pkg.Spine.Itemrefs {
+		item, ok := e.items[ref.IDRef]
+		if !ok || item.hasProperty("nav") {
+			continue
+		}
+		file := e.itemPath(item)
+		data, err := e.readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := parseHTML(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing %s: %s", file, err)
+		}
+		h.startFile(file)
+		if body := doc.find("body"); body != nil {
+			h.blocks(body.children)
+		} else {
+			h.blocks(doc.children)
+		}
+		if s := h.sections[file]; s.Title == nil && titles[file] != "" && s.Image == nil {
+			s.Title = textTitle(titles[file])
+		}
+	}
+	h.resolveLinks(func(file, href string) (string, bool) {
+		u, err := url.Parse(href)
+		if err != nil || u.Scheme != "" || u.Host != "" {
+			return href, false
+		}
+		target := file
+		if u.Path != "" {
+			target = resolvePath(file, u.Path)
+		}
+		if u.Fragment != "" {
+			if id, ok := e.ids[target+"#"+u.Fragment]; ok {
+				return "#" + id, true
+			}
+		}
+		if s, ok := h.sections[target]; ok {
+			if s.ID == "" {
+				s.ID = e.uniqueID(target, strings.TrimSuffix(path.Base(target), path.Ext(target)))
+			}
+			return "#" + s.ID, true
+		}
+		return href, false
+	})
+	e.fb.Body = h.body
+	e.fb.NotesBody = h.notes
+	e.fb.Description = e.description()
+	return e.fb, nil
+}
+
+// uniqueID return id unique for the whole book
+func (e *epubReader) uniqueID(file, id string) string {
+	key := file + "#" + id
+	if uid, ok := e.ids[key]; ok {
+		return uid
+	}
+	uid := id
+	for i := 2; e.usedIDs[uid]; i++ {
+		uid = id + "_" + strconv.Itoa(i)
+	}
+	e.usedIDs[uid] = true
+	e.ids[key] = uid
+	return uid
+}
+
+func (e *epubReader) itemPath(item opfItem) string {
+	p, err := url.PathUnescape(item.Href)
+	if err != nil {
+		p = item.Href
+	}
+	return path.Join(e.opfDir, p)
+}
+
+// resolvePath return path of src relative to file in zip
+func resolvePath(file, src string) string {
+	if i := strings.IndexAny(src, "#?"); i >= 0 {
+		src = src[:i]
+	}
+	if p, err := url.PathUnescape(src); err == nil {
+		src = p
+	}
+	return path.Join(path.Dir(file), src)
+}
+
+func (e *epubReader) readBinaries() {
+	e.binaries = map[string]string{}
+	used := map[string]bool{}
+	for _, item := range e.pkg.Manifest {
+		if !strings.HasPrefix(item.MediaType, "image/") {
+			continue
+		}
+		file := e.itemPath(item)
+		data, err := e.readFile(file)
+		if err != nil {
+			continue
+		}
+		id := path.Base(file)
+		for i := 2; used[id]; i++ {
+			id = strconv.Itoa(i) + "_" + path.Base(file)
+		}
+		used[id] = true
+		b := &Binary{ID: id, ContentType: item.MediaType, Value: data}
+		b.SetXMLName(xml.Name{Local: "binary"})
+		e.fb.Binary = append(e.fb.Binary, b)
+		e.binaries[file] = id
+	}
+}
+
+// readTOC return titles of spine documents from NCX or navigation document
+func (e *epubReader) readTOC() map[string]string {
+	titles := map[string]string{}
+	add := func(file, src, title string) {
+		target := resolvePath(file, src)
+		if _, ok := titles[target]; !ok && strings.TrimSpace(title) != "" {
+			titles[target] = collapseSpace(title)
+		}
+	}
+	for _, item := range e.pkg.Manifest {
+		if !item.hasProperty("nav") {
+			continue
+		}
+		file := e.itemPath(item)
+		data, err := e.readFile(file)
+		if err != nil {
+			continue
+		}
+		doc, err := parseHTML(bytes.NewReader(data))
+		if err != nil {
+			continue
+		}
+		var walk func(n *htmlNode)
+		walk = func(n *htmlNode) {
+			if n.name == "nav" && n.epubType() != "" && n.epubType() != "toc" {
+				return
+			}
+			if n.name == "a" && n.attr("href") != "" {
+				add(file, n.attr("href"), n.textContent())
+			}
+			for _, c := range n.children {
+				walk(c)
+			}
+		}
+		walk(doc)
+		return titles
+	}
+	if item, ok := e.items[e.pkg.Spine.Toc]; ok {
+		file := e.itemPath(item)
+		data, err := e.readFile(file)
+		if err != nil {
+			return titles
+		}
+		var ncx struct {
+			Points []ncxPoint `xml:"navMap>navPoint"`
+		}
+		if xml.Unmarshal(data, &ncx) != nil {
+			return titles
+		}
+		var walk func([]ncxPoint)
+		walk = func(points []ncxPoint) {
+			for _, p := range points {
+				add(file, p.Src, p.Label)
+				walk(p.Points)
+			}
+		}
+		walk(ncx.Points)
+	}
+	return titles
+}
+
+// textTitle return title with paragraph for every line
+func textTitle(lines ...string) *Title {
+	t := &Title{}
+	t.SetXMLName(xml.Name{Local: "title"})
+	for _, l := range lines {
+		p := newP("p")
+		p.Content = []Contenter{CharData(l)}
+		t.appendContent(p)
+	}
+	return t
+}
+
+func textField(name, value string) *TextField {
+	t := &TextField{Value: strings.TrimSpace(value)}
+	t.SetXMLName(xml.Name{Local: name})
+	return t
+}
+
+// refinements return EPUB 3 meta values refining element with id
+func (e *epubReader) refinements(id string) map[string]string {
+	res := map[string]string{}
+	if id == "" {
+		return res
+	}
+	for _, m := range e.pkg.Metadata.Metas {
+		if m.Refines == "#"+id && m.Property != "" {
+			res[m.Property] = strings.TrimSpace(m.Value)
+		}
+	}
+	return res
+}
+
+func (e *epubReader) description() *Description {
+	md := e.pkg.Metadata
+	d := &Description{}
+	d.SetXMLName(xml.Name{Local: "description"})
+
+	ti := &TitleInfo{}
+	ti.SetXMLName(xml.Name{Local: "title-info"})
+	d.TitleInfo = ti
+	if len(md.Titles) > 0 {
+		ti.BookTitle = textField("book-title", md.Titles[0].Value)
+	}
+	for _, c := range append(md.Creators, md.Contributors...) {
+		refines := e.refinements(c.ID)
+		role, fileAs := c.Role, c.FileAs
+		if r, ok := refines["role"]; ok {
+			role = r
+		}
+		if f, ok := refines["file-as"]; ok {
+			fileAs = f
+		}
+		switch role {
+		case "", "aut":
+			a := personAuthor("author", c.Value, fileAs)
+			ti.Authors = append(ti.Authors, a)
+		case "trl":
+			a := personAuthor("translator", c.Value, fileAs)
+			ti.Translators = append(ti.Translators, a)
+		}
+	}
+	if len(md.Languages) > 0 {
+		ti.Lang = strings.TrimSpace(md.Languages[0])
+	}
+	for _, s := range md.Subjects {
+		if s.ID != "" && e.refinements(s.ID)["authority"] != "" {
+			// BISAC or Thema subject of genre
+			continue
+		}
+		if s := strings.TrimSpace(s.Value); s != "" {
+			g := &Genre{Genre: s}
+			g.SetXMLName(xml.Name{Local: "genre"})
+			ti.Genres = append(ti.Genres, g)
+		}
+	}
+	if len(md.Descriptions) > 0 {
+		ti.Annotation = e.annotation(md.Descriptions[0])
+	}
+	ti.Sequences = e.sequences()
+	for _, item := range e.pkg.Manifest {
+		if item.hasProperty("cover-image") {
+			ti.Coverpage = e.coverpage(item)
+		}
+	}
+	for _, m := range md.Metas {
+		if m.Name == "cover" && ti.Coverpage == nil {
+			if item, ok := e.items[m.Content]; ok {
+				ti.Coverpage = e.coverpage(item)
+			}
+		}
+	}
+
+	di := &DocumentInfo{ID: e.identifier(), Version: 1}
+	di.SetXMLName(xml.Name{Local: "document-info"})
+	di.ProgramUsed = textField("program-used", "gofb2")
+	d.DocumentInfo = di
+
+	pi := &PublishInfo{}
+	pi.SetXMLName(xml.Name{Local: "publish-info"})
+	if len(md.Publishers) > 0 {
+		pi.Publisher = textField("publisher", md.Publishers[0])
+	}
+	for _, date := range md.Dates {
+		if date.Event == "" || date.Event == "publication" {
+			if v := strings.TrimSpace(date.Value); len(v) >= 4 {
+				pi.Year = v[:4]
+				break
+			}
+		}
+	}
+	for _, id := range md.Identifiers {
+		v := strings.TrimSpace(id.Value)
+		if strings.EqualFold(id.Scheme, "isbn") || strings.HasPrefix(strings.ToLower(v), "urn:isbn:") {
+			pi.ISBN = textField("isbn", v[strings.LastIndex(v, ":")+1:])
+			break
+		}
+	}
+	if pi.Publisher != nil || pi.Year != "" || pi.ISBN != nil {
+		d.PublishInfo = pi
+	}
+	return d
+}
+
+func (e *epubReader) identifier() string {
+	ids := e.pkg.Metadata.Identifiers
+	for _, id := range ids {
+		if id.ID == e.pkg.UniqueIdentifier {
+			return strings.TrimSpace(id.Value)
+		}
+	}
+	if len(ids) > 0 {
+		return strings.TrimSpace(ids[0].Value)
+	}
+	return ""
+}
+
+func (e *epubReader) annotation(s string) *Annotation {
+	a := &Annotation{}
+	a.SetXMLName(xml.Name{Local: "annotation"})
+	doc, err := parseHTML(strings.NewReader("<div>" + s + "</div>"))
+	if err != nil {
+		p := newP("p")
+		p.Content = []Contenter{CharData(strings.TrimSpace(s))}
+		a.appendContent(p)
+		return a
+	}
+	h := newHTMLImporter()
+	h.target = &a.contentBase
+	h.stack = []*Section{newSection()}
+	h.levels = []int{0}
+	h.blocks(doc.children)
+	return a
+}
+
+func (e *epubReader) coverpage(item opfItem) *Coverpage {
+	id, ok := e.binaries[e.itemPath(item)]
+	if !ok {
+		return nil
+	}
+	c := &Coverpage{}
+	c.SetXMLName(xml.Name{Local: "coverpage"})
+	c.Image = &InlineImage{XlinkHref: "#" + id}
+	c.Image.SetXMLName(xml.Name{Local: "image"})
+	return c
+}
+
+// sequences return series from EPUB 3 collections or calibre metadata
+func (e *epubReader) sequences() []*Sequence {
+	var res []*Sequence
+	add := func(name, number string) {
+		s := &Sequence{Name: strings.TrimSpace(name)}
+		s.SetXMLName(xml.Name{Local: "sequence"})
+		if n, err := ParseSequenceNumber(number); err == nil {
+			s.SetNumber(n)
+		}
+		res = append(res, s)
+	}
+	var calibreName, calibreIndex string
+	for _, m := range e.pkg.Metadata.Metas {
+		switch {
+		case m.Property == "belongs-to-collection" && m.Refines == "":
+			add(m.Value, e.refinements(m.ID)["group-position"])
+		case m.Name == "calibre:series":
+			calibreName = m.Content
+		case m.Name == "calibre:series_index":
+			calibreIndex = m.Content
+		}
+	}
+	if len(res) == 0 && calibreName != "" {
+		add(calibreName, calibreIndex)
+	}
+	return res
+}
+
+// personAuthor make author from display name and optional "Last, First" form
+func personAuthor(tag, name, fileAs string) *Author {
+	a := &Author{}
+	a.SetXMLName(xml.Name{Local: tag})
+	var first, middle, last string
+	if parts := strings.SplitN(fileAs, ",", 2); len(parts) == 2 {
+		last = strings.TrimSpace(parts[0])
+		given := strings.Fields(parts[1])
+		if len(given) > 0 {
+			first = given[0]
+			middle = strings.Join(given[1:], " ")
+		}
+	} else {
+		words := strings.Fields(name)
+		switch len(words) {
+		case 0:
+		case 1:
+			a.Nickname = textField("nickname", words[0])
+			return a
+		default:
+			first = words[0]
+			last = words[len(words)-1]
+			middle = strings.Join(words[1:len(words)-1], " ")
+		}
+	}
+	if first != "" {
+		a.FirstName = textField("first-name", first)
+	}
+	if middle != "" {
+		a.MiddleName = textField("middle-name", middle)
+	}
+	if last != "" {
+		a.LastName = textField("last-name", last)
+	}
+	return a
+}
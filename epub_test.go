@@ -0,0 +1,177 @@
+package gofb2
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// epubFiles return contents of files of EPUB archive by name
+func epubFiles(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.File) == 0 || r.File[0].Name != "mimetype" || r.File[0].Method != zip.Store {
+		t.Error("mimetype is not the first stored file")
+	}
+	files := map[string]string{}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(b)
+	}
+	return files
+}
+
+func TestEPUBPackage(t *testing.T) {
+	fb := readBook(t, "sample.fb2")
+	fb.Binary[1].ID = ".."
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, fb, EPUBOptions{SplitDepth: 2}); err != nil {
+		t.Fatal(err)
+	}
+	files := epubFiles(t, buf.Bytes())
+	var pkg opfPackage
+	if err := xml.Unmarshal([]byte(files["OEBPS/content.opf"]), &pkg); err != nil {
+		t.Fatal(err)
+	}
+	items := map[string]opfItem{}
+	hrefs := map[string]bool{}
+	nav := ""
+	for _, item := range pkg.Manifest {
+		items[item.ID] = item
+		hrefs[item.Href] = true
+		if _, ok := files["OEBPS/"+item.Href]; !ok {
+			t.Errorf("no file of manifest item %s", item.Href)
+		}
+		if item.hasProperty("nav") {
+			nav = item.Href
+		}
+	}
+	for name := range files {
+		if strings.HasPrefix(name, "OEBPS/") && name != "OEBPS/content.opf" && !hrefs[strings.TrimPrefix(name, "OEBPS/")] {
+			t.Errorf("file %s is not in manifest", name)
+		}
+	}
+	if nav == "" {
+		t.Fatal("no nav document")
+	}
+	if !hrefs["images/image-2"] {
+		t.Errorf("binary %q has no generated name", "..")
+	}
+	spine := map[string]bool{}
+	for _, ref := range pkg.Spine.Itemrefs {
+		item, ok := items[ref.IDRef]
+		if !ok {
+			t.Errorf("spine item %s is not in manifest", ref.IDRef)
+		}
+		spine[item.Href] = true
+	}
+	// every link of nav point to existing anchor of document in spine,
+	// the first link is a stylesheet
+	links := regexp.MustCompile(`href="([^"#]*)(?:#([^"]*))?"`).FindAllStringSubmatch(files["OEBPS/"+nav], -1)
+	if len(links) < 2 {
+		t.Fatalf("nav links = %v", links)
+	}
+	for _, l := range links[1:] {
+		if !spine[l[1]] {
+			t.Errorf("nav link %s is not in spine", l[0])
+		}
+		if l[2] != "" && !strings.Contains(files["OEBPS/"+path.Clean(l[1])], `id="`+l[2]+`"`) {
+			t.Errorf("no anchor of nav link %s", l[0])
+		}
+	}
+	if err := WriteEPUB(&buf, nil, EPUBOptions{}); err == nil {
+		t.Error("no error for nil book")
+	}
+}
+
+func TestEPUBRoundTrip(t *testing.T) {
+	src := readBook(t, "sample.fb2")
+	src.Description.TitleInfo.Sequences[0].SetNumber(1.5)
+
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, src, EPUBOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	fb, err := ReadEPUB(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ti := fb.Description.TitleInfo
+	if got := fieldValue(ti.BookTitle); got != "Война и мир" {
+		t.Errorf("title = %q", got)
+	}
+	if len(ti.Authors) != 1 || fieldValue(ti.Authors[0].LastName) != "ТОЛСТОЙ" {
+		t.Errorf("authors = %v", authorNames(ti.Authors))
+	}
+	if len(ti.Translators) != 1 || authorName(ti.Translators[0]) != "Louise Maude" {
+		t.Errorf("translators = %v", authorNames(ti.Translators))
+	}
+	if ti.Lang != "ru" {
+		t.Errorf("lang = %q", ti.Lang)
+	}
+	if ti.Coverpage == nil {
+		t.Error("no coverpage")
+	}
+	var series []string
+	for _, s := range FlattenSequences(ti.Sequences) {
+		series = append(series, s.String())
+	}
+	if len(series) == 0 || series[0] != "Эпопея #1.5" {
+		t.Errorf("series = %v", series)
+	}
+
+	titles := sectionTitles(fb.Body.Sections)
+	for _, want := range []string{"Часть первая", "Глава I"} {
+		found := false
+		for _, title := range titles {
+			found = found || title == want
+		}
+		if !found {
+			t.Errorf("no section %q in %v", want, titles)
+		}
+	}
+	if fb.NotesBody == nil || len(fb.NotesBody.Sections) != 2 {
+		t.Error("notes are lost")
+	}
+	if len(fb.Binary) != len(src.Binary) {
+		t.Errorf("binaries = %d, want %d", len(fb.Binary), len(src.Binary))
+	}
+}
+
+// sectionTitles return titles of sections and their subsections
+func sectionTitles(sections []*Section) []string {
+	var res []string
+	for _, s := range sections {
+		res = append(res, titleText(s.Title))
+		res = append(res, sectionTitles(s.Sections)...)
+	}
+	return res
+}
+
+func TestOPFItemProperties(t *testing.T) {
+	item := opfItem{Properties: "scripted nav"}
+	if !item.hasProperty("nav") || item.hasProperty("navigation") {
+		t.Error("nav property")
+	}
+	item = opfItem{Properties: "not-a-nav cover-images"}
+	if item.hasProperty("nav") || item.hasProperty("cover-image") {
+		t.Error("substring matched as property")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/xml"
 	"fmt"
 	"strconv"
-	"time"
 )
 
 // TODO marshal
@@ -160,7 +159,8 @@ func (s *Stylesheet) attrCallback(attr xml.Attr) error {
 }
 
 func (s *Stylesheet) charDataCallback(cd xml.CharData) error {
-	s.Value = cd
+	// copy buffer, golang reuse byte array
+	s.Value = append(s.Value, cd...)
 	return nil
 }
 
@@ -482,11 +482,12 @@ type Date struct {
 
 func (d *Date) attrCallback(attr xml.Attr) error {
 	if attr.Name.Local == "value" {
-		parse, err := time.Parse(dateFormat, attr.Value)
-		if err != nil {
-			return err
+		// invalid value is skipped, text of date is kept
+		if parse, err := ParseDate(attr.Value); err == nil {
+			d.Value = &parse
+		} else if r, ok := ParseDateRange(attr.Value); ok {
+			d.Value = &r.From
 		}
-		d.Value = &XMLDate{parse}
 	} else if attr.Name.Local == "lang" {
 		d.Lang = attr.Value
 	} else {
@@ -512,7 +513,11 @@ type Sequence struct {
 
 	Sequences []*Sequence `xml:"sequence,omitempty"`
 	Name      string      `xml:"name,attr"`
-	Number    int         `xml:"number,attr"`
+	// Number is an integer part of number of book in sequence
+	Number int `xml:"number,attr"`
+	// NumberText is a number attribute as is, it keeps fractional numbers
+	// like "1.5" and is empty when number is absent
+	NumberText string `xml:"-"`
 }
 
 func (s *Sequence) tagCallback(start xml.StartElement) (Node, error) {
@@ -527,11 +532,11 @@ func (s *Sequence) attrCallback(attr xml.Attr) error {
 	if attr.Name.Local == "name" {
 		s.Name = attr.Value
 	} else if attr.Name.Local == "number" {
-		n, err := strconv.Atoi(attr.Value)
-		if err != nil {
-			return err
+		// fractional, empty and malformed numbers are common in the wild
+		s.NumberText = attr.Value
+		if n, err := ParseSequenceNumber(attr.Value); err == nil {
+			s.Number = int(n)
 		}
-		s.Number = n
 	} else {
 		return s.baseNode.attrCallback(attr)
 	}
@@ -0,0 +1,32 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+)
+
+// readBook parse FictionBook from file of testdata
+func readBook(t *testing.T, name string) *FictionBook {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fb := &FictionBook{}
+	if err := xml.NewDecoder(f).Decode(fb); err != nil {
+		t.Fatal(err)
+	}
+	return fb
+}
+
+// parseBook parse FictionBook from XML text
+func parseBook(t *testing.T, s string) *FictionBook {
+	t.Helper()
+	fb := &FictionBook{}
+	if err := xml.Unmarshal([]byte(s), fb); err != nil {
+		t.Fatal(err)
+	}
+	return fb
+}
@@ -0,0 +1,120 @@
+package gofb2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenreCategory is a group of genres
+type GenreCategory struct {
+	Code string
+	Ru   string
+	En   string
+}
+
+// GenreInfo is a genre of FictionBook genre list
+type GenreInfo struct {
+	Code string
+	// Category is a code of genre category
+	Category string
+	Ru       string
+	En       string
+	// BISAC and Thema are the closest subject codes used in EPUB
+	BISAC string
+	Thema string
+	// Extra genres are from FictionBook 2.1 list
+	Extra bool
+}
+
+// Name return name of genre in language, English name is returned
+// for languages other than Russian
+func (g *GenreInfo) Name(lang string) string {
+	switch baseLang(lang) {
+	case "ru", "uk", "be":
+		return g.Ru
+	}
+	return g.En
+}
+
+// Name return name of category in language
+func (c *GenreCategory) Name(lang string) string {
+	switch baseLang(lang) {
+	case "ru", "uk", "be":
+		return c.Ru
+	}
+	return c.En
+}
+
+var genreIndex = func() map[string]*GenreInfo {
+	res := make(map[string]*GenreInfo, len(genreList))
+	for _, g := range genreList {
+		res[g.Code] = g
+	}
+	return res
+}()
+
+// Genres return genre list
+func Genres() []*GenreInfo {
+	return append([]*GenreInfo(nil), genreList...)
+}
+
+// GenreCategories return categories of genre list
+func GenreCategories() []*GenreCategory {
+	return append([]*GenreCategory(nil), genreCategories...)
+}
+
+// CanonicalGenre return code of genre list for genre or its alias,
+// unknown genres are returned trimmed and in lower case
+func CanonicalGenre(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if c, ok := genreAliases[code]; ok {
+		return c
+	}
+	return code
+}
+
+// LookupGenre return genre by code or alias
+func LookupGenre(code string) (*GenreInfo, bool) {
+	g, ok := genreIndex[CanonicalGenre(code)]
+	return g, ok
+}
+
+// Parent return category of genre
+func (g *GenreInfo) Parent() *GenreCategory {
+	for _, c := range genreCategories {
+		if c.Code == g.Category {
+			return c
+		}
+	}
+	return nil
+}
+
+// ValidateGenres return error listing genres of title-info that are not
+// in genre list, aliases are valid
+func ValidateGenres(ti *TitleInfo) error {
+	var unknown []string
+	for _, g := range ti.Genres {
+		if _, ok := LookupGenre(g.Genre); !ok {
+			unknown = append(unknown, strings.TrimSpace(g.Genre))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown genres: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// CanonicalGenres replace aliases of genres of title-info by codes of
+// genre list and remove duplicates
+func CanonicalGenres(ti *TitleInfo) {
+	seen := map[string]bool{}
+	genres := ti.Genres[:0]
+	for _, g := range ti.Genres {
+		g.Genre = CanonicalGenre(g.Genre)
+		if !seen[g.Genre] {
+			seen[g.Genre] = true
+			genres = append(genres, g)
+		}
+	}
+	ti.Genres = genres
+}
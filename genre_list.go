@@ -0,0 +1,226 @@
+package gofb2
+
+// genreCategories are groups of genres of FictionBook genre list
+var genreCategories = []*GenreCategory{
+	{"sf", "Фантастика", "Science Fiction & Fantasy"},
+	{"detective", "Детективы и триллеры", "Detectives & Thrillers"},
+	{"prose", "Проза", "Prose"},
+	{"love", "Любовные романы", "Romance"},
+	{"adventure", "Приключения", "Adventure"},
+	{"children", "Детское", "Children's"},
+	{"poetry", "Поэзия, драматургия", "Poetry & Dramaturgy"},
+	{"antique", "Старинное", "Antique Literature"},
+	{"science", "Наука, образование", "Science & Education"},
+	{"computers", "Компьютеры и интернет", "Computers & Internet"},
+	{"reference", "Справочная литература", "Reference"},
+	{"nonfiction", "Документальная литература", "Nonfiction"},
+	{"religion", "Религия и духовность", "Religion & Spirituality"},
+	{"humor", "Юмор", "Humor"},
+	{"home", "Дом и семья", "Home & Family"},
+	{"business", "Деловая литература", "Business"},
+}
+
+// genreList is a FictionBook 2.0 genre list with extra genres of 2.1 list,
+// subjects are the closest BISAC and Thema codes
+var genreList = []*GenreInfo{
+	{"sf_history", "sf", "Альтернативная история", "Alternative History", "FIC040000", "FL", false},
+	{"sf_action", "sf", "Боевая фантастика", "Action Science Fiction", "FIC028010", "FL", false},
+	{"sf_epic", "sf", "Эпическая фантастика", "Epic Science Fiction", "FIC028000", "FL", false},
+	{"sf_heroic", "sf", "Героическая фантастика", "Heroic Science Fiction", "FIC028010", "FL", false},
+	{"sf_detective", "sf", "Детективная фантастика", "Detective Science Fiction", "FIC028000", "FL", false},
+	{"sf_cyberpunk", "sf", "Киберпанк", "Cyberpunk", "FIC028000", "FL", false},
+	{"sf_space", "sf", "Космическая фантастика", "Space Science Fiction", "FIC028030", "FLS", false},
+	{"sf_social", "sf", "Социально-психологическая фантастика", "Social Science Fiction", "FIC028000", "FL", false},
+	{"sf_horror", "sf", "Ужасы и мистика", "Horror & Mystic", "FIC015000", "FK", false},
+	{"sf_humor", "sf", "Юмористическая фантастика", "Humorous Science Fiction", "FIC028000", "FL", false},
+	{"sf_fantasy", "sf", "Фэнтези", "Fantasy", "FIC009000", "FM", false},
+	{"sf", "sf", "Научная фантастика", "Science Fiction", "FIC028000", "FL", false},
+	{"sf_postapocalyptic", "sf", "Постапокалипсис", "Post-Apocalyptic", "FIC028070", "FLQ", true},
+	{"sf_fantasy_city", "sf", "Городское фэнтези", "Urban Fantasy", "FIC009000", "FM", true},
+	{"sf_mystic", "sf", "Мистика", "Mystic", "FIC024000", "FK", true},
+	{"sf_stimpank", "sf", "Стимпанк", "Steampunk", "FIC028000", "FL", true},
+	{"humor_fantasy", "sf", "Юмористическое фэнтези", "Humorous Fantasy", "FIC009000", "FM", true},
+
+	{"det_classic", "detective", "Классический детектив", "Classical Detective", "FIC022000", "FFC", false},
+	{"det_police", "detective", "Полицейский детектив", "Police Stories", "FIC022020", "FFP", false},
+	{"det_action", "detective", "Боевик", "Action", "FIC002000", "FH", false},
+	{"det_irony", "detective", "Иронический детектив", "Ironical Detective", "FIC022000", "FFK", false},
+	{"det_history", "detective", "Исторический детектив", "Historical Detective", "FIC022060", "FFH", false},
+	{"det_espionage", "detective", "Шпионский детектив", "Espionage Detective", "FIC006000", "FHD", false},
+	{"det_crime", "detective", "Криминальный детектив", "Crime Detective", "FIC050000", "FF", false},
+	{"det_political", "detective", "Политический детектив", "Political Detective", "FIC031060", "FHP", false},
+	{"det_maniac", "detective", "Маньяки", "Maniacs", "FIC031000", "FH", false},
+	{"det_hard", "detective", "Крутой детектив", "Hard-Boiled Detective", "FIC022010", "FFL", false},
+	{"thriller", "detective", "Триллер", "Thrillers", "FIC031000", "FH", false},
+	{"detective", "detective", "Детектив", "Detectives", "FIC022000", "FF", false},
+
+	{"prose_classic", "prose", "Классическая проза", "Classics Prose", "FIC004000", "FBC", false},
+	{"prose_history", "prose", "Историческая проза", "Historical Prose", "FIC014000", "FV", false},
+	{"prose_contemporary", "prose", "Современная проза", "Contemporary Prose", "FIC019000", "FBA", false},
+	{"prose_counter", "prose", "Контркультура", "Counterculture", "FIC019000", "FBA", false},
+	{"prose_rus_classic", "prose", "Русская классическая проза", "Russian Classics", "FIC004000", "FBC", false},
+	{"prose_su_classics", "prose", "Советская классическая проза", "Soviet Classics", "FIC004000", "FBC", false},
+	{"prose_military", "prose", "Проза о войне", "Military Prose", "FIC032000", "FJM", true},
+
+	{"love_contemporary", "love", "Современные любовные романы", "Contemporary Romance", "FIC027020", "FRD", false},
+	{"love_history", "love", "Исторические любовные романы", "Historical Romance", "FIC027050", "FRH", false},
+	{"love_detective", "love", "Остросюжетные любовные романы", "Detective Romance", "FIC027110", "FR", false},
+	{"love_short", "love", "Короткие любовные романы", "Short Romance", "FIC027000", "FR", false},
+	{"love_erotica", "love", "Эротика", "Erotica", "FIC005000", "FP", false},
+
+	{"adv_western", "adventure", "Вестерн", "Western", "FIC033000", "FJW", false},
+	{"adv_history", "adventure", "Исторические приключения", "History Adventure", "FIC014000", "FJH", false},
+	{"adv_indian", "adventure", "Приключения про индейцев", "Indians", "FIC002000", "FJ", false},
+	{"adv_maritime", "adventure", "Морские приключения", "Maritime Fiction", "FIC002000", "FJ", false},
+	{"adv_geo", "adventure", "Путешествия и география", "Travel & Geography", "TRV000000", "WT", false},
+	{"adv_animal", "adventure", "Природа и животные", "Nature & Animals", "NAT000000", "WN", false},
+	{"adventure", "adventure", "Приключения", "Adventure", "FIC002000", "FJ", false},
+
+	{"child_tale", "children", "Сказка", "Fairy Tales", "JUV012000", "YFJ", false},
+	{"child_verse", "children", "Детские стихи", "Verses for Children", "JUV000000", "YDP", false},
+	{"child_prose", "children", "Детская проза", "Prose for Children", "JUV000000", "YFB", false},
+	{"child_sf", "children", "Детская фантастика", "Science Fiction for Children", "JUV053000", "YFG", false},
+	{"child_det", "children", "Детские остросюжетные", "Detectives & Thrillers for Children", "JUV028000", "YFCF", false},
+	{"child_adv", "children", "Детские приключения", "Adventures for Children", "JUV001000", "YFC", false},
+	{"child_education", "children", "Детская образовательная литература", "Education for Children", "JNF000000", "YN", false},
+	{"children", "children", "Детская литература", "Children's", "JUV000000", "YF", false},
+
+	{"poetry", "poetry", "Поэзия", "Poetry", "POE000000", "DC", false},
+	{"dramaturgy", "poetry", "Драматургия", "Dramaturgy", "DRA000000", "DD", false},
+
+	{"antique_ant", "antique", "Античная литература", "Antique", "FIC004000", "FBC", false},
+	{"antique_european", "antique", "Европейская старинная литература", "European", "FIC004000", "FBC", false},
+	{"antique_russian", "antique", "Древнерусская литература", "Old Russian", "FIC004000", "FBC", false},
+	{"antique_east", "antique", "Древневосточная литература", "Old East", "FIC004000", "FBC", false},
+	{"antique_myths", "antique", "Мифы, легенды, эпос", "Myths, Legends, Epos", "FIC010000", "FN", false},
+	{"antique", "antique", "Старинная литература", "Antique Literature", "FIC004000", "FBC", false},
+
+	{"sci_history", "science", "История", "History", "HIS000000", "NH", false},
+	{"sci_psychology", "science", "Психология", "Psychology", "PSY000000", "JM", false},
+	{"sci_culture", "science", "Культурология", "Cultural Science", "SOC002010", "JBC", false},
+	{"sci_religion", "science", "Религиоведение", "Religious Studies", "REL000000", "QR", false},
+	{"sci_philosophy", "science", "Философия", "Philosophy", "PHI000000", "QD", false},
+	{"sci_politics", "science", "Политика", "Politics", "POL000000", "JP", false},
+	{"sci_business", "science", "Деловая литература", "Business Literature", "BUS000000", "KJ", false},
+	{"sci_juris", "science", "Юриспруденция", "Jurisprudence", "LAW000000", "L", false},
+	{"sci_linguistic", "science", "Языкознание", "Linguistics", "LAN000000", "CF", false},
+	{"sci_medicine", "science", "Медицина", "Medicine", "MED000000", "M", false},
+	{"sci_phys", "science", "Физика", "Physics", "SCI055000", "PH", false},
+	{"sci_math", "science", "Математика", "Mathematics", "MAT000000", "PB", false},
+	{"sci_chem", "science", "Химия", "Chemistry", "SCI013000", "PN", false},
+	{"sci_biology", "science", "Биология", "Biology", "SCI008000", "PS", false},
+	{"sci_tech", "science", "Технические науки", "Technical Science", "TEC000000", "T", false},
+	{"science", "science", "Научная литература", "Science", "SCI000000", "P", false},
+
+	{"comp_www", "computers", "Интернет", "Internet", "COM060000", "UD", false},
+	{"comp_programming", "computers", "Программирование", "Programming", "COM051000", "UM", false},
+	{"comp_hard", "computers", "Компьютерное железо", "Hardware", "COM067000", "UK", false},
+	{"comp_soft", "computers", "Программы", "Software", "COM000000", "UF", false},
+	{"comp_db", "computers", "Базы данных", "Databases", "COM021000", "UN", false},
+	{"comp_osnet", "computers", "ОС и сети", "OS & Networking", "COM046000", "UL", false},
+	{"computers", "computers", "Компьютерная литература", "Computers", "COM000000", "U", false},
+
+	{"ref_encyc", "reference", "Энциклопедии", "Encyclopedias", "REF007000", "GBC", false},
+	{"ref_dict", "reference", "Словари", "Dictionaries", "REF008000", "CBD", false},
+	{"ref_ref", "reference", "Справочники", "Reference", "REF000000", "GB", false},
+	{"ref_guide", "reference", "Руководства", "Guidebooks", "REF000000", "GB", false},
+	{"reference", "reference", "Справочная литература", "Reference", "REF000000", "GB", false},
+
+	{"nonf_biography", "nonfiction", "Биографии и мемуары", "Biography & Memoirs", "BIO000000", "DNB", false},
+	{"nonf_publicism", "nonfiction", "Публицистика", "Publicism", "LCO010000", "DNL", false},
+	{"nonf_criticism", "nonfiction", "Критика", "Criticism", "LIT000000", "DS", false},
+	{"design", "nonfiction", "Искусство и дизайн", "Art & Design", "ART000000", "A", false},
+	{"nonfiction", "nonfiction", "Документальная литература", "Nonfiction", "NON000000", "DN", false},
+
+	{"religion_rel", "religion", "Религия", "Religion", "REL000000", "QR", false},
+	{"religion_esoterics", "religion", "Эзотерика", "Esoterics", "OCC000000", "QRY", false},
+	{"religion_self", "religion", "Самосовершенствование", "Self-Improvement", "SEL000000", "VS", false},
+	{"religion", "religion", "Религиозная литература", "Religion & Spirituality", "REL000000", "QR", false},
+
+	{"humor_anecdote", "humor", "Анекдоты", "Anecdotes", "HUM000000", "WH", false},
+	{"humor_prose", "humor", "Юмористическая проза", "Humorous Prose", "FIC016000", "FU", false},
+	{"humor_verse", "humor", "Юмористические стихи", "Humorous Verses", "HUM000000", "WH", false},
+	{"humor", "humor", "Юмор", "Humor", "HUM000000", "WH", false},
+
+	{"home_cooking", "home", "Кулинария", "Cooking", "CKB000000", "WB", false},
+	{"home_pets", "home", "Домашние животные", "Pets", "PET000000", "WNG", false},
+	{"home_crafts", "home", "Хобби и ремёсла", "Hobbies & Crafts", "CRA000000", "WF", false},
+	{"home_entertain", "home", "Развлечения", "Entertaining", "GAM000000", "WD", false},
+	{"home_health", "home", "Здоровье", "Health", "HEA000000", "VFD", false},
+	{"home_garden", "home", "Сад и огород", "Garden", "GAR000000", "WM", false},
+	{"home_diy", "home", "Сделай сам", "Do It Yourself", "HOM000000", "WK", false},
+	{"home_sport", "home", "Спорт", "Sports", "SPO000000", "S", false},
+	{"home_sex", "home", "Эротика, секс", "Erotica & Sex", "FAM000000", "VFVC", false},
+	{"home", "home", "Домоводство", "Home", "HOM000000", "WK", false},
+
+	{"job_hunting", "business", "Поиск работы, карьера", "Job Hunting", "BUS037020", "VSC", true},
+	{"management", "business", "Менеджмент", "Management", "BUS041000", "KJM", true},
+	{"marketing", "business", "Маркетинг, PR, реклама", "Marketing", "BUS043000", "KJS", true},
+	{"banking", "business", "Банковское дело", "Banking", "BUS004000", "KFFK", true},
+	{"stock", "business", "Ценные бумаги, инвестиции", "Stock & Investments", "BUS036000", "KFFM", true},
+	{"accounting", "business", "Бухучёт и аудит", "Accounting", "BUS001000", "KFC", true},
+	{"global_economy", "business", "Внешнеэкономическая деятельность", "Global Economy", "BUS035000", "KC", true},
+	{"economics", "business", "Экономика", "Economics", "BUS069000", "KC", true},
+	{"industries", "business", "Отраслевые издания", "Industries", "BUS070000", "KN", true},
+	{"org_behavior", "business", "Корпоративная культура", "Corporate Culture", "BUS085000", "KJU", true},
+	{"personal_finance", "business", "Личные финансы", "Personal Finance", "BUS050000", "VSB", true},
+	{"real_estate", "business", "Недвижимость", "Real Estate", "BUS054000", "KN", true},
+	{"popular_business", "business", "Карьера, кадры", "Popular Business", "BUS000000", "KJ", true},
+	{"small_business", "business", "Малый бизнес", "Small Business", "BUS060000", "KJH", true},
+	{"paper_work", "business", "Делопроизводство", "Paper Work", "BUS000000", "KJ", true},
+	{"economics_ref", "business", "Деловая литература", "Business Reference", "BUS000000", "KJ", true},
+}
+
+// genreAliases map legacy and common genre codes to codes of genre list
+var genreAliases = map[string]string{
+	"fantasy":            "sf_fantasy",
+	"city_fantasy":       "sf_fantasy_city",
+	"urban_fantasy":      "sf_fantasy_city",
+	"sf_etc":             "sf",
+	"sci_fi":             "sf",
+	"science_fiction":    "sf",
+	"postapocalyptic":    "sf_postapocalyptic",
+	"cyberpunk":          "sf_cyberpunk",
+	"steampunk":          "sf_stimpank",
+	"sf_steampunk":       "sf_stimpank",
+	"horror":             "sf_horror",
+	"mystic":             "sf_mystic",
+	"det_cozy":           "det_irony",
+	"crime":              "det_crime",
+	"spy":                "det_espionage",
+	"romance":            "love_contemporary",
+	"love":               "love_contemporary",
+	"love_sf":            "love_contemporary",
+	"erotica":            "love_erotica",
+	"prose":              "prose_contemporary",
+	"prose_sentimental":  "prose_contemporary",
+	"historical_fiction": "prose_history",
+	"prose_war":          "prose_military",
+	"western":            "adv_western",
+	"fairy_tale":         "child_tale",
+	"child_tale_rus":     "child_tale",
+	"poetry_classic":     "poetry",
+	"poem":               "poetry",
+	"drama":              "dramaturgy",
+	"myths":              "antique_myths",
+	"history":            "sci_history",
+	"psychology":         "sci_psychology",
+	"philosophy":         "sci_philosophy",
+	"sci_economy":        "economics",
+	"economy":            "economics",
+	"biography":          "nonf_biography",
+	"memoirs":            "nonf_biography",
+	"essays":             "nonf_publicism",
+	"criticism":          "nonf_criticism",
+	"art":                "design",
+	"esoterics":          "religion_esoterics",
+	"humour":             "humor",
+	"cooking":            "home_cooking",
+	"cookery":            "home_cooking",
+	"sport":              "home_sport",
+	"health":             "home_health",
+	"dictionary":         "ref_dict",
+	"encyclopedia":       "ref_encyc",
+	"programming":        "comp_programming",
+	"internet":           "comp_www",
+}
@@ -0,0 +1,127 @@
+package gofb2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func genreTitleInfo(codes ...string) *TitleInfo {
+	ti := &TitleInfo{}
+	for _, c := range codes {
+		g := &Genre{Genre: c}
+		g.SetXMLName(xml.Name{Local: "genre"})
+		ti.Genres = append(ti.Genres, g)
+	}
+	return ti
+}
+
+func TestGenreList(t *testing.T) {
+	for _, g := range Genres() {
+		if g.Parent() == nil {
+			t.Errorf("genre %s has unknown category %s", g.Code, g.Category)
+		}
+		if g.Ru == "" || g.En == "" {
+			t.Errorf("genre %s has no name", g.Code)
+		}
+	}
+	if len(genreIndex) != len(genreList) {
+		t.Errorf("genre list has duplicates")
+	}
+	for alias, code := range genreAliases {
+		if _, ok := genreIndex[code]; !ok {
+			t.Errorf("alias %s of unknown genre %s", alias, code)
+		}
+		if _, ok := genreIndex[alias]; ok {
+			t.Errorf("alias %s is a genre", alias)
+		}
+	}
+}
+
+func TestLookupGenre(t *testing.T) {
+	tests := []struct {
+		code, canonical, bisac, thema, name string
+	}{
+		{"sf_space", "sf_space", "FIC028030", "FLS", "Space Science Fiction"},
+		{" Fantasy ", "sf_fantasy", "FIC009000", "FM", "Fantasy"},
+		{"fairy_tale", "child_tale", "JUV012000", "YFJ", "Fairy Tales"},
+		{"urban_fantasy", "sf_fantasy_city", "FIC009000", "FM", "Urban Fantasy"},
+	}
+	for _, tt := range tests {
+		if got := CanonicalGenre(tt.code); got != tt.canonical {
+			t.Errorf("canonical genre of %q is %q, want %q", tt.code, got, tt.canonical)
+		}
+		g, ok := LookupGenre(tt.code)
+		if !ok {
+			t.Errorf("genre %q is not found", tt.code)
+			continue
+		}
+		if g.Code != tt.canonical || g.BISAC != tt.bisac || g.Thema != tt.thema || g.Name("en-US") != tt.name {
+			t.Errorf("genre %q is %+v", tt.code, g)
+		}
+	}
+	if g, _ := LookupGenre("det_classic"); g.Name("ru") != "Классический детектив" || g.Parent().Code != "detective" {
+		t.Errorf("genre det_classic is %+v", g)
+	}
+	if g, ok := LookupGenre("no_such_genre"); ok {
+		t.Errorf("unknown genre is found: %+v", g)
+	}
+	if got := CanonicalGenre(" No_Such "); got != "no_such" {
+		t.Errorf("canonical unknown genre is %q", got)
+	}
+}
+
+func TestValidateGenres(t *testing.T) {
+	if err := ValidateGenres(genreTitleInfo("sf_space", "fantasy")); err != nil {
+		t.Error(err)
+	}
+	err := ValidateGenres(genreTitleInfo("sf_space", " bad ", "worse"))
+	if err == nil || err.Error() != "unknown genres: bad, worse" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCanonicalGenres(t *testing.T) {
+	ti := genreTitleInfo("fantasy", "sf_fantasy", "fairy_tale", "other")
+	CanonicalGenres(ti)
+	var got []string
+	for _, g := range ti.Genres {
+		got = append(got, g.Genre)
+	}
+	if want := "sf_fantasy child_tale other"; strings.Join(got, " ") != want {
+		t.Errorf("genres = %q, want %q", got, want)
+	}
+}
+
+func TestEPUBGenreSubjects(t *testing.T) {
+	src := readBook(t, "sample.fb2")
+	src.Description.TitleInfo.Genres = genreTitleInfo("fantasy", "sf_fantasy_city").Genres
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, src, EPUBOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var opf string
+	for name, data := range epubFiles(t, buf.Bytes()) {
+		if strings.HasSuffix(name, ".opf") {
+			opf = data
+		}
+	}
+	// both genres have the same BISAC and Thema subjects
+	for _, s := range []string{">FIC009000</meta>", ">FM</meta>"} {
+		if strings.Count(opf, s) != 1 {
+			t.Errorf("subject %s is written %d times", s, strings.Count(opf, s))
+		}
+	}
+	fb, err := ReadEPUB(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var genres []string
+	for _, g := range fb.Description.TitleInfo.Genres {
+		genres = append(genres, g.Genre)
+	}
+	if want := "fantasy sf_fantasy_city"; strings.Join(genres, " ") != want {
+		t.Errorf("genres = %q, want %q", genres, want)
+	}
+}
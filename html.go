@@ -0,0 +1,530 @@
+package gofb2
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions describe HTML export
+type HTMLOptions struct {
+	// ImageDir is a directory where binaries are extracted to.
+	// If empty, images are embedded as data URIs.
+	ImageDir string
+
+	// ImageURL is a prefix for extracted image links, ImageDir by default
+	ImageURL string
+
+	// Stylesheets enables embedding of the book's text/css stylesheets
+	Stylesheets bool
+
+	// Fragment disables html, head and body tags
+	Fragment bool
+}
+
+var htmlInlineTags = map[string]string{
+	"strong":        "strong",
+	"emphasis":      "em",
+	"strikethrough": "s",
+	"sub":           "sub",
+	"sup":           "sup",
+	"code":          "code",
+}
+
+// WriteHTML write book to w as HTML document
+func WriteHTML(w io.Writer, fb *FictionBook, opts HTMLOptions) error {
+	if fb == nil {
+		return fmt.Errorf("no book to write")
+	}
+	h := &htmlWriter{w: w, opts: opts, binaries: binaryIndex(fb), images: map[string]string{}}
+	if !opts.Fragment {
+		h.head(fb)
+	}
+	if fb.Body != nil {
+		h.body(fb.Body, "")
+	}
+	if fb.NotesBody != nil {
+		h.body(&fb.NotesBody.Body, "notes")
+	}
+	if !opts.Fragment {
+		h.write("</body>\n</html>\n")
+	}
+	return h.err
+}
+
+// uniqueName return name that is not used yet, "-2", "-3" and so on is
+// added before extension of used name
+func uniqueName(name string, used map[string]bool) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; used[name]; i++ {
+		name = base + "-" + strconv.Itoa(i) + ext
+	}
+	used[name] = true
+	return name
+}
+
+// binaryFileName return file name for binary with id, ids like ".."
+// that are not usable as file name get generated name
+func binaryFileName(id string, n int) string {
+	name := path.Base(strings.ReplaceAll(id, "\\", "/"))
+	if name == "." || name == ".." || name == "/" {
+		name = "image-" + strconv.Itoa(n)
+	}
+	return name
+}
+
+// safeSchemes are URL schemes of external links kept in export
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true, "ftp": true}
+
+// safeURL return external link or empty string for links with schemes
+// like javascript: or data: that are unsafe in exported document
+func safeURL(href string) string {
+	// browsers ignore control characters and spaces in scheme
+	clean := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, href)
+	i := strings.IndexAny(clean, ":/?#")
+	if i < 0 || clean[i] != ':' {
+		// relative link
+		return href
+	}
+	if !safeSchemes[strings.ToLower(clean[:i])] {
+		return ""
+	}
+	return href
+}
+
+// binaryIndex return binaries by id
+func binaryIndex(fb *FictionBook) map[string]*Binary {
+	m := make(map[string]*Binary, len(fb.Binary))
+	for _, b := range fb.Binary {
+		m[b.ID] = b
+	}
+	return m
+}
+
+type htmlWriter struct {
+	w        io.Writer
+	opts     HTMLOptions
+	binaries map[string]*Binary
+	images   map[string]string
+	names    map[string]bool
+	err      error
+
+	// files by node id, used when book is split to several files
+	files map[string]string
+	file  string
+	epub  bool
+	// generated ids of sections without id
+	anchors map[*Section]string
+}
+
+func (h *htmlWriter) write(s ...string) {
+	for _, str := range s {
+		if h.err != nil {
+			return
+		}
+		_, h.err = io.WriteString(h.w, str)
+	}
+}
+
+func (h *htmlWriter) text(s string) {
+	h.write(html.EscapeString(s))
+}
+
+// open write start tag, attrs are name-value pairs, empty values are omitted
+func (h *htmlWriter) open(tag string, attrs ...string) {
+	h.tag(tag, ">", attrs)
+}
+
+// empty write void element like img
+func (h *htmlWriter) empty(tag string, attrs ...string) {
+	h.tag(tag, "/>", attrs)
+}
+
+func (h *htmlWriter) tag(tag, end string, attrs []string) {
+	h.write("<", tag)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if attrs[i+1] != "" {
+			h.write(" ", attrs[i], `="`, html.EscapeString(attrs[i+1]), `"`)
+		}
+	}
+	h.write(end)
+}
+
+func (h *htmlWriter) close(tag string) {
+	h.write("</", tag, ">")
+}
+
+func (h *htmlWriter) head(fb *FictionBook) {
+	var lang, title string
+	var authors []string
+	if fb.Description != nil && fb.Description.TitleInfo != nil {
+		ti := fb.Description.TitleInfo
+		lang = ti.Lang
+		if ti.BookTitle != nil {
+			title = ti.BookTitle.Value
+		}
+		for _, a := range ti.Authors {
+			authors = append(authors, authorName(a))
+		}
+	}
+	h.write("<!DOCTYPE html>\n")
+	h.open("html", "lang", lang)
+	h.write("\n<head>\n<meta charset=\"utf-8\"/>\n<title>")
+	h.text(title)
+	h.write("</title>\n")
+	if len(authors) > 0 {
+		h.write(`<meta name="author" content="`, html.EscapeString(strings.Join(authors, ", ")), "\"/>\n")
+	}
+	if h.opts.Stylesheets {
+		for _, s := range fb.Stylesheet {
+			if s.Type == "text/css" {
+				h.write("<style>\n", strings.ReplaceAll(string(s.Value), "</", `<\/`), "\n</style>\n")
+			}
+		}
+	}
+	h.write("</head>\n<body>\n")
+}
+
+// authorName return author name in "First Middle Last" form
+func authorName(a *Author) string {
+	var parts []string
+	for _, f := range []*TextField{a.FirstName, a.MiddleName, a.LastName} {
+		if f != nil && strings.TrimSpace(f.Value) != "" {
+			parts = append(parts, strings.TrimSpace(f.Value))
+		}
+	}
+	if len(parts) == 0 && a.Nickname != nil {
+		return strings.TrimSpace(a.Nickname.Value)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (h *htmlWriter) body(b *Body, class string) {
+	h.open("div", "class", class, "lang", b.Lang)
+	h.write("\n")
+	if b.Image != nil {
+		h.image(b.Image)
+	}
+	if b.Title != nil {
+		h.title(b.Title, 1)
+	}
+	for _, ep := range b.Epigraphs {
+		h.epigraph(ep)
+	}
+	for _, s := range b.Sections {
+		h.section(s, 2)
+	}
+	h.close("div")
+	h.write("\n")
+}
+
+func (h *htmlWriter) section(s *Section, level int) {
+	h.sectionStart(s, level)
+	for _, cs := range s.Sections {
+		h.section(cs, level+1)
+	}
+	h.blocks(s.Content)
+	h.sectionEnd()
+}
+
+// sectionStart open section and write its title, epigraphs, image and annotation
+func (h *htmlWriter) sectionStart(s *Section, level int) {
+	id := s.ID
+	if id == "" {
+		id = h.anchors[s]
+	}
+	h.open("section", "id", id, "lang", s.Lang)
+	h.write("\n")
+	if s.Title != nil {
+		h.title(s.Title, level)
+	}
+	for _, ep := range s.Epigraphs {
+		h.epigraph(ep)
+	}
+	if s.Image != nil {
+		h.image(s.Image)
+	}
+	if s.Annotation != nil {
+		h.open("div", "class", "annotation", "id", s.Annotation.ID, "lang", s.Annotation.Lang)
+		h.write("\n")
+		h.blocks(s.Annotation.Content)
+		h.close("div")
+		h.write("\n")
+	}
+}
+
+func (h *htmlWriter) sectionEnd() {
+	h.close("section")
+	h.write("\n")
+}
+
+func (h *htmlWriter) title(t *Title, level int) {
+	if level > 6 {
+		level = 6
+	}
+	tag := "h" + strconv.Itoa(level)
+	h.open(tag, "lang", t.Lang)
+	first := true
+	for _, c := range t.Content {
+		if !first {
+			h.write("<br/>")
+		}
+		if p, ok := c.(*P); ok {
+			h.inline(p.Content)
+		}
+		first = false
+	}
+	h.close(tag)
+	h.write("\n")
+}
+
+func (h *htmlWriter) epigraph(ep *Epigraph) {
+	h.open("blockquote", "class", "epigraph", "id", ep.ID)
+	h.write("\n")
+	h.blocks(ep.Content)
+	for _, a := range ep.TextAuthor {
+		h.paragraph(a, "text-author")
+	}
+	h.close("blockquote")
+	h.write("\n")
+}
+
+func (h *htmlWriter) paragraph(p *P, class string) {
+	if p.Style != "" {
+		class = strings.TrimSpace(class + " " + p.Style)
+	}
+	h.open("p", "id", p.ID, "class", class, "lang", p.Lang)
+	h.inline(p.Content)
+	h.close("p")
+	h.write("\n")
+}
+
+func (h *htmlWriter) blocks(cont []Contenter) {
+	for _, c := range cont {
+		switch e := c.(type) {
+		case *P:
+			if e.GetXMLName().Local == "subtitle" {
+				h.paragraph(e, "subtitle")
+			} else {
+				h.paragraph(e, "")
+			}
+		case *EmptyLine:
+			h.write("<br/>\n")
+		case *Image:
+			h.image(e)
+		case *Poem:
+			h.poem(e)
+		case *Cite:
+			h.open("blockquote", "class", "cite", "id", e.ID, "lang", e.Lang)
+			h.write("\n")
+			h.blocks(e.Content)
+			for _, a := range e.TextAuthor {
+				h.paragraph(a, "text-author")
+			}
+			h.close("blockquote")
+			h.write("\n")
+		case *Table:
+			h.table(e)
+		case *Section:
+			h.section(e, 6)
+		default:
+			h.blocks(c.GetContent())
+		}
+	}
+}
+
+func (h *htmlWriter) poem(p *Poem) {
+	h.write(`<div class="poem">`, "\n")
+	if p.Title != nil {
+		h.title(p.Title, 6)
+	}
+	for _, ep := range p.Epigraphs {
+		h.epigraph(ep)
+	}
+	for _, c := range p.Content {
+		switch e := c.(type) {
+		case *Stanza:
+			h.open("div", "class", "stanza", "lang", e.Lang)
+			h.write("\n")
+			if e.Title != nil {
+				h.title(e.Title, 6)
+			}
+			if e.Subtitle != nil {
+				h.paragraph(e.Subtitle, "subtitle")
+			}
+			for _, v := range e.V {
+				h.paragraph(v, "v")
+			}
+			h.close("div")
+			h.write("\n")
+		case *P:
+			h.paragraph(e, "subtitle")
+		}
+	}
+	h.close("div")
+	h.write("\n")
+}
+
+func (h *htmlWriter) table(t *Table) {
+	h.open("table", "id", t.ID, "class", t.Style)
+	h.write("\n")
+	for _, tr := range t.TR {
+		h.open("tr", "style", alignStyle(tr.Align, ""))
+		for _, c := range tr.Content {
+			td, ok := c.(*TD)
+			if !ok {
+				continue
+			}
+			tag := td.GetXMLName().Local
+			if tag != "th" {
+				tag = "td"
+			}
+			var colspan, rowspan string
+			if td.Colspan > 1 {
+				colspan = strconv.Itoa(td.Colspan)
+			}
+			if td.Rowspan > 1 {
+				rowspan = strconv.Itoa(td.Rowspan)
+			}
+			h.open(tag,
+				"id", td.ID,
+				"class", td.Style,
+				"colspan", colspan,
+				"rowspan", rowspan,
+				"style", alignStyle(td.Align, td.Valign),
+				"lang", td.Lang,
+			)
+			h.inline(td.Content)
+			h.close(tag)
+		}
+		h.close("tr")
+		h.write("\n")
+	}
+	h.close("table")
+	h.write("\n")
+}
+
+func alignStyle(align, valign string) string {
+	var s []string
+	if align != "" {
+		s = append(s, "text-align: "+align)
+	}
+	if valign != "" {
+		s = append(s, "vertical-align: "+valign)
+	}
+	return strings.Join(s, "; ")
+}
+
+func (h *htmlWriter) inline(cont []Contenter) {
+	for _, c := range cont {
+		switch e := c.(type) {
+		case CharData:
+			h.text(string(e))
+		case *Link:
+			class, epubType := "", ""
+			if e.Type == "note" {
+				class = "note"
+				if h.epub {
+					epubType = "noteref"
+				}
+			}
+			h.open("a", "href", h.href(e.XlinkHref), "class", class, "epub:type", epubType)
+			h.inline(e.Content)
+			h.close("a")
+		case *InlineImage:
+			h.empty("img", "src", h.imageSrc(e.XlinkHref), "alt", e.Alt)
+		case *NamedStyleType:
+			h.open("span", "class", e.Name, "lang", e.Lang)
+			h.inline(e.Content)
+			h.close("span")
+		case *StyleType:
+			h.styled(e.GetXMLName().Local, e.Lang, e.Content)
+		case *StyleLinkType:
+			h.styled(e.GetXMLName().Local, "", e.Content)
+		default:
+			h.inline(c.GetContent())
+		}
+	}
+}
+
+func (h *htmlWriter) styled(name, lang string, cont []Contenter) {
+	tag, ok := htmlInlineTags[name]
+	if !ok {
+		tag = "span"
+	}
+	h.open(tag, "lang", lang)
+	h.inline(cont)
+	h.close(tag)
+}
+
+func (h *htmlWriter) image(i *Image) {
+	h.write(`<div class="image">`)
+	h.empty("img", "id", i.ID, "src", h.imageSrc(i.XlinkHref), "alt", i.Alt, "title", i.Title)
+	h.write("</div>\n")
+}
+
+// href return link to node with id in other file if book is split
+func (h *htmlWriter) href(href string) string {
+	id, ok := localID(href)
+	if !ok {
+		return safeURL(href)
+	}
+	if f, ok := h.files[id]; ok && f != h.file {
+		return f + href
+	}
+	return href
+}
+
+// imageSrc return data URI or path to extracted file for binary referenced by href
+func (h *htmlWriter) imageSrc(href string) string {
+	id, ok := localID(href)
+	if !ok {
+		return safeURL(href)
+	}
+	if src, ok := h.images[id]; ok {
+		return src
+	}
+	b, ok := h.binaries[id]
+	if !ok {
+		return href
+	}
+	var src string
+	if h.opts.ImageDir == "" {
+		src = fmt.Sprintf("data:%s;base64,%s", b.ContentType, base64.StdEncoding.EncodeToString(b.Value))
+	} else {
+		if h.names == nil {
+			h.names = map[string]bool{}
+		}
+		// binaries with the same base name must not overwrite each other
+		name := uniqueName(binaryFileName(id, len(h.names)+1), h.names)
+		if h.err == nil {
+			if err := os.MkdirAll(h.opts.ImageDir, 0755); err != nil {
+				h.err = err
+			} else {
+				h.err = ioutil.WriteFile(filepath.Join(h.opts.ImageDir, name), b.Value, 0644)
+			}
+		}
+		prefix := h.opts.ImageURL
+		if prefix == "" {
+			prefix = filepath.ToSlash(h.opts.ImageDir)
+		}
+		// path.Join would collapse "//" of URL scheme
+		src = strings.TrimSuffix(prefix, "/") + "/" + url.PathEscape(name)
+	}
+	h.images[id] = src
+	return src
+}
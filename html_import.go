@@ -0,0 +1,970 @@
+package gofb2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// htmlNode is an element or a text node of parsed (X)HTML document
+type htmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*htmlNode
+	text     string
+}
+
+func (n *htmlNode) isText() bool {
+	return n.name == ""
+}
+
+// attr return value of attribute with local name
+func (n *htmlNode) attr(name string) string {
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// epubType return epub:type or role attribute
+func (n *htmlNode) epubType() string {
+	for _, a := range n.attrs {
+		if a.Name.Local == "type" && a.Name.Space != "" {
+			return a.Value
+		}
+	}
+	return strings.TrimPrefix(n.attr("role"), "doc-")
+}
+
+// find return first element with name in depth-first order
+func (n *htmlNode) find(name string) *htmlNode {
+	if n.name == name {
+		return n
+	}
+	for _, c := range n.children {
+		if f := c.find(name); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// textContent return text of node and its children
+func (n *htmlNode) textContent() string {
+	if n.isText() {
+		return n.text
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		b.WriteString(c.textContent())
+	}
+	return b.String()
+}
+
+// parseHTML parse XHTML or not well-formed HTML document
+func parseHTML(r io.Reader) (*htmlNode, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	root := &htmlNode{name: "#document"}
+	stack := []*htmlNode{root}
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch e := token.(type) {
+		case xml.StartElement:
+			n := &htmlNode{name: strings.ToLower(e.Name.Local), attrs: e.Attr}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			name := strings.ToLower(e.Name.Local)
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		case xml.CharData:
+			top.children = append(top.children, &htmlNode{text: string(e)})
+		}
+	}
+	return root, nil
+}
+
+// ReadHTML convert simple HTML manuscript to FictionBook. Headings become
+// sections, when document has no headings they are detected in paragraphs
+// like "Chapter N". Paragraphs of short lines separated by <br/> become
+// poems, empty paragraphs become empty lines and blockquotes become cites.
+// Images are kept only for data URIs.
+func ReadHTML(r io.Reader, opts ImportOptions) (*FictionBook, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseHTML(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	fb := &FictionBook{}
+	fb.SetXMLName(xml.Name{Local: "FictionBook"})
+
+	h := newHTMLImporter()
+	h.manuscript = true
+	h.headings = true
+	for _, name := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
+		if doc.find(name) != nil {
+			h.headings = false
+		}
+	}
+	h.image = func(file, src string) string {
+		b := dataURIBinary(src, len(fb.Binary)+1)
+		if b == nil {
+			return ""
+		}
+		fb.Binary = append(fb.Binary, b)
+		return "#" + b.ID
+	}
+	h.startFile("")
+	body := doc.find("body")
+	if body == nil {
+		body = doc
+	}
+	h.blocks(body.children)
+	h.resolveLinks(func(file, href string) (string, bool) {
+		return href, strings.HasPrefix(href, "#")
+	})
+	fb.Body = h.body
+	fb.NotesBody = h.notes
+	fillEmptySections(fb.Body.Sections)
+
+	if opts.Title == "" {
+		if t := doc.find("title"); t != nil {
+			opts.Title = collapseSpace(strings.TrimSpace(t.textContent()))
+		}
+	}
+	if opts.Title == "" {
+		if t := doc.find("h1"); t != nil {
+			opts.Title = collapseSpace(strings.TrimSpace(t.textContent()))
+		}
+	}
+	if html := doc.find("html"); html != nil && opts.Lang == "" {
+		opts.Lang = html.attr("lang")
+	}
+	if opts.Lang == "" {
+		opts.Lang = detectLang(body.textContent())
+	}
+	metas := map[string]string{}
+	var findMetas func(n *htmlNode)
+	findMetas = func(n *htmlNode) {
+		if n.name == "meta" {
+			metas[strings.ToLower(n.attr("name"))] = strings.TrimSpace(n.attr("content"))
+		}
+		for _, c := range n.children {
+			findMetas(c)
+		}
+	}
+	if head := doc.find("head"); head != nil {
+		findMetas(head)
+	}
+	if opts.Author == "" {
+		opts.Author = metas["author"]
+	}
+	fb.Description = importDescription(opts, data)
+	ti := fb.Description.TitleInfo
+	if s := metas["description"]; s != "" {
+		ti.Annotation = &Annotation{}
+		ti.Annotation.SetXMLName(xml.Name{Local: "annotation"})
+		p := newP("p")
+		p.Content = []Contenter{CharData(s)}
+		ti.Annotation.appendContent(p)
+	}
+	if s := metas["keywords"]; s != "" {
+		ti.Keywords = textField("keywords", s)
+	}
+	return fb, nil
+}
+
+// dataURIBinary return binary with content of base64 data URI or nil
+func dataURIBinary(src string, n int) *Binary {
+	if !strings.HasPrefix(src, "data:") {
+		return nil
+	}
+	i := strings.Index(src, ",")
+	if i < 0 || !strings.HasSuffix(src[:i], ";base64") {
+		return nil
+	}
+	contentType := strings.TrimSuffix(src[len("data:"):i], ";base64")
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(src[i+1:]), ""))
+	if err != nil || !strings.HasPrefix(contentType, "image/") {
+		return nil
+	}
+	ext := strings.TrimSuffix(strings.TrimPrefix(contentType, "image/"), "+xml")
+	if ext == "jpeg" {
+		ext = "jpg"
+	}
+	b := &Binary{ID: "image" + strconv.Itoa(n) + "." + ext, ContentType: contentType, Value: data}
+	b.SetXMLName(xml.Name{Local: "binary"})
+	return b
+}
+
+var htmlStyles = map[string]string{
+	"em":     "emphasis",
+	"i":      "emphasis",
+	"cite":   "emphasis",
+	"dfn":    "emphasis",
+	"var":    "emphasis",
+	"strong": "strong",
+	"b":      "strong",
+	"s":      "strikethrough",
+	"strike": "strikethrough",
+	"del":    "strikethrough",
+	"sub":    "sub",
+	"sup":    "sup",
+	"code":   "code",
+	"tt":     "code",
+	"kbd":    "code",
+	"samp":   "code",
+}
+
+var htmlBlocks = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true,
+	"header": true, "footer": true, "aside": true, "nav": true, "figure": true,
+	"figcaption": true, "blockquote": true, "ul": true, "ol": true, "li": true,
+	"dl": true, "dt": true, "dd": true, "table": true, "pre": true, "hr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"body": true, "center": true, "address": true, "svg": true,
+}
+
+var htmlSkip = map[string]bool{
+	"head": true, "script": true, "style": true, "title": true, "noscript": true,
+}
+
+// htmlImporter convert HTML tree to book sections
+type htmlImporter struct {
+	body  *Body
+	notes *NotesBody
+
+	// current section stack, levels are heading levels
+	stack  []*Section
+	levels []int
+	target *contentBase
+
+	file      string
+	pendingID string
+	note      *Section
+
+	// manuscript enable heuristics for verse, empty lines and citation
+	// authors, headings enable detection of chapter headings in paragraphs
+	manuscript bool
+	headings   bool
+
+	// image return binary href for img src or empty string
+	image func(file, src string) string
+	// id return unique id for element id in file
+	id func(file, id string) string
+
+	links    []htmlLink
+	noteIDs  map[string]bool
+	sections map[string]*Section
+}
+
+// htmlLink is a link waiting for resolving after all files are imported
+type htmlLink struct {
+	link *Link
+	file string
+	href string
+}
+
+func newHTMLImporter() *htmlImporter {
+	h := &htmlImporter{
+		body:     &Body{},
+		noteIDs:  map[string]bool{},
+		sections: map[string]*Section{},
+	}
+	h.body.SetXMLName(xml.Name{Local: "body"})
+	h.image = func(file, src string) string { return "" }
+	h.id = func(file, id string) string { return id }
+	return h
+}
+
+// startFile start new top level section for file
+func (h *htmlImporter) startFile(file string) {
+	h.file = file
+	s := newSection()
+	h.body.Sections = append(h.body.Sections, s)
+	h.stack = []*Section{s}
+	h.levels = []int{0}
+	h.target = &s.contentBase
+	h.sections[file] = s
+}
+
+func (h *htmlImporter) current() *Section {
+	return h.stack[len(h.stack)-1]
+}
+
+func newSection() *Section {
+	s := &Section{}
+	s.SetXMLName(xml.Name{Local: "section"})
+	return s
+}
+
+func newP(name string) *P {
+	p := &P{}
+	p.SetXMLName(xml.Name{Local: name})
+	return p
+}
+
+func (h *htmlImporter) appendBlock(c Contenter) {
+	if h.target == nil {
+		h.startFile(h.file)
+	}
+	if s := h.current(); h.target == &s.contentBase && len(s.Sections) > 0 {
+		// content after subsections goes to a new untitled section
+		cs := newSection()
+		s.Sections = append(s.Sections, cs)
+		h.stack = append(h.stack, cs)
+		h.levels = append(h.levels, h.levels[len(h.levels)-1]+1)
+		h.target = &cs.contentBase
+	}
+	h.target.appendContent(c)
+}
+
+// title return title with paragraph for every line of nodes or nil
+func (h *htmlImporter) title(nodes []*htmlNode) *Title {
+	title := &Title{}
+	title.SetXMLName(xml.Name{Local: "title"})
+	for _, line := range splitLines(nodes) {
+		p := newP("p")
+		p.Content = h.inline(line, false)
+		trimContent(&p.contentBase)
+		if len(p.Content) > 0 {
+			title.appendContent(p)
+		}
+	}
+	if len(title.Content) == 0 {
+		return nil
+	}
+	return title
+}
+
+// splitLines split inline nodes by <br/>
+func splitLines(nodes []*htmlNode) [][]*htmlNode {
+	var lines [][]*htmlNode
+	var line []*htmlNode
+	for _, n := range nodes {
+		if n.name == "br" {
+			lines = append(lines, line)
+			line = nil
+			continue
+		}
+		line = append(line, n)
+	}
+	return append(lines, line)
+}
+
+// heading start new section with title
+func (h *htmlImporter) heading(level int, n *htmlNode) {
+	title := h.title(n.children)
+	if title == nil {
+		return
+	}
+
+	root := h.stack[0]
+	var s *Section
+	if len(h.stack) == 1 && root.Title == nil && len(root.Content) == 0 && len(root.Sections) == 0 {
+		s = root
+		h.levels[0] = level
+	} else {
+		for len(h.stack) > 1 && h.levels[len(h.levels)-1] >= level {
+			h.stack = h.stack[:len(h.stack)-1]
+			h.levels = h.levels[:len(h.levels)-1]
+		}
+		s = newSection()
+		if len(h.stack) == 1 && h.levels[0] >= level {
+			// sibling of file section
+			h.body.Sections = append(h.body.Sections, s)
+			h.stack = []*Section{s}
+			h.levels = []int{level}
+		} else {
+			parent := h.current()
+			if len(parent.Content) > 0 {
+				// section can't have both content and subsections
+				first := newSection()
+				first.Content = parent.Content
+				parent.Content = nil
+				parent.Sections = append(parent.Sections, first)
+			}
+			parent.Sections = append(parent.Sections, s)
+			h.stack = append(h.stack, s)
+			h.levels = append(h.levels, level)
+		}
+	}
+	s.Title = title
+	if id := n.attr("id"); id != "" {
+		s.ID = h.id(h.file, id)
+	} else if h.pendingID != "" {
+		s.ID, h.pendingID = h.pendingID, ""
+	}
+	h.target = &s.contentBase
+}
+
+// blocks convert block level nodes
+func (h *htmlImporter) blocks(nodes []*htmlNode) {
+	var run []*htmlNode
+	flush := func() {
+		if len(run) > 0 {
+			h.paragraph(run, "p", "")
+			run = nil
+		}
+	}
+	for _, n := range nodes {
+		if n.isText() || (!htmlBlocks[n.name] && !htmlSkip[n.name] && n.name != "img" && n.name != "br" && n.name != "image") {
+			run = append(run, n)
+			continue
+		}
+		flush()
+		h.block(n)
+	}
+	flush()
+}
+
+func (h *htmlImporter) block(n *htmlNode) {
+	if htmlSkip[n.name] {
+		return
+	}
+	if id := n.attr("id"); id != "" && n.name != "p" && !isHeadingTag(n.name) {
+		h.pendingID = h.id(h.file, id)
+	}
+	switch t := n.epubType(); {
+	case t == "footnote" || t == "endnote" || t == "rearnote" || t == "note":
+		h.footnote(n)
+		return
+	case t == "toc" || t == "landmarks" || t == "page-list":
+		return
+	}
+	switch n.name {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(n.name[1:])
+		if h.note != nil && h.note.Title == nil && len(h.note.Content) == 0 {
+			h.note.Title = h.title(n.children)
+			return
+		}
+		if h.note != nil || h.target != nil && h.target != &h.current().contentBase {
+			// heading inside cite or other block
+			h.paragraph(n.children, "subtitle", n.attr("id"))
+			return
+		}
+		h.heading(level, n)
+	case "p", "dt", "dd", "figcaption", "address":
+		if h.hasBlocks(n) {
+			h.blocks(n.children)
+			return
+		}
+		if h.manuscript && h.structure(n) {
+			return
+		}
+		name := "p"
+		if hasClass(n, "subtitle") {
+			name = "subtitle"
+		} else if hasClass(n, "text-author") && h.inCite() {
+			name = "text-author"
+		}
+		h.paragraph(n.children, name, n.attr("id"))
+	case "blockquote":
+		h.cite(n)
+	case "ul", "ol":
+		h.list(n)
+	case "table":
+		h.table(n)
+	case "pre":
+		h.pre(n)
+	case "hr":
+		el := &EmptyLine{}
+		el.SetXMLName(xml.Name{Local: "empty-line"})
+		h.appendBlock(el)
+	case "br":
+		if h.target != nil && len(h.target.Content) > 0 {
+			el := &EmptyLine{}
+			el.SetXMLName(xml.Name{Local: "empty-line"})
+			h.appendBlock(el)
+		}
+	case "img", "image", "svg":
+		h.blockImage(n)
+	case "div":
+		if hasClass(n, "poem") {
+			h.poem(n)
+			return
+		}
+		h.blocks(n.children)
+	default:
+		if !h.hasBlocks(n) {
+			h.paragraph(n.children, "p", "")
+			return
+		}
+		h.blocks(n.children)
+	}
+}
+
+// inCite report whether blocks go to cite or epigraph
+func (h *htmlImporter) inCite() bool {
+	return h.target != &h.current().contentBase && (h.note == nil || h.target != &h.note.contentBase)
+}
+
+// isHeadingTag report whether name is h1-h6
+func isHeadingTag(name string) bool {
+	return len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6'
+}
+
+// hasClass report whether class attribute of n contains class
+func hasClass(n *htmlNode, class string) bool {
+	for _, c := range strings.Fields(n.attr("class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *htmlImporter) hasBlocks(n *htmlNode) bool {
+	for _, c := range n.children {
+		if !c.isText() && htmlBlocks[c.name] {
+			return true
+		}
+	}
+	return false
+}
+
+// paragraph add paragraphs from inline nodes, <br/> splits paragraph
+func (h *htmlImporter) paragraph(nodes []*htmlNode, name, id string) {
+	for _, l := range splitLines(nodes) {
+		p := newP(name)
+		p.Content = h.inline(l, false)
+		trimContent(&p.contentBase)
+		if len(p.Content) == 0 {
+			continue
+		}
+		if id != "" {
+			p.ID, id = h.id(h.file, id), ""
+		} else if h.pendingID != "" {
+			p.ID, h.pendingID = h.pendingID, ""
+		}
+		h.appendBlock(p)
+	}
+}
+
+// inline convert inline nodes to mixed content
+func (h *htmlImporter) inline(nodes []*htmlNode, inLink bool) []Contenter {
+	var res []Contenter
+	for _, n := range nodes {
+		if n.isText() {
+			if n.text != "" {
+				res = append(res, CharData(n.text))
+			}
+			continue
+		}
+		if htmlSkip[n.name] {
+			continue
+		}
+		if style, ok := htmlStyles[n.name]; ok {
+			if inLink {
+				st := &StyleLinkType{}
+				st.SetXMLName(xml.Name{Local: style})
+				st.Content = h.inline(n.children, true)
+				res = append(res, st)
+			} else {
+				st := &StyleType{}
+				st.SetXMLName(xml.Name{Local: style})
+				st.Content = h.inline(n.children, false)
+				res = append(res, st)
+			}
+			continue
+		}
+		switch n.name {
+		case "a":
+			href := n.attr("href")
+			if href == "" || inLink {
+				res = append(res, h.inline(n.children, inLink)...)
+				continue
+			}
+			l := &Link{}
+			l.SetXMLName(xml.Name{Local: "a"})
+			l.Content = h.inline(n.children, true)
+			if t := n.epubType(); t == "noteref" {
+				l.Type = "note"
+			}
+			h.links = append(h.links, htmlLink{link: l, file: h.file, href: href})
+			res = append(res, l)
+		case "img", "image":
+			if href := h.image(h.file, imageSrc(n)); href != "" {
+				i := &InlineImage{XlinkHref: href, Alt: n.attr("alt")}
+				i.SetXMLName(xml.Name{Local: "image"})
+				res = append(res, i)
+			} else if alt := n.attr("alt"); alt != "" {
+				res = append(res, CharData(alt))
+			}
+		case "br":
+			res = append(res, CharData(" "))
+		default:
+			res = append(res, h.inline(n.children, inLink)...)
+		}
+	}
+	return res
+}
+
+func imageSrc(n *htmlNode) string {
+	if src := n.attr("src"); src != "" {
+		return src
+	}
+	return n.attr("href")
+}
+
+func (h *htmlImporter) blockImage(n *htmlNode) {
+	if n.name == "svg" {
+		if img := n.find("image"); img != nil {
+			n = img
+		} else {
+			return
+		}
+	}
+	href := h.image(h.file, imageSrc(n))
+	if href == "" {
+		return
+	}
+	i := &Image{XlinkHref: href, Alt: n.attr("alt"), Title: n.attr("title")}
+	i.SetXMLName(xml.Name{Local: "image"})
+	if h.pendingID != "" {
+		i.ID, h.pendingID = h.pendingID, ""
+	}
+	h.appendBlock(i)
+}
+
+// withTarget convert nodes to content of other node
+func (h *htmlImporter) withTarget(target *contentBase, fn func()) {
+	old := h.target
+	h.target = target
+	fn()
+	h.target = old
+}
+
+func (h *htmlImporter) cite(n *htmlNode) {
+	c := &Cite{}
+	c.SetXMLName(xml.Name{Local: "cite"})
+	if h.pendingID != "" {
+		c.ID, h.pendingID = h.pendingID, ""
+	}
+	h.withTarget(&c.contentBase, func() {
+		h.blocks(n.children)
+	})
+	// cite can't contain images and other cites
+	var cont []Contenter
+	for _, cc := range c.Content {
+		switch e := cc.(type) {
+		case *Image:
+		case *Cite:
+			cont = append(cont, e.Content...)
+		case *P:
+			if e.GetXMLName().Local == "text-author" {
+				c.TextAuthor = append(c.TextAuthor, e)
+			} else {
+				cont = append(cont, cc)
+			}
+		default:
+			cont = append(cont, cc)
+		}
+	}
+	c.Content = cont
+	if last := len(c.Content) - 1; h.manuscript && len(c.TextAuthor) == 0 && last > 0 {
+		// last line like "— Author" is an author of citation
+		if p, ok := c.Content[last].(*P); ok && p.GetXMLName().Local == "p" {
+			if author, ok := textAuthorLine(contentText(p.Content)); ok {
+				ta := newP("text-author")
+				ta.Content = []Contenter{CharData(author)}
+				c.TextAuthor = append(c.TextAuthor, ta)
+				c.Content = c.Content[:last]
+			}
+		}
+	}
+	if len(c.Content) > 0 {
+		h.appendBlock(c)
+	}
+}
+
+// poem convert div.poem with div.stanza of p.v lines
+func (h *htmlImporter) poem(n *htmlNode) {
+	p := newPoem()
+	for _, c := range n.children {
+		switch {
+		case c.name == "div" && hasClass(c, "stanza"):
+			st := newStanza()
+			for _, v := range c.children {
+				switch {
+				case isHeadingTag(v.name):
+					st.Title = h.title(v.children)
+				case v.name == "p" && hasClass(v, "subtitle"):
+					st.Subtitle = newP("subtitle")
+					st.Subtitle.Content = h.inline(v.children, false)
+					trimContent(&st.Subtitle.contentBase)
+				case v.name == "p":
+					h.stanzaLines(st, v.children)
+				}
+			}
+			if len(st.V) > 0 {
+				p.appendContent(st)
+			}
+		case isHeadingTag(c.name):
+			p.Title = h.title(c.children)
+		case c.name == "p":
+			sub := newP("subtitle")
+			sub.Content = h.inline(c.children, false)
+			trimContent(&sub.contentBase)
+			if len(sub.Content) > 0 {
+				p.appendContent(sub)
+			}
+		}
+	}
+	if len(p.Content) > 0 {
+		h.appendBlock(p)
+	}
+}
+
+func newPoem() *Poem {
+	p := &Poem{}
+	p.SetXMLName(xml.Name{Local: "poem"})
+	return p
+}
+
+func newStanza() *Stanza {
+	st := &Stanza{}
+	st.SetXMLName(xml.Name{Local: "stanza"})
+	return st
+}
+
+// stanzaLines add verse line for every line of inline nodes
+func (h *htmlImporter) stanzaLines(st *Stanza, nodes []*htmlNode) {
+	for _, line := range splitLines(nodes) {
+		v := newP("v")
+		v.Content = h.inline(line, false)
+		trimContent(&v.contentBase)
+		if len(v.Content) > 0 {
+			st.V = append(st.V, v)
+		}
+	}
+}
+
+// structure apply manuscript heuristics to paragraph,
+// return false if n is an ordinary paragraph
+func (h *htmlImporter) structure(n *htmlNode) bool {
+	if h.note != nil || h.target != &h.current().contentBase {
+		return false
+	}
+	lines := splitLines(n.children)
+	var text []string
+	for _, l := range lines {
+		var b strings.Builder
+		for _, c := range l {
+			b.WriteString(c.textContent())
+		}
+		if s := strings.TrimSpace(collapseSpace(b.String())); s != "" {
+			text = append(text, s)
+		}
+	}
+	switch {
+	case len(text) == 0:
+		if len(h.target.Content) > 0 {
+			el := &EmptyLine{}
+			el.SetXMLName(xml.Name{Local: "empty-line"})
+			h.appendBlock(el)
+		}
+	case len(text) > 1 && isVerse(text, verseLength):
+		st := newStanza()
+		h.stanzaLines(st, n.children)
+		if cont := h.target.Content; len(cont) > 0 {
+			if p, ok := cont[len(cont)-1].(*Poem); ok {
+				p.appendContent(st)
+				return true
+			}
+		}
+		p := newPoem()
+		p.appendContent(st)
+		h.appendBlock(p)
+	case len(text) > 1 || !h.headings:
+		return false
+	case headingLevel(text[0]) > 0:
+		h.heading(headingLevel(text[0]), n)
+	case isCapsLine(text[0]):
+		if s := h.current(); s.Title != nil && len(s.Content) == 0 && len(s.Sections) == 0 {
+			// second line of previous heading
+			if t := h.title(n.children); t != nil {
+				s.Title.Content = append(s.Title.Content, t.Content...)
+			}
+			return true
+		}
+		h.heading(chapterLevel, n)
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *htmlImporter) list(n *htmlNode) {
+	i := 0
+	for _, li := range n.children {
+		if li.name != "li" {
+			continue
+		}
+		i++
+		marker := "• "
+		if n.name == "ol" {
+			marker = strconv.Itoa(i) + ". "
+		}
+		var nested []*htmlNode
+		var inline []*htmlNode
+		for _, c := range li.children {
+			if c.name == "ul" || c.name == "ol" {
+				nested = append(nested, c)
+			} else {
+				inline = append(inline, c)
+			}
+		}
+		p := newP("p")
+		p.Content = append([]Contenter{CharData(marker)}, h.inline(inline, false)...)
+		trimContent(&p.contentBase)
+		h.appendBlock(p)
+		for _, c := range nested {
+			h.list(c)
+		}
+	}
+}
+
+func (h *htmlImporter) pre(n *htmlNode) {
+	for _, line := range strings.Split(strings.Trim(n.textContent(), "\n"), "\n") {
+		p := newP("p")
+		if strings.TrimSpace(line) != "" {
+			code := &StyleType{}
+			code.SetXMLName(xml.Name{Local: "code"})
+			code.Content = []Contenter{CharData(line)}
+			p.Content = []Contenter{code}
+		}
+		h.appendBlock(p)
+	}
+}
+
+func (h *htmlImporter) table(n *htmlNode) {
+	t := &Table{}
+	t.SetXMLName(xml.Name{Local: "table"})
+	if id := n.attr("id"); id != "" {
+		t.ID = h.id(h.file, id)
+	}
+	var rows func(*htmlNode)
+	rows = func(n *htmlNode) {
+		for _, c := range n.children {
+			switch c.name {
+			case "thead", "tbody", "tfoot":
+				rows(c)
+			case "tr":
+				tr := &TR{Align: c.attr("align")}
+				tr.SetXMLName(xml.Name{Local: "tr"})
+				for _, cell := range c.children {
+					if cell.name != "td" && cell.name != "th" {
+						continue
+					}
+					td := &TD{Align: cell.attr("align"), Valign: cell.attr("valign")}
+					td.SetXMLName(xml.Name{Local: cell.name})
+					td.Colspan, _ = strconv.Atoi(cell.attr("colspan"))
+					td.Rowspan, _ = strconv.Atoi(cell.attr("rowspan"))
+					td.Content = h.inline(cell.children, false)
+					trimContent(&td.contentBase)
+					tr.appendContent(td)
+				}
+				t.TR = append(t.TR, tr)
+			}
+		}
+	}
+	rows(n)
+	if len(t.TR) > 0 {
+		h.appendBlock(t)
+	}
+}
+
+// footnote convert footnote element to section of notes body
+func (h *htmlImporter) footnote(n *htmlNode) {
+	if h.notes == nil {
+		h.notes = &NotesBody{Name: "notes"}
+		h.notes.SetXMLName(xml.Name{Local: "body"})
+	}
+	s := newSection()
+	id := n.attr("id")
+	if id == "" {
+		id, h.pendingID = h.pendingID, ""
+	} else {
+		id = h.id(h.file, id)
+	}
+	h.pendingID = ""
+	s.ID = id
+	if id != "" {
+		h.noteIDs[id] = true
+	}
+	h.notes.Sections = append(h.notes.Sections, s)
+	h.withTarget(&s.contentBase, func() {
+		h.note = s
+		h.blocks(n.children)
+		h.note = nil
+	})
+}
+
+// resolveLinks fix hrefs of links after all files are imported
+func (h *htmlImporter) resolveLinks(resolve func(file, href string) (string, bool)) {
+	for _, l := range h.links {
+		href, local := resolve(l.file, l.href)
+		l.link.XlinkHref = href
+		if !local {
+			continue
+		}
+		if id, ok := localID(href); ok && h.noteIDs[id] {
+			l.link.Type = "note"
+		}
+	}
+}
+
+// trimContent remove leading and trailing whitespace of mixed content
+func trimContent(c *contentBase) {
+	for len(c.Content) > 0 {
+		cd, ok := c.Content[0].(CharData)
+		if !ok {
+			break
+		}
+		s := strings.TrimLeftFunc(string(cd), isXMLSpace)
+		if s != "" {
+			c.Content[0] = CharData(s)
+			break
+		}
+		c.Content = c.Content[1:]
+	}
+	for len(c.Content) > 0 {
+		last := len(c.Content) - 1
+		cd, ok := c.Content[last].(CharData)
+		if !ok {
+			break
+		}
+		s := strings.TrimRightFunc(string(cd), isXMLSpace)
+		if s != "" {
+			c.Content[last] = CharData(s)
+			break
+		}
+		c.Content = c.Content[:last]
+	}
+}
@@ -0,0 +1,98 @@
+package gofb2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const imagesBook = `<FictionBook xmlns:l="http://www.w3.org/1999/xlink"><body>
+<section><image l:href="#a/x.png"/><p>a</p></section>
+<section><image l:href="#b/x.png"/><p>b</p></section>
+<section><image l:href="#a/x.png"/><p>a</p></section>
+</body>
+<binary id="a/x.png" content-type="image/png">YQ==</binary>
+<binary id="b/x.png" content-type="image/png">Yg==</binary>
+</FictionBook>`
+
+func TestHTMLImageURL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gofb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var b strings.Builder
+	opts := HTMLOptions{ImageDir: dir, ImageURL: "https://cdn.example/img/"}
+	if err := WriteHTML(&b, parseBook(t, imagesBook), opts); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, src := range []string{`src="https://cdn.example/img/x.png"`, `src="https://cdn.example/img/x-2.png"`} {
+		if !strings.Contains(out, src) {
+			t.Errorf("no %s in %s", src, out)
+		}
+	}
+	if n := strings.Count(out, "x-2.png"); n != 1 {
+		t.Errorf("x-2.png is used %d times", n)
+	}
+	for name, want := range map[string]string{"x.png": "a", "x-2.png": "b"} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v, want %q", name, data, err, want)
+		}
+	}
+}
+
+func TestHTMLBinaryFileName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gofb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fb := parseBook(t, strings.NewReplacer("a/x.png", "..", "b/x.png", "/").Replace(imagesBook))
+	var b strings.Builder
+	if err := WriteHTML(&b, fb, HTMLOptions{ImageDir: dir}); err != nil {
+		t.Fatal(err)
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	if strings.Join(names, " ") != "image-1 image-2" {
+		t.Errorf("files = %v", names)
+	}
+}
+
+func TestHTMLUnsafeLinks(t *testing.T) {
+	fb := parseBook(t, `<FictionBook xmlns:l="http://www.w3.org/1999/xlink"><body><section>
+<p><a l:href="javascript:alert(1)">a</a> <a l:href=" Java&#9;Script:alert(1)">b</a> <a l:href="data:text/html,x">c</a></p>
+<p><a l:href="https://example.com/a:b">d</a> <a l:href="mailto:a@example.com">e</a> <a l:href="other.html#x">f</a> <a l:href="#n1">g</a></p>
+<image l:href="javascript:alert(1)"/>
+</section></body></FictionBook>`)
+	var b strings.Builder
+	if err := WriteHTML(&b, fb, HTMLOptions{Fragment: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := strings.ToLower(b.String())
+	for _, s := range []string{"javascript", "data:"} {
+		if strings.Contains(out, s) {
+			t.Errorf("%s link in %s", s, out)
+		}
+	}
+	for _, href := range []string{"https://example.com/a:b", "mailto:a@example.com", "other.html#x", "#n1"} {
+		if !strings.Contains(out, `href="`+href+`"`) {
+			t.Errorf("no link %s in %s", href, out)
+		}
+	}
+	if err := WriteHTML(&b, nil, HTMLOptions{}); err == nil {
+		t.Error("no error for nil book")
+	}
+}
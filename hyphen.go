@@ -0,0 +1,290 @@
+package gofb2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Hyphenator find hyphenation points of words by Liang's patterns
+type Hyphenator struct {
+	// LeftMin and RightMin are minimal count of letters
+	// before first and after last hyphen
+	LeftMin, RightMin int
+
+	patterns   map[string][]byte
+	exceptions map[string][]int
+	// maxLength is a length in runes of the longest pattern
+	maxLength int
+}
+
+// LoadHyphenator read patterns in TeX format: \patterns{...} group with
+// patterns like "a1b" and \hyphenation{...} group with exceptions like
+// "ta-ble", "%" start a comment. File without groups is a list of patterns.
+func LoadHyphenator(r io.Reader) (*Hyphenator, error) {
+	h := &Hyphenator{
+		LeftMin:    2,
+		RightMin:   2,
+		patterns:   map[string][]byte{},
+		exceptions: map[string][]int{},
+	}
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1024*1024)
+	group := "patterns"
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.IndexByte(line, '%'); i >= 0 {
+			line = line[:i]
+		}
+		for _, f := range strings.Fields(line) {
+			switch {
+			case strings.HasPrefix(f, `\patterns{`), strings.HasPrefix(f, `\hyphenation{`):
+				i := strings.IndexByte(f, '{')
+				group, f = f[1:i], f[i+1:]
+			case strings.HasPrefix(f, `\`):
+				return nil, fmt.Errorf("unknown command %s", f)
+			}
+			f = strings.TrimSuffix(f, "}")
+			if f == "" {
+				continue
+			}
+			if group == "hyphenation" {
+				h.addException(f)
+			} else if err := h.addPattern(f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// addPattern add pattern like ".ach4", digits are values between letters
+func (h *Hyphenator) addPattern(p string) error {
+	var letters strings.Builder
+	values := []byte{0}
+	for _, r := range p {
+		if r >= '0' && r <= '9' {
+			values[len(values)-1] = byte(r - '0')
+			continue
+		}
+		letters.WriteRune(unicode.ToLower(r))
+		values = append(values, 0)
+	}
+	if letters.Len() == 0 {
+		return fmt.Errorf("wrong pattern %s", p)
+	}
+	h.patterns[letters.String()] = values
+	if n := len(values) - 1; n > h.maxLength {
+		h.maxLength = n
+	}
+	return nil
+}
+
+// addException add word with hyphens like "ta-ble"
+func (h *Hyphenator) addException(w string) {
+	var word strings.Builder
+	var points []int
+	n := 0
+	for _, r := range w {
+		if r == '-' {
+			points = append(points, n)
+			continue
+		}
+		word.WriteRune(unicode.ToLower(r))
+		n++
+	}
+	h.exceptions[word.String()] = points
+}
+
+// Hyphenate return positions of runes in word before which hyphen
+// can be inserted
+func (h *Hyphenator) Hyphenate(word string) []int {
+	word = strings.ToLower(word)
+	n := utf8.RuneCountInString(word)
+	if n < h.LeftMin+h.RightMin {
+		return nil
+	}
+	var points []int
+	if exc, ok := h.exceptions[word]; ok {
+		for _, p := range exc {
+			if p >= h.LeftMin && p <= n-h.RightMin {
+				points = append(points, p)
+			}
+		}
+		return points
+	}
+
+	w := []rune("." + word + ".")
+	values := make([]byte, len(w)+1)
+	for i := range w {
+		for j := i + 1; j <= len(w) && j-i <= h.maxLength; j++ {
+			p, ok := h.patterns[string(w[i:j])]
+			if !ok {
+				continue
+			}
+			for k, v := range p {
+				if v > values[i+k] {
+					values[i+k] = v
+				}
+			}
+		}
+	}
+	for i := h.LeftMin; i <= n-h.RightMin; i++ {
+		// value before i-th rune of word, w has leading dot
+		if values[i+1]%2 == 1 {
+			points = append(points, i)
+		}
+	}
+	return points
+}
+
+// Insert return word with hyphen inserted in hyphenation points
+func (h *Hyphenator) Insert(word, hyphen string) string {
+	points := h.Hyphenate(word)
+	if len(points) == 0 {
+		return word
+	}
+	var b strings.Builder
+	i := 0
+	for _, r := range word {
+		if len(points) > 0 && points[0] == i {
+			b.WriteString(hyphen)
+			points = points[1:]
+		}
+		b.WriteRune(r)
+		i++
+	}
+	return b.String()
+}
+
+// hyphenSource is a bundled patterns of language
+type hyphenSource struct {
+	patterns          string
+	leftMin, rightMin int
+}
+
+var hyphenSources = map[string]hyphenSource{
+	"en": {hyphenEn, 2, 3},
+	"ru": {hyphenRu, 2, 2},
+	"uk": {hyphenUk, 2, 2},
+	"de": {hyphenDe, 2, 2},
+}
+
+var (
+	hyphenatorsMu sync.Mutex
+	hyphenators   = map[string]*Hyphenator{}
+)
+
+// RegisterHyphenator set hyphenator of language, it replace bundled
+// patterns for language
+func RegisterHyphenator(lang string, h *Hyphenator) {
+	hyphenatorsMu.Lock()
+	defer hyphenatorsMu.Unlock()
+	hyphenators[strings.ToLower(lang)] = h
+}
+
+// HyphenatorFor return hyphenator of language tag like "ru" or "en-US"
+// or nil if there are no patterns for language. Bundled patterns exist
+// for en, ru, uk and de.
+func HyphenatorFor(lang string) *Hyphenator {
+	lang = strings.ToLower(lang)
+	hyphenatorsMu.Lock()
+	defer hyphenatorsMu.Unlock()
+	if h, ok := hyphenators[lang]; ok {
+		return h
+	}
+	base := baseLang(lang)
+	h, ok := hyphenators[base]
+	if !ok {
+		if src, found := hyphenSources[base]; found {
+			var err error
+			if h, err = LoadHyphenator(strings.NewReader(src.patterns)); err == nil {
+				h.LeftMin, h.RightMin = src.leftMin, src.rightMin
+			}
+		}
+		hyphenators[base] = h
+	}
+	hyphenators[lang] = h
+	return h
+}
+
+// HyphenateOptions set parameters of Hyphenate
+type HyphenateOptions struct {
+	// MinWord is a minimal length of hyphenated word, default is 5
+	MinWord int
+}
+
+// Hyphenate insert soft hyphens (U+00AD) into words of paragraphs,
+// verses and table cells by patterns of effective language of paragraph.
+// Text of code spans and links, short words and words already containing
+// soft hyphens are not changed.
+func Hyphenate(fb *FictionBook, opts HyphenateOptions) {
+	if opts.MinWord <= 0 {
+		opts.MinWord = 5
+	}
+	var hyphenate func(cont []Contenter, h *Hyphenator)
+	hyphenate = func(cont []Contenter, h *Hyphenator) {
+		for i, c := range cont {
+			if cd, ok := c.(CharData); ok {
+				cont[i] = CharData(hyphenateText(string(cd), h, opts.MinWord))
+				continue
+			}
+			if _, ok := c.(*Link); ok || isCode(c) {
+				continue
+			}
+			if content := styleContent(c); content != nil {
+				hyphenate(*content, h)
+			}
+		}
+	}
+	walkLang(fb, func(c Contenter, lang string) bool {
+		switch e := c.(type) {
+		case *P:
+			if h := HyphenatorFor(lang); h != nil {
+				hyphenate(e.Content, h)
+			}
+			return false
+		case *TD:
+			if h := HyphenatorFor(lang); h != nil {
+				hyphenate(e.Content, h)
+			}
+			return false
+		}
+		return true
+	})
+}
+
+// hyphenateText insert soft hyphens into words of s
+func hyphenateText(s string, h *Hyphenator, minWord int) string {
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || r == '\u00ad'
+	}
+	var b strings.Builder
+	for s != "" {
+		i := strings.IndexFunc(s, isWord)
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		end := strings.IndexFunc(s, func(r rune) bool { return !isWord(r) })
+		if end < 0 {
+			end = len(s)
+		}
+		word := s[:end]
+		s = s[end:]
+		if utf8.RuneCountInString(word) >= minWord && !strings.ContainsRune(word, '\u00ad') {
+			word = h.Insert(word, "\u00ad")
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package gofb2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHyphenatorInsert(t *testing.T) {
+	for _, tc := range []struct{ lang, word, want string }{
+		{"en", "hyphenation", "hy-phen-a-tion"},
+		{"en-US", "algorithm", "al-go-rithm"},
+		{"en", "Typography", "Ty-pog-ra-phy"},
+		{"ru", "электрификация", "элек-три-фи-ка-ция"},
+		{"ru", "Программирование", "Про-грам-ми-ро-ва-ние"},
+		{"uk", "українська", "укра-їн-ська"},
+		{"de", "Silbentrennung", "Sil-ben-tren-nung"},
+	} {
+		h := HyphenatorFor(tc.lang)
+		if h == nil {
+			t.Fatalf("no hyphenator for %s", tc.lang)
+		}
+		if got := h.Insert(tc.word, "-"); got != tc.want {
+			t.Errorf("%s: Insert(%q) = %q, want %q", tc.lang, tc.word, got, tc.want)
+		}
+	}
+	if HyphenatorFor("xx") != nil {
+		t.Error("hyphenator for unknown language")
+	}
+}
+
+func TestLoadHyphenator(t *testing.T) {
+	h, err := LoadHyphenator(strings.NewReader(`\patterns{ 1ba } \hyphenation{ ab-ba-ba }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.LeftMin, h.RightMin = 1, 1
+	if got := h.Insert("abab", "-"); got != "a-bab" {
+		t.Errorf("Insert(abab) = %q", got)
+	}
+	if got := h.Insert("abbaba", "-"); got != "ab-ba-ba" {
+		t.Errorf("exception Insert(abbaba) = %q", got)
+	}
+}
+
+func TestHyphenateBook(t *testing.T) {
+	fb := parseBook(t, `<FictionBook xmlns:l="http://www.w3.org/1999/xlink"><description><title-info><lang>ru</lang></title-info></description>
+<body><section><p>Электрификация <code>программирование</code> <a l:href="http://x">ссылка-длинная</a> кот</p>
+<p xml:lang="en-US">Hyphenation</p></section></body></FictionBook>`)
+	Hyphenate(fb, HyphenateOptions{})
+	// hyphenation is idempotent
+	Hyphenate(fb, HyphenateOptions{})
+	var got []string
+	walk(bookContent(fb), func(c Contenter) bool {
+		if p, ok := c.(*P); ok {
+			got = append(got, strings.Replace(rawText(p.Content), "\u00ad", "~", -1))
+		}
+		return true
+	})
+	want := []string{"Элек~три~фи~ка~ция программирование ссылка-длинная кот", "Hy~phen~a~tion"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,422 @@
+package gofb2
+
+// hyphenUk is Ukrainian hyphenation patterns converted from hyph-uk.tex
+// of hyph-utf8 project, comments of the file are removed.
+//
+// The patterns are by Maksym Polyakov, Werner Lemberg and Vladimir Volovich,
+// they are distributed under the conditions of the LaTeX Project Public
+// License. See NOTICE.
+const hyphenUk = `\patterns{
+'ї4в 'ї4д 'ї4ж 'ї4з 'ї4л 'ї4м 'ї4с 'ї4х -'8'8 -'8а8 -'8б8 -'8в8 -'8г8 -'8д8
+-'8е8 -'8ж8 -'8з8 -'8и8 -'8й8 -'8к8 -'8л8 -'8м8 -'8н8 -'8о8 -'8п8 -'8р8
+-'8с8 -'8т8 -'8у8 -'8ф8 -'8х8 -'8ц8 -'8ч8 -'8ш8 -'8щ8 -'8ь8 -'8ю8 -'8я8
+-'8є8 -'8і8 -'8ї8 -'8ґ8 --8 -а8'8 -а8а8 -а8б8 -а8в8 -а8г8 -а8д8 -а8е8 -а8ж8
+-а8з8 -а8и8 -а8й8 -а8к8 -а8л8 -а8м8 -а8н8 -а8о8 -а8п8 -а8р8 -а8с8 -а8т8
+-а8у8 -а8ф8 -а8х8 -а8ц8 -а8ч8 -а8ш8 -а8щ8 -а8ь8 -а8ю8 -а8я8 -а8є8 -а8і8
+-а8ї8 -а8ґ8 -а8’8 -б8'8 -б8а8 -б8б8 -б8в8 -б8г8 -б8д8 -б8е8 -б8ж8 -б8з8
+-б8и8 -б8й8 -б8к8 -б8л8 -б8м8 -б8н8 -б8о8 -б8п8 -б8р8 -б8с8 -б8т8 -б8у8
+-б8ф8 -б8х8 -б8ц8 -б8ч8 -б8ш8 -б8щ8 -б8ь8 -б8ю8 -б8я8 -б8є8 -б8і8 -б8ї8
+-б8ґ8 -б8’8 -в8'8 -в8а8 -в8б8 -в8в8 -в8г8 -в8д8 -в8е8 -в8ж8 -в8з8 -в8и8
+-в8й8 -в8к8 -в8л8 -в8м8 -в8н8 -в8о8 -в8п8 -в8р8 -в8с8 -в8т8 -в8у8 -в8ф8
+-в8х8 -в8ц8 -в8ч8 -в8ш8 -в8щ8 -в8ь8 -в8ю8 -в8я8 -в8є8 -в8і8 -в8ї8 -в8ґ8
+-в8’8 -г8'8 -г8а8 -г8б8 -г8в8 -г8г8 -г8д8 -г8е8 -г8ж8 -г8з8 -г8и8 -г8й8
+-г8к8 -г8л8 -г8м8 -г8н8 -г8о8 -г8п8 -г8р8 -г8с8 -г8т8 -г8у8 -г8ф8 -г8х8
+-г8ц8 -г8ч8 -г8ш8 -г8щ8 -г8ь8 -г8ю8 -г8я8 -г8є8 -г8і8 -г8ї8 -г8ґ8 -г8’8
+-д8'8 -д8а8 -д8б8 -д8в8 -д8г8 -д8д8 -д8е8 -д8ж8 -д8з8 -д8и8 -д8й8 -д8к8
+-д8л8 -д8м8 -д8н8 -д8о8 -д8п8 -д8р8 -д8с8 -д8т8 -д8у8 -д8ф8 -д8х8 -д8ц8
+-д8ч8 -д8ш8 -д8щ8 -д8ь8 -д8ю8 -д8я8 -д8є8 -д8і8 -д8ї8 -д8ґ8 -д8’8 -е8'8
+-е8а8 -е8б8 -е8в8 -е8г8 -е8д8 -е8е8 -е8ж8 -е8з8 -е8и8 -е8й8 -е8к8 -е8л8
+-е8м8 -е8н8 -е8о8 -е8п8 -е8р8 -е8с8 -е8т8 -е8у8 -е8ф8 -е8х8 -е8ц8 -е8ч8
+-е8ш8 -е8щ8 -е8ь8 -е8ю8 -е8я8 -е8є8 -е8і8 -е8ї8 -е8ґ8 -е8’8 -ж8'8 -ж8а8
+-ж8б8 -ж8в8 -ж8г8 -ж8д8 -ж8е8 -ж8ж8 -ж8з8 -ж8и8 -ж8й8 -ж8к8 -ж8л8 -ж8м8
+-ж8н8 -ж8о8 -ж8п8 -ж8р8 -ж8с8 -ж8т8 -ж8у8 -ж8ф8 -ж8х8 -ж8ц8 -ж8ч8 -ж8ш8
+-ж8щ8 -ж8ь8 -ж8ю8 -ж8я8 -ж8є8 -ж8і8 -ж8ї8 -ж8ґ8 -ж8’8 -з8'8 -з8а8 -з8б8
+-з8в8 -з8г8 -з8д8 -з8е8 -з8ж8 -з8з8 -з8и8 -з8й8 -з8к8 -з8л8 -з8м8 -з8н8
+-з8о8 -з8п8 -з8р8 -з8с8 -з8т8 -з8у8 -з8ф8 -з8х8 -з8ц8 -з8ч8 -з8ш8 -з8щ8
+-з8ь8 -з8ю8 -з8я8 -з8є8 -з8і8 -з8ї8 -з8ґ8 -з8’8 -и8'8 -и8а8 -и8б8 -и8в8
+-и8г8 -и8д8 -и8е8 -и8ж8 -и8з8 -и8и8 -и8й8 -и8к8 -и8л8 -и8м8 -и8н8 -и8о8
+-и8п8 -и8р8 -и8с8 -и8т8 -и8у8 -и8ф8 -и8х8 -и8ц8 -и8ч8 -и8ш8 -и8щ8 -и8ь8
+-и8ю8 -и8я8 -и8є8 -и8і8 -и8ї8 -и8ґ8 -и8’8 -й8'8 -й8а8 -й8б8 -й8в8 -й8г8
+-й8д8 -й8е8 -й8ж8 -й8з8 -й8и8 -й8й8 -й8к8 -й8л8 -й8м8 -й8н8 -й8о8 -й8п8
+-й8р8 -й8с8 -й8т8 -й8у8 -й8ф8 -й8х8 -й8ц8 -й8ч8 -й8ш8 -й8щ8 -й8ь8 -й8ю8
+-й8я8 -й8є8 -й8і8 -й8ї8 -й8ґ8 -й8’8 -к8'8 -к8а8 -к8б8 -к8в8 -к8г8 -к8д8
+-к8е8 -к8ж8 -к8з8 -к8и8 -к8й8 -к8к8 -к8л8 -к8м8 -к8н8 -к8о8 -к8п8 -к8р8
+-к8с8 -к8т8 -к8у8 -к8ф8 -к8х8 -к8ц8 -к8ч8 -к8ш8 -к8щ8 -к8ь8 -к8ю8 -к8я8
+-к8є8 -к8і8 -к8ї8 -к8ґ8 -к8’8 -л8'8 -л8а8 -л8б8 -л8в8 -л8г8 -л8д8 -л8е8
+-л8ж8 -л8з8 -л8и8 -л8й8 -л8к8 -л8л8 -л8м8 -л8н8 -л8о8 -л8п8 -л8р8 -л8с8
+-л8т8 -л8у8 -л8ф8 -л8х8 -л8ц8 -л8ч8 -л8ш8 -л8щ8 -л8ь8 -л8ю8 -л8я8 -л8є8
+-л8і8 -л8ї8 -л8ґ8 -л8’8 -м8'8 -м8а8 -м8б8 -м8в8 -м8г8 -м8д8 -м8е8 -м8ж8
+-м8з8 -м8и8 -м8й8 -м8к8 -м8л8 -м8м8 -м8н8 -м8о8 -м8п8 -м8р8 -м8с8 -м8т8
+-м8у8 -м8ф8 -м8х8 -м8ц8 -м8ч8 -м8ш8 -м8щ8 -м8ь8 -м8ю8 -м8я8 -м8є8 -м8і8
+-м8ї8 -м8ґ8 -м8’8 -н8'8 -н8а8 -н8б8 -н8в8 -н8г8 -н8д8 -н8е8 -н8ж8 -н8з8
+-н8и8 -н8й8 -н8к8 -н8л8 -н8м8 -н8н8 -н8о8 -н8п8 -н8р8 -н8с8 -н8т8 -н8у8
+-н8ф8 -н8х8 -н8ц8 -н8ч8 -н8ш8 -н8щ8 -н8ь8 -н8ю8 -н8я8 -н8є8 -н8і8 -н8ї8
+-н8ґ8 -н8’8 -о8'8 -о8а8 -о8б8 -о8в8 -о8г8 -о8д8 -о8е8 -о8ж8 -о8з8 -о8и8
+-о8й8 -о8к8 -о8л8 -о8м8 -о8н8 -о8о8 -о8п8 -о8р8 -о8с8 -о8т8 -о8у8 -о8ф8
+-о8х8 -о8ц8 -о8ч8 -о8ш8 -о8щ8 -о8ь8 -о8ю8 -о8я8 -о8є8 -о8і8 -о8ї8 -о8ґ8
+-о8’8 -п8'8 -п8а8 -п8б8 -п8в8 -п8г8 -п8д8 -п8е8 -п8ж8 -п8з8 -п8и8 -п8й8
+-п8к8 -п8л8 -п8м8 -п8н8 -п8о8 -п8п8 -п8р8 -п8с8 -п8т8 -п8у8 -п8ф8 -п8х8
+-п8ц8 -п8ч8 -п8ш8 -п8щ8 -п8ь8 -п8ю8 -п8я8 -п8є8 -п8і8 -п8ї8 -п8ґ8 -п8’8
+-р8'8 -р8а8 -р8б8 -р8в8 -р8г8 -р8д8 -р8е8 -р8ж8 -р8з8 -р8и8 -р8й8 -р8к8
+-р8л8 -р8м8 -р8н8 -р8о8 -р8п8 -р8р8 -р8с8 -р8т8 -р8у8 -р8ф8 -р8х8 -р8ц8
+-р8ч8 -р8ш8 -р8щ8 -р8ь8 -р8ю8 -р8я8 -р8є8 -р8і8 -р8ї8 -р8ґ8 -р8’8 -с8'8
+-с8а8 -с8б8 -с8в8 -с8г8 -с8д8 -с8е8 -с8ж8 -с8з8 -с8и8 -с8й8 -с8к8 -с8л8
+-с8м8 -с8н8 -с8о8 -с8п8 -с8р8 -с8с8 -с8т8 -с8у8 -с8ф8 -с8х8 -с8ц8 -с8ч8
+-с8ш8 -с8щ8 -с8ь8 -с8ю8 -с8я8 -с8є8 -с8і8 -с8ї8 -с8ґ8 -с8’8 -т8'8 -т8а8
+-т8б8 -т8в8 -т8г8 -т8д8 -т8е8 -т8ж8 -т8з8 -т8и8 -т8й8 -т8к8 -т8л8 -т8м8
+-т8н8 -т8о8 -т8п8 -т8р8 -т8с8 -т8т8 -т8у8 -т8ф8 -т8х8 -т8ц8 -т8ч8 -т8ш8
+-т8щ8 -т8ь8 -т8ю8 -т8я8 -т8є8 -т8і8 -т8ї8 -т8ґ8 -т8’8 -у8'8 -у8а8 -у8б8
+-у8в8 -у8г8 -у8д8 -у8е8 -у8ж8 -у8з8 -у8и8 -у8й8 -у8к8 -у8л8 -у8м8 -у8н8
+-у8о8 -у8п8 -у8р8 -у8с8 -у8т8 -у8у8 -у8ф8 -у8х8 -у8ц8 -у8ч8 -у8ш8 -у8щ8
+-у8ь8 -у8ю8 -у8я8 -у8є8 -у8і8 -у8ї8 -у8ґ8 -у8’8 -ф8'8 -ф8а8 -ф8б8 -ф8в8
+-ф8г8 -ф8д8 -ф8е8 -ф8ж8 -ф8з8 -ф8и8 -ф8й8 -ф8к8 -ф8л8 -ф8м8 -ф8н8 -ф8о8
+-ф8п8 -ф8р8 -ф8с8 -ф8т8 -ф8у8 -ф8ф8 -ф8х8 -ф8ц8 -ф8ч8 -ф8ш8 -ф8щ8 -ф8ь8
+-ф8ю8 -ф8я8 -ф8є8 -ф8і8 -ф8ї8 -ф8ґ8 -ф8’8 -х8'8 -х8а8 -х8б8 -х8в8 -х8г8
+-х8д8 -х8е8 -х8ж8 -х8з8 -х8и8 -х8й8 -х8к8 -х8л8 -х8м8 -х8н8 -х8о8 -х8п8
+-х8р8 -х8с8 -х8т8 -х8у8 -х8ф8 -х8х8 -х8ц8 -х8ч8 -х8ш8 -х8щ8 -х8ь8 -х8ю8
+-х8я8 -х8є8 -х8і8 -х8ї8 -х8ґ8 -х8’8 -ц8'8 -ц8а8 -ц8б8 -ц8в8 -ц8г8 -ц8д8
+-ц8е8 -ц8ж8 -ц8з8 -ц8и8 -ц8й8 -ц8к8 -ц8л8 -ц8м8 -ц8н8 -ц8о8 -ц8п8 -ц8р8
+-ц8с8 -ц8т8 -ц8у8 -ц8ф8 -ц8х8 -ц8ц8 -ц8ч8 -ц8ш8 -ц8щ8 -ц8ь8 -ц8ю8 -ц8я8
+-ц8є8 -ц8і8 -ц8ї8 -ц8ґ8 -ц8’8 -ч8'8 -ч8а8 -ч8б8 -ч8в8 -ч8г8 -ч8д8 -ч8е8
+-ч8ж8 -ч8з8 -ч8и8 -ч8й8 -ч8к8 -ч8л8 -ч8м8 -ч8н8 -ч8о8 -ч8п8 -ч8р8 -ч8с8
+-ч8т8 -ч8у8 -ч8ф8 -ч8х8 -ч8ц8 -ч8ч8 -ч8ш8 -ч8щ8 -ч8ь8 -ч8ю8 -ч8я8 -ч8є8
+-ч8і8 -ч8ї8 -ч8ґ8 -ч8’8 -ш8'8 -ш8а8 -ш8б8 -ш8в8 -ш8г8 -ш8д8 -ш8е8 -ш8ж8
+-ш8з8 -ш8и8 -ш8й8 -ш8к8 -ш8л8 -ш8м8 -ш8н8 -ш8о8 -ш8п8 -ш8р8 -ш8с8 -ш8т8
+-ш8у8 -ш8ф8 -ш8х8 -ш8ц8 -ш8ч8 -ш8ш8 -ш8щ8 -ш8ь8 -ш8ю8 -ш8я8 -ш8є8 -ш8і8
+-ш8ї8 -ш8ґ8 -ш8’8 -щ8'8 -щ8а8 -щ8б8 -щ8в8 -щ8г8 -щ8д8 -щ8е8 -щ8ж8 -щ8з8
+-щ8и8 -щ8й8 -щ8к8 -щ8л8 -щ8м8 -щ8н8 -щ8о8 -щ8п8 -щ8р8 -щ8с8 -щ8т8 -щ8у8
+-щ8ф8 -щ8х8 -щ8ц8 -щ8ч8 -щ8ш8 -щ8щ8 -щ8ь8 -щ8ю8 -щ8я8 -щ8є8 -щ8і8 -щ8ї8
+-щ8ґ8 -щ8’8 -ь8'8 -ь8а8 -ь8б8 -ь8в8 -ь8г8 -ь8д8 -ь8е8 -ь8ж8 -ь8з8 -ь8и8
+-ь8й8 -ь8к8 -ь8л8 -ь8м8 -ь8н8 -ь8о8 -ь8п8 -ь8р8 -ь8с8 -ь8т8 -ь8у8 -ь8ф8
+-ь8х8 -ь8ц8 -ь8ч8 -ь8ш8 -ь8щ8 -ь8ь8 -ь8ю8 -ь8я8 -ь8є8 -ь8і8 -ь8ї8 -ь8ґ8
+-ь8’8 -ю8'8 -ю8а8 -ю8б8 -ю8в8 -ю8г8 -ю8д8 -ю8е8 -ю8ж8 -ю8з8 -ю8и8 -ю8й8
+-ю8к8 -ю8л8 -ю8м8 -ю8н8 -ю8о8 -ю8п8 -ю8р8 -ю8с8 -ю8т8 -ю8у8 -ю8ф8 -ю8х8
+-ю8ц8 -ю8ч8 -ю8ш8 -ю8щ8 -ю8ь8 -ю8ю8 -ю8я8 -ю8є8 -ю8і8 -ю8ї8 -ю8ґ8 -ю8’8
+-я8'8 -я8а8 -я8б8 -я8в8 -я8г8 -я8д8 -я8е8 -я8ж8 -я8з8 -я8и8 -я8й8 -я8к8
+-я8л8 -я8м8 -я8н8 -я8о8 -я8п8 -я8р8 -я8с8 -я8т8 -я8у8 -я8ф8 -я8х8 -я8ц8
+-я8ч8 -я8ш8 -я8щ8 -я8ь8 -я8ю8 -я8я8 -я8є8 -я8і8 -я8ї8 -я8ґ8 -я8’8 -є8'8
+-є8а8 -є8б8 -є8в8 -є8г8 -є8д8 -є8е8 -є8ж8 -є8з8 -є8и8 -є8й8 -є8к8 -є8л8
+-є8м8 -є8н8 -є8о8 -є8п8 -є8р8 -є8с8 -є8т8 -є8у8 -є8ф8 -є8х8 -є8ц8 -є8ч8
+-є8ш8 -є8щ8 -є8ь8 -є8ю8 -є8я8 -є8є8 -є8і8 -є8ї8 -є8ґ8 -є8’8 -і8'8 -і8а8
+-і8б8 -і8в8 -і8г8 -і8д8 -і8е8 -і8ж8 -і8з8 -і8и8 -і8й8 -і8к8 -і8л8 -і8м8
+-і8н8 -і8о8 -і8п8 -і8р8 -і8с8 -і8т8 -і8у8 -і8ф8 -і8х8 -і8ц8 -і8ч8 -і8ш8
+-і8щ8 -і8ь8 -і8ю8 -і8я8 -і8є8 -і8і8 -і8ї8 -і8ґ8 -і8’8 -ї8'8 -ї8а8 -ї8б8
+-ї8в8 -ї8г8 -ї8д8 -ї8е8 -ї8ж8 -ї8з8 -ї8и8 -ї8й8 -ї8к8 -ї8л8 -ї8м8 -ї8н8
+-ї8о8 -ї8п8 -ї8р8 -ї8с8 -ї8т8 -ї8у8 -ї8ф8 -ї8х8 -ї8ц8 -ї8ч8 -ї8ш8 -ї8щ8
+-ї8ь8 -ї8ю8 -ї8я8 -ї8є8 -ї8і8 -ї8ї8 -ї8ґ8 -ї8’8 -ґ8'8 -ґ8а8 -ґ8б8 -ґ8в8
+-ґ8г8 -ґ8д8 -ґ8е8 -ґ8ж8 -ґ8з8 -ґ8и8 -ґ8й8 -ґ8к8 -ґ8л8 -ґ8м8 -ґ8н8 -ґ8о8
+-ґ8п8 -ґ8р8 -ґ8с8 -ґ8т8 -ґ8у8 -ґ8ф8 -ґ8х8 -ґ8ц8 -ґ8ч8 -ґ8ш8 -ґ8щ8 -ґ8ь8
+-ґ8ю8 -ґ8я8 -ґ8є8 -ґ8і8 -ґ8ї8 -ґ8ґ8 -ґ8’8 -’8а8 -’8б8 -’8в8 -’8г8 -’8д8
+-’8е8 -’8ж8 -’8з8 -’8и8 -’8й8 -’8к8 -’8л8 -’8м8 -’8н8 -’8о8 -’8п8 -’8р8
+-’8с8 -’8т8 -’8у8 -’8ф8 -’8х8 -’8ц8 -’8ч8 -’8ш8 -’8щ8 -’8ь8 -’8ю8 -’8я8
+-’8є8 -’8і8 -’8ї8 -’8ґ8 -’8’8 .'-8 .а-8 .б'8 .б-8 .бд6 .бе4з'3 .бе4з3
+.бе4з’3 .бе5з4о3д .бе5зе. .без3ро4з3 .без5о4соб .безві4д3 .безу4 .блі4ц3ана
+.блі4ц3криг .блі4ц3опит .блі4ц3торг .блі4ц3тур .блі4ц3і4спит .бр6 .б’8 .в'8
+.в-8 .вб6 .вб6'6 .вб6’6 .вв6'6 .вв6’6 .вг6 .вд6 .вж6 .вз6 .вз6д6 .ви3в4ч
+.ви3м4р .вк6 .вл6 .вм6 .вм6'6 .вм6’6 .вп6 .вп6'6 .вп6х6 .вп6’6 .вс6 .вс6т6
+.вт6 .вш6к6 .ві4д3 .ві5д4а .ві5д4ер .ві5д4озв .ві5д4ом .ві5д4ун .ві5д4і
+.від'3 .віді3м4р .від’3 .в’8 .г-8 .д'8 .д-8 .дж6 .дз6 .дл6 .до3в4ч .дої4в
+.дої4л .дої5ль .дс6 .д’8 .е-8 .ж-8 .з'8 .з-8 .за3в4ч .за3м4р .зав3м4р .заї4к
+.заї4ц .заї4ч .зб6 .зв6'6 .зв6’6 .зг6 .зд6 .зд6з6 .зл6 .зм6'6 .зм6’6 .зс6
+.зс6к6 .зс6т6 .зч6 .зш6 .зш6к6 .зі3м4р .зґ6 .з’8 .и-8 .й-8 .йш6 .к-8 .кл6
+.кп6 .кс6 .кх6 .кш6 .л-8 .лк6с6 .лс6 .ль6 .м'8 .м-8 .мс6 .мф6 .мі4ж3 .м’8
+.н'8 .н-8 .на3в4ч .на3м4р .напі4в3 .наї4д .нб6 .н’8 .о-8 .ом4рі .п'8 .п-8
+.пе4ом. .пере3 .пере3м4р .пере4д'3 .пере4д3бач .пере4д3виб .пере4д3г
+.пере4д3д .пере4д3м .пере4д3ост .пере4д3пла .пере4д3пок .пере4д3р .пере4д3св
+.пере4д3умов .пере4д3усі .пере4д3фр .пере4д3ч .пере4д’3 .по3в4ч .по3м4р
+.пона4д3 .пона5д4и .пона5д4я .пона5д4і .пої4 .пр6 .при3в4ч .при3м4р .пс6
+.пх6 .пі4в3 .пі4д3 .пі5д4е .пі5д4и .пі5д4о .пі5д4у .пі5д4і .під'3 .під’3
+.п’8 .р'8 .р-8 .ро4з'3 .ро4з3 .ро4з’3 .ро5з4а .ро5з4е .ро5з4і .роз3м4р .рт6
+.р’8 .с-8 .ск6 .ск6л6 .сл6 .сп6 .сп6'6 .сп6л6 .сп6х6 .сп6’6 .спі4в3 .ст6
+.сх6 .сх6л6 .т'8 .т-8 .тк6 .тр6 .тх6 .ть6 .т’8 .у-8 .ум4ре .ум4ри .ум4ру
+.ум4рі .ф'8 .ф-8 .фл6 .ф’8 .х-8 .хл6 .ц-8 .ць6 .ч-8 .чере4з'3 .чере4з3
+.чере4з’3 .чх6 .ш'8 .ш-8 .шк6 .шл6 .шп6 .шт6 .ш’8 .щ-8 .ь-8 .ю-8 .я-8 .є-8
+.і-8 .ї-8 .ґ-8 .’-8 2а1 2б1б 2б1к 2б1п 2б1с 2б1т 2б1ф 2б1х 2б1ц 2б1ч 2б1ш
+2б1щ 2в'3 2в1б 2в1в 2в1г 2в1д 2в1ж 2в1з 2в1й 2в1к 2в1л 2в1м 2в1н 2в1п 2в1р
+2в1с 2в1т 2в1ф 2в1х 2в1ц 2в1ч 2в1ш 2в1щ 2в’3 2г1г 2г1к 2г1п 2г1с 2г1т 2г1ф
+2г1ц 2г1ч 2г1ш 2д1д 2д1к 2д1п 2д1с 2д1т 2д1ф 2д1х 2д1ц 2д1ч 2д1ш 2д1щ 2дь1к
+2дь1с 2дь1т 2дь1ц 2е1 2ж1ж 2ж1к 2ж1п 2ж1с 2ж1т 2ж1ф 2ж1х 2ж1ц 2ж1ч 2ж1ш 2з1з
+2з1к 2з1п 2з1с 2з1т 2з1ф 2з1х 2з1ц 2з1ч 2з1ш 2з1щ 2зь1к 2зь1с 2зь1т 2и1 2й1б
+2й1в 2й1г 2й1д 2й1ж 2й1з 2й1й 2й1к 2й1л 2й1м 2й1н 2й1п 2й1р 2й1с 2й1т 2й1ф
+2й1х 2й1ц 2й1ч 2й1ш 2й1щ 2к1б 2к1г 2к1д 2к1з 2к1к 2к1сп 2к1ст 2к1ськ 2л1б
+2л1в 2л1г 2л1д 2л1ж 2л1з 2л1к 2л1л 2л1м 2л1н 2л1п 2л1р 2л1с 2л1т 2л1ф 2л1х
+2л1ц 2л1ч 2л1ґ 2ль1б 2ль1в 2ль1г 2ль1д 2ль1ж 2ль1з 2ль1й 2ль1к 2ль1м 2ль1н
+2ль1п 2ль1р 2ль1с 2ль1т 2ль1ф 2ль1х 2ль1ц 2ль1ч 2ль1ш 2ль1щ 2м'3 2м1б 2м1в
+2м1г 2м1д 2м1ж 2м1з 2м1к 2м1л 2м1м 2м1н 2м1п 2м1р 2м1с 2м1т 2м1ф 2м1х 2м1ц
+2м1ч 2м1ш 2м1щ 2м’3 2н'3 2н1б 2н1в 2н1г 2н1д 2н1ж 2н1з 2н1к 2н1л 2н1м 2н1н
+2н1п 2н1р 2н1с 2н1т 2н1ф 2н1х 2н1ц 2н1ч 2н1ш 2н1щ 2нь1б 2нь1г 2нь1з 2нь1й
+2нь1к 2нь1л 2нь1м 2нь1с 2нь1т 2нь1х 2нь1ц 2нь1ч 2нь1ш 2н’3 2о1 2п1б 2п1д
+2п1з 2п1п 2п1сп 2п1ст 2п1ськ 2р'3 2р1б 2р1в 2р1г 2р1д 2р1ж 2р1з 2р1й 2р1к
+2р1л 2р1м 2р1н 2р1п 2р1р 2р1с 2р1т 2р1ф 2р1х 2р1ц 2р1ч 2р1ш 2р1щ 2р1ґ 2рь1к
+2рь1ц 2р’3 2с1б 2с1г 2с1д 2с1пк 2с1пп 2с1пс 2с1пт 2с1пх 2с1пч 2с1с 2с1ськ
+2с1тк 2с1тп 2с1тс 2с1тсь 2с1тт 2с1тф 2с1тц 2с1шт 2сь1б 2сь1д 2сь1кк 2сь1кс
+2сь1кт 2т1б 2т1г 2т1д 2т1ж 2т1з 2т1ск 2т1сп 2т1ст 2т1ськ 2т1т 2т1шк 2ть1б
+2у1 2ф1б 2ф1г 2ф1з 2ф1сп 2ф1ст 2ф1ськ 2ф1ф 2ф1шт 2х1г 2х1д 2х1ст 2х1ськ 2х1х
+2ц1б 2ц1г 2ц1д 2ц1з 2ц1ст 2ц1ц 2ц1шк 2ч1б 2ч1д 2ч1ж 2ч1ч 2ш1б 2ш1г 2ш1тк
+2ш1тсь 2ш1ш 2щ1щ 2ю1 2я1 2є1 2і1 2ї1 2ґ1ґ 3а4вторит 3а4гент 3а4грес
+3а4декват 3а4дитив 3а4зарт 3а4ктив 3а4ктуал 3а4курат 3а4куст 3а4кцепт
+3а4кциз 3а4лергі 3а4матор 3а4наліз 3а4натом 3а4парат 3а4пеляц 3а4постол
+3а4птеч 3а4ргумен 3а4ромат 3а4соці 3а4спект 3а4тлет 3а4халі 3блаж 3ближ
+3близ 3блиск 3блок 3блоці 3бран 3брати 3брест 3бризк 3британ 3бруд 3в'4яз
+3в4б4лаг 3в4бив 3в4веден 3в4дал 3в4довз 3в4довол 3в4живан 3в4к4лад 3в4лад
+3в4ласн 3в4лашт 3в4лов 3в4п4лив 3в4п4равн 3в4певн 3в4поряд 3в4р4одлив
+3в4разлив 3в4рожай 3в4сюд 3в4т4рут 3в4т4руч 3в4тіл 3в’4яз 3г4ідро 3глад
+3глиб 3глин 3глоб 3глуз 3глуш 3гляд 3глян 3гнан 3гнил 3гноз 3гнучк 3грав
+3град 3грай 3грам 3гран 3грати 3граф 3граш 3граю 3грає 3грес 3грець 3гроб
+3грож 3гроз 3громад 3груван 3грунт 3груп 3грів 3гріт 3гріш 3д4ан 3д4бав
+3д4бал 3д4бан 3д4бат 3д4бає 3двиг 3двою 3двоє 3двій 3двір 3двічі 3ддя 3драж
+3дром 3друж 3друк 3дряп 3дріб 3дріма 3е4колог 3е4коном 3е4легант 3е4лектр
+3е4лемент 3е4моці 3е4мігр 3е4нерг 3е4стакад 3е4стет 3е4тап 3жвав 3жміть
+3жріть 3з'4ясо 3з4б4рой 3з4б4рою 3з4б4роє 3з4бага 3з4баланс 3з4був 3з4бут
+3з4в4'яз 3з4в4’яз 3з4год 3з4дат 3з4довж 3з4доров 3з4дійсн 3з4чеп 3зваж 3зван
+3звед 3звел 3звест 3звись 3звич 3звищ 3зворуш 3звук 3звуч 3звіт 3зйом 3змін
+3зміш 3знав 3знай 3знак 3знал 3знан 3знат 3знаход 3знач 3знаю 3знає 3зниж
+3знім 3зрозум 3зрюв 3зрів 3зріл 3зрін 3з’4ясо 3й4ма 3й4менн 3й4мищ 3й4мовірн
+3й4му. 3й4муть 3й4міть 3й4ш4л 3й4шов 3й6о 3ллю 3лля 3ллє 3м'4якш 3м'4ят
+3м4к4не 3м4к4ну 3м4к4ні 3м4нож 3м4ріть 3м4щен 3м’4якш 3м’4ят 3ння 3о4б'єдн
+3о4б'єкт 3о4береж 3о4бира 3о4борон 3о4бід 3о4біц 3о4б’єдн 3о4б’єкт 3о4даль
+3о4дяг 3о4збро 3о4крем 3о4перат 3о4плат 3о4птим 3о4пуст 3о4пуше 3о4пуще
+3о4рдинац 3о4ренд 3о4соб 3о4сяжн 3о4холо 3о4хорон 3о4хоч 3о4чисн 3о4чищ
+3п4ре 3п4ри 3п4ро 3п4рі 3п4с4ков 3п4сов 3п4сон 3п4сув 3р4вав 3р4вати 3р4віть
+3с4к4лад 3с4к4ле 3с4к4лит 3с4к4ло 3с4к4рипт 3с4кид 3с4кок 3с4коп 3с4кор
+3с4короч 3с4коч 3с4кіль 3с4кіпл 3с4п4лав 3с4п4лат 3с4п4лач 3с4п4рав
+3с4п4ритн 3с4п4рият 3с4п4ромо 3с4пад 3с4пект 3с4перм 3с4пин 3с4повід
+3с4пожив 3с4постер 3с4промож 3с4пі 3с4піть 3с4піш 3с4т4вор 3с4т4ражд
+3с4т4рах 3с4т4риб 3с4т4риж 3с4т4рой 3с4т4рок 3с4т4ром 3с4т4роф 3с4т4роч
+3с4т4рою 3с4т4роя 3с4т4роє 3с4т4рої 3с4т4рукт 3с4т4рукц 3с4т4рій 3с4т4ріл
+3с4т4річ 3с4табіл 3с4тав 3с4тад 3с4таз 3с4тайн 3с4тал 3с4тан 3с4тар 3с4тара
+3с4тат 3с4тач 3с4тає 3с4теп 3с4тереж 3с4теріг 3с4тиг 3с4тиж 3с4тисл 3с4титу
+3с4товб 3с4той 3с4торон 3с4торін 3с4тосо 3с4тосу 3с4тою 3с4тоян 3с4туп
+3с4тяг 3с4тіб 3с4тій 3с4тіль 3с4тір 3с4фер 3с4хил 3с4хов 3с4хід 3т4к4нен
+3т4кан 3т4ь4мар 3т4ь4мяні 3ттю 3ття 3у4ваг 3у4важ 3у4гав 3у4згод 3у4клад
+3у4компл 3у4крупн 3у4люблен 3у4мит 3у4міл 3у4п4рав 3у4перед 3у4разлив
+3у4рбан 3у4рочист 3у4ряд 3у4рядов 3у4спіш 3у4станов 3у4стпіш 3у4сувати
+3у4твор 3у4тробн 3х4то 3ш4код 3ш4кол 3ш4кідл 3ш4кіл 3ш4кір 3ш4таб 3ш4туч
+3я4дерн 3я4зик 3я4кіс 3я4рус 3я4скрав 3є4д3н 3є4дин 3є4писк 3є4рей 3і4зотоп
+3і4люстр 3і4мовір 3і4нтенс 3і4нформ 3і4ніціат 3і4снув 3ї4ждж 3ї4зд 3ї4ст
+3ї4хав 3ї4хат 3ґрунт 4в3антрац 4д7зем 6' 6б6ль. 6б6с6тв. 6б6с6тр. 6б6с6ьк.
+6б6ст. 6б6ць. 6бв. 6бз. 6бй. 6бл. 6бн. 6бр. 6бс. 6в6др. 6в6дь. 6в6зь. 6в6ль.
+6в6с6тв. 6в6с6ть. 6в6с6ьк. 6в6сь. 6в6ць. 6вб. 6вв. 6вд. 6вж. 6вз. 6вй. 6вк.
+6вл. 6вм. 6вн. 6вп. 6вр. 6вс. 6вт. 6вх. 6вч. 6вш. 6вщ. 6г6ль. 6г6с6тв.
+6г6сь. 6гв. 6гг. 6гд. 6гл. 6гм. 6гн. 6гр. 6гс. 6гт. 6д6зь. 6д6с6тв. 6д6с6ьк.
+6д6ь6сь. 6дж. 6дз. 6дл. 6дм. 6дн. 6др. 6дт. 6дь. 6ж6дь. 6ж6сь. 6жб. 6жв.
+6з6дв. 6з6дн. 6з6дь. 6з6нь. 6з6сь. 6з6ьб. 6з6ьк. 6зв. 6зг. 6зд. 6зк. 6зл.
+6зм. 6зн. 6зр. 6зь. 6й6кл. 6й6ль. 6й6мс. 6й6нс. 6й6с6тв. 6й6с6тр. 6й6с6ьк.
+6й6ст. 6й6сь. 6й6тс. 6йб. 6йв. 6йг. 6йд. 6йз. 6йк. 6йл. 6йм. 6йн. 6йп. 6йр.
+6йс. 6йт. 6йф. 6йх. 6йц. 6йч. 6йш. 6к6ль. 6к6ст. 6к6сь. 6к6тр. 6кв. 6кк.
+6кл. 6кр. 6кс. 6кт. 6кх. 6кш. 6л6ль. 6л6мс. 6л6хв. 6л6ь6дс. 6л6ь6ств.
+6л6ь6сь. 6л6ь6ськ. 6л6ь6тр. 6л6ьб. 6л6ьв. 6л6ьг. 6л6ьд. 6л6ьз. 6л6ьк. 6л6ьм.
+6л6ьн. 6л6ьп. 6л6ьс. 6л6ьт. 6л6ьф. 6л6ьх. 6л6ьц. 6л6ьч. 6л6ьш. 6л6ьщ. 6лб.
+6лг. 6лд. 6лк. 6лл. 6лм. 6лн. 6лп. 6лс. 6лт. 6ль. 6м6б6ль. 6м6бр. 6м6ль.
+6м6с6тв. 6м6с6ьк. 6м6сь. 6мб. 6мг. 6мж. 6мк. 6мл. 6мм. 6мн. 6мп. 6мр. 6мс.
+6мт. 6мф. 6мх. 6мш. 6н6г6ль. 6н6гл. 6н6гр. 6н6гс. 6н6дж. 6н6дз. 6н6дп.
+6н6др. 6н6кс. 6н6кт. 6н6с6тв. 6н6с6тр. 6н6с6ьк. 6н6с6ькй. 6н6ск. 6н6ст.
+6н6т6ств. 6н6тк. 6н6тр. 6н6ть. 6н6ць. 6н6ь6сь. 6н6ьб. 6н6ьг. 6н6ьк. 6нв.
+6нг. 6нд. 6нж. 6нз. 6нк. 6нм. 6нн. 6нр. 6нс. 6нт. 6нф. 6нх. 6нц. 6нч. 6нш.
+6нь. 6п6с6тв. 6п6сь. 6п6тр. 6пд. 6пл. 6пр. 6пс. 6пт. 6пф. 6пц. 6р6дв. 6р6дж.
+6р6дь. 6р6зн. 6р6зь. 6р6кс. 6р6кт. 6р6л6ьз. 6р6ль. 6р6н6ст. 6р6нс. 6р6нь.
+6р6с6тв. 6р6с6ть. 6р6с6ьк. 6р6ср. 6р6ст. 6р6сь. 6р6тв. 6р6тр. 6р6ть. 6р6ць.
+6р6щ6сь. 6рб. 6рв. 6рг. 6рд. 6рж. 6рз. 6рк. 6рл. 6рм. 6рн. 6рп. 6рр. 6рс.
+6рт. 6рф. 6рх. 6рц. 6рч. 6рш. 6рщ. 6рь. 6с6д6рп. 6с6дп. 6с6ль. 6с6т6рь.
+6с6тв. 6с6тй. 6с6тм. 6с6тр. 6с6ть. 6с6ць. 6с6ьб. 6с6ьк. 6с6ьм. 6ск. 6сл.
+6см. 6сн. 6сп. 6сс. 6ст. 6сь. 6т6вт. 6т6зт. 6т6ль. 6т6мр. 6т6с6тв. 6т6с6ьк.
+6т6ь6сь. 6тв. 6тл. 6тм. 6тр. 6тс. 6тт. 6тц. 6тч. 6ть. 6ф6с6тв. 6ф6ть. 6фм.
+6фр. 6фт. 6фф. 6фь. 6хв. 6хм. 6хн. 6хр. 6хт. 6хш. 6ц6тв. 6ц6ьк. 6ць. 6чб.
+6чм. 6чн. 6чт. 6ш6ль. 6ш6нл. 6ш6сь. 6ш6тв. 6шв. 6шм. 6шн. 6шт. 6щ6сь. 6ь 6’
+8'8'- 8'8а- 8'8б- 8'8в- 8'8г- 8'8д- 8'8е- 8'8ж- 8'8з- 8'8и- 8'8й- 8'8к-
+8'8л- 8'8м- 8'8н- 8'8о- 8'8п- 8'8р- 8'8с- 8'8т- 8'8у- 8'8ф- 8'8х- 8'8ц-
+8'8ч- 8'8ш- 8'8щ- 8'8ь- 8'8ю- 8'8я- 8'8є- 8'8і- 8'8ї- 8'8ґ- 8-7 8а8'- 8а8а-
+8а8б- 8а8в- 8а8г- 8а8д- 8а8е- 8а8ж- 8а8з- 8а8и- 8а8й- 8а8к- 8а8л- 8а8м-
+8а8н- 8а8о- 8а8п- 8а8р- 8а8с- 8а8т- 8а8у- 8а8ф- 8а8х- 8а8ц- 8а8ч- 8а8ш-
+8а8щ- 8а8ь- 8а8ю- 8а8я- 8а8є- 8а8і- 8а8ї- 8а8ґ- 8а8’- 8б8'- 8б8а- 8б8б-
+8б8в- 8б8г- 8б8д- 8б8е- 8б8ж- 8б8з- 8б8и- 8б8й- 8б8к- 8б8л- 8б8м- 8б8н-
+8б8о- 8б8п- 8б8р- 8б8с- 8б8т- 8б8у- 8б8ф- 8б8х- 8б8ц- 8б8ч- 8б8ш- 8б8щ-
+8б8ь- 8б8ю- 8б8я- 8б8є- 8б8і- 8б8ї- 8б8ґ- 8б8’- 8в8'- 8в8а- 8в8б- 8в8в-
+8в8г- 8в8д- 8в8е- 8в8ж- 8в8з- 8в8и- 8в8й- 8в8к- 8в8л- 8в8м- 8в8н- 8в8о-
+8в8п- 8в8р- 8в8с- 8в8т- 8в8у- 8в8ф- 8в8х- 8в8ц- 8в8ч- 8в8ш- 8в8щ- 8в8ь-
+8в8ю- 8в8я- 8в8є- 8в8і- 8в8ї- 8в8ґ- 8в8’- 8г8'- 8г8а- 8г8б- 8г8в- 8г8г-
+8г8д- 8г8е- 8г8ж- 8г8з- 8г8и- 8г8й- 8г8к- 8г8л- 8г8м- 8г8н- 8г8о- 8г8п-
+8г8р- 8г8с- 8г8т- 8г8у- 8г8ф- 8г8х- 8г8ц- 8г8ч- 8г8ш- 8г8щ- 8г8ь- 8г8ю-
+8г8я- 8г8є- 8г8і- 8г8ї- 8г8ґ- 8г8’- 8д8'- 8д8а- 8д8б- 8д8в- 8д8г- 8д8д-
+8д8е- 8д8ж- 8д8з- 8д8и- 8д8й- 8д8к- 8д8л- 8д8м- 8д8н- 8д8о- 8д8п- 8д8р-
+8д8с- 8д8т- 8д8у- 8д8ф- 8д8х- 8д8ц- 8д8ч- 8д8ш- 8д8щ- 8д8ь- 8д8ю- 8д8я-
+8д8є- 8д8і- 8д8ї- 8д8ґ- 8д8’- 8е8'- 8е8а- 8е8б- 8е8в- 8е8г- 8е8д- 8е8е-
+8е8ж- 8е8з- 8е8и- 8е8й- 8е8к- 8е8л- 8е8м- 8е8н- 8е8о- 8е8п- 8е8р- 8е8с-
+8е8т- 8е8у- 8е8ф- 8е8х- 8е8ц- 8е8ч- 8е8ш- 8е8щ- 8е8ь- 8е8ю- 8е8я- 8е8є-
+8е8і- 8е8ї- 8е8ґ- 8е8’- 8ж8'- 8ж8а- 8ж8б- 8ж8в- 8ж8г- 8ж8д- 8ж8е- 8ж8ж-
+8ж8з- 8ж8и- 8ж8й- 8ж8к- 8ж8л- 8ж8м- 8ж8н- 8ж8о- 8ж8п- 8ж8р- 8ж8с- 8ж8т-
+8ж8у- 8ж8ф- 8ж8х- 8ж8ц- 8ж8ч- 8ж8ш- 8ж8щ- 8ж8ь- 8ж8ю- 8ж8я- 8ж8є- 8ж8і-
+8ж8ї- 8ж8ґ- 8ж8’- 8з8'- 8з8а- 8з8б- 8з8в- 8з8г- 8з8д- 8з8е- 8з8ж- 8з8з-
+8з8и- 8з8й- 8з8к- 8з8л- 8з8м- 8з8н- 8з8о- 8з8п- 8з8р- 8з8с- 8з8т- 8з8у-
+8з8ф- 8з8х- 8з8ц- 8з8ч- 8з8ш- 8з8щ- 8з8ь- 8з8ю- 8з8я- 8з8є- 8з8і- 8з8ї-
+8з8ґ- 8з8’- 8и8'- 8и8а- 8и8б- 8и8в- 8и8г- 8и8д- 8и8е- 8и8ж- 8и8з- 8и8и-
+8и8й- 8и8к- 8и8л- 8и8м- 8и8н- 8и8о- 8и8п- 8и8р- 8и8с- 8и8т- 8и8у- 8и8ф-
+8и8х- 8и8ц- 8и8ч- 8и8ш- 8и8щ- 8и8ь- 8и8ю- 8и8я- 8и8є- 8и8і- 8и8ї- 8и8ґ-
+8и8’- 8й8'- 8й8а- 8й8б- 8й8в- 8й8г- 8й8д- 8й8е- 8й8ж- 8й8з- 8й8и- 8й8й-
+8й8к- 8й8л- 8й8м- 8й8н- 8й8о- 8й8п- 8й8р- 8й8с- 8й8т- 8й8у- 8й8ф- 8й8х-
+8й8ц- 8й8ч- 8й8ш- 8й8щ- 8й8ь- 8й8ю- 8й8я- 8й8є- 8й8і- 8й8ї- 8й8ґ- 8й8’-
+8к8'- 8к8а- 8к8б- 8к8в- 8к8г- 8к8д- 8к8е- 8к8ж- 8к8з- 8к8и- 8к8й- 8к8к-
+8к8л- 8к8м- 8к8н- 8к8о- 8к8п- 8к8р- 8к8с- 8к8т- 8к8у- 8к8ф- 8к8х- 8к8ц-
+8к8ч- 8к8ш- 8к8щ- 8к8ь- 8к8ю- 8к8я- 8к8є- 8к8і- 8к8ї- 8к8ґ- 8к8’- 8л8'-
+8л8а- 8л8б- 8л8в- 8л8г- 8л8д- 8л8е- 8л8ж- 8л8з- 8л8и- 8л8й- 8л8к- 8л8л-
+8л8м- 8л8н- 8л8о- 8л8п- 8л8р- 8л8с- 8л8т- 8л8у- 8л8ф- 8л8х- 8л8ц- 8л8ч-
+8л8ш- 8л8щ- 8л8ь- 8л8ю- 8л8я- 8л8є- 8л8і- 8л8ї- 8л8ґ- 8л8’- 8м8'- 8м8а-
+8м8б- 8м8в- 8м8г- 8м8д- 8м8е- 8м8ж- 8м8з- 8м8и- 8м8й- 8м8к- 8м8л- 8м8м-
+8м8н- 8м8о- 8м8п- 8м8р- 8м8с- 8м8т- 8м8у- 8м8ф- 8м8х- 8м8ц- 8м8ч- 8м8ш-
+8м8щ- 8м8ь- 8м8ю- 8м8я- 8м8є- 8м8і- 8м8ї- 8м8ґ- 8м8’- 8н8'- 8н8а- 8н8б-
+8н8в- 8н8г- 8н8д- 8н8е- 8н8ж- 8н8з- 8н8и- 8н8й- 8н8к- 8н8л- 8н8м- 8н8н-
+8н8о- 8н8п- 8н8р- 8н8с- 8н8т- 8н8у- 8н8ф- 8н8х- 8н8ц- 8н8ч- 8н8ш- 8н8щ-
+8н8ь- 8н8ю- 8н8я- 8н8є- 8н8і- 8н8ї- 8н8ґ- 8н8’- 8о8'- 8о8а- 8о8б- 8о8в-
+8о8г- 8о8д- 8о8е- 8о8ж- 8о8з- 8о8и- 8о8й- 8о8к- 8о8л- 8о8м- 8о8н- 8о8о-
+8о8п- 8о8р- 8о8с- 8о8т- 8о8у- 8о8ф- 8о8х- 8о8ц- 8о8ч- 8о8ш- 8о8щ- 8о8ь-
+8о8ю- 8о8я- 8о8є- 8о8і- 8о8ї- 8о8ґ- 8о8’- 8п8'- 8п8а- 8п8б- 8п8в- 8п8г-
+8п8д- 8п8е- 8п8ж- 8п8з- 8п8и- 8п8й- 8п8к- 8п8л- 8п8м- 8п8н- 8п8о- 8п8п-
+8п8р- 8п8с- 8п8т- 8п8у- 8п8ф- 8п8х- 8п8ц- 8п8ч- 8п8ш- 8п8щ- 8п8ь- 8п8ю-
+8п8я- 8п8є- 8п8і- 8п8ї- 8п8ґ- 8п8’- 8р8'- 8р8а- 8р8б- 8р8в- 8р8г- 8р8д-
+8р8е- 8р8ж- 8р8з- 8р8и- 8р8й- 8р8к- 8р8л- 8р8м- 8р8н- 8р8о- 8р8п- 8р8р-
+8р8с- 8р8т- 8р8у- 8р8ф- 8р8х- 8р8ц- 8р8ч- 8р8ш- 8р8щ- 8р8ь- 8р8ю- 8р8я-
+8р8є- 8р8і- 8р8ї- 8р8ґ- 8р8’- 8с8'- 8с8а- 8с8б- 8с8в- 8с8г- 8с8д- 8с8е-
+8с8ж- 8с8з- 8с8и- 8с8й- 8с8к- 8с8л- 8с8м- 8с8н- 8с8о- 8с8п- 8с8р- 8с8с-
+8с8т- 8с8у- 8с8ф- 8с8х- 8с8ц- 8с8ч- 8с8ш- 8с8щ- 8с8ь- 8с8ю- 8с8я- 8с8є-
+8с8і- 8с8ї- 8с8ґ- 8с8’- 8т8'- 8т8а- 8т8б- 8т8в- 8т8г- 8т8д- 8т8е- 8т8ж-
+8т8з- 8т8и- 8т8й- 8т8к- 8т8л- 8т8м- 8т8н- 8т8о- 8т8п- 8т8р- 8т8с- 8т8т-
+8т8у- 8т8ф- 8т8х- 8т8ц- 8т8ч- 8т8ш- 8т8щ- 8т8ь- 8т8ю- 8т8я- 8т8є- 8т8і-
+8т8ї- 8т8ґ- 8т8’- 8у8'- 8у8а- 8у8б- 8у8в- 8у8г- 8у8д- 8у8е- 8у8ж- 8у8з-
+8у8и- 8у8й- 8у8к- 8у8л- 8у8м- 8у8н- 8у8о- 8у8п- 8у8р- 8у8с- 8у8т- 8у8у-
+8у8ф- 8у8х- 8у8ц- 8у8ч- 8у8ш- 8у8щ- 8у8ь- 8у8ю- 8у8я- 8у8є- 8у8і- 8у8ї-
+8у8ґ- 8у8’- 8ф8'- 8ф8а- 8ф8б- 8ф8в- 8ф8г- 8ф8д- 8ф8е- 8ф8ж- 8ф8з- 8ф8и-
+8ф8й- 8ф8к- 8ф8л- 8ф8м- 8ф8н- 8ф8о- 8ф8п- 8ф8р- 8ф8с- 8ф8т- 8ф8у- 8ф8ф-
+8ф8х- 8ф8ц- 8ф8ч- 8ф8ш- 8ф8щ- 8ф8ь- 8ф8ю- 8ф8я- 8ф8є- 8ф8і- 8ф8ї- 8ф8ґ-
+8ф8’- 8х8'- 8х8а- 8х8б- 8х8в- 8х8г- 8х8д- 8х8е- 8х8ж- 8х8з- 8х8и- 8х8й-
+8х8к- 8х8л- 8х8м- 8х8н- 8х8о- 8х8п- 8х8р- 8х8с- 8х8т- 8х8у- 8х8ф- 8х8х-
+8х8ц- 8х8ч- 8х8ш- 8х8щ- 8х8ь- 8х8ю- 8х8я- 8х8є- 8х8і- 8х8ї- 8х8ґ- 8х8’-
+8ц8'- 8ц8а- 8ц8б- 8ц8в- 8ц8г- 8ц8д- 8ц8е- 8ц8ж- 8ц8з- 8ц8и- 8ц8й- 8ц8к-
+8ц8л- 8ц8м- 8ц8н- 8ц8о- 8ц8п- 8ц8р- 8ц8с- 8ц8т- 8ц8у- 8ц8ф- 8ц8х- 8ц8ц-
+8ц8ч- 8ц8ш- 8ц8щ- 8ц8ь- 8ц8ю- 8ц8я- 8ц8є- 8ц8і- 8ц8ї- 8ц8ґ- 8ц8’- 8ч8'-
+8ч8а- 8ч8б- 8ч8в- 8ч8г- 8ч8д- 8ч8е- 8ч8ж- 8ч8з- 8ч8и- 8ч8й- 8ч8к- 8ч8л-
+8ч8м- 8ч8н- 8ч8о- 8ч8п- 8ч8р- 8ч8с- 8ч8т- 8ч8у- 8ч8ф- 8ч8х- 8ч8ц- 8ч8ч-
+8ч8ш- 8ч8щ- 8ч8ь- 8ч8ю- 8ч8я- 8ч8є- 8ч8і- 8ч8ї- 8ч8ґ- 8ч8’- 8ш8'- 8ш8а-
+8ш8б- 8ш8в- 8ш8г- 8ш8д- 8ш8е- 8ш8ж- 8ш8з- 8ш8и- 8ш8й- 8ш8к- 8ш8л- 8ш8м-
+8ш8н- 8ш8о- 8ш8п- 8ш8р- 8ш8с- 8ш8т- 8ш8у- 8ш8ф- 8ш8х- 8ш8ц- 8ш8ч- 8ш8ш-
+8ш8щ- 8ш8ь- 8ш8ю- 8ш8я- 8ш8є- 8ш8і- 8ш8ї- 8ш8ґ- 8ш8’- 8щ8'- 8щ8а- 8щ8б-
+8щ8в- 8щ8г- 8щ8д- 8щ8е- 8щ8ж- 8щ8з- 8щ8и- 8щ8й- 8щ8к- 8щ8л- 8щ8м- 8щ8н-
+8щ8о- 8щ8п- 8щ8р- 8щ8с- 8щ8т- 8щ8у- 8щ8ф- 8щ8х- 8щ8ц- 8щ8ч- 8щ8ш- 8щ8щ-
+8щ8ь- 8щ8ю- 8щ8я- 8щ8є- 8щ8і- 8щ8ї- 8щ8ґ- 8щ8’- 8ь8'- 8ь8а- 8ь8б- 8ь8в-
+8ь8г- 8ь8д- 8ь8е- 8ь8ж- 8ь8з- 8ь8и- 8ь8й- 8ь8к- 8ь8л- 8ь8м- 8ь8н- 8ь8о-
+8ь8п- 8ь8р- 8ь8с- 8ь8т- 8ь8у- 8ь8ф- 8ь8х- 8ь8ц- 8ь8ч- 8ь8ш- 8ь8щ- 8ь8ь-
+8ь8ю- 8ь8я- 8ь8є- 8ь8і- 8ь8ї- 8ь8ґ- 8ь8’- 8ю8'- 8ю8а- 8ю8б- 8ю8в- 8ю8г-
+8ю8д- 8ю8е- 8ю8ж- 8ю8з- 8ю8и- 8ю8й- 8ю8к- 8ю8л- 8ю8м- 8ю8н- 8ю8о- 8ю8п-
+8ю8р- 8ю8с- 8ю8т- 8ю8у- 8ю8ф- 8ю8х- 8ю8ц- 8ю8ч- 8ю8ш- 8ю8щ- 8ю8ь- 8ю8ю-
+8ю8я- 8ю8є- 8ю8і- 8ю8ї- 8ю8ґ- 8ю8’- 8я8'- 8я8а- 8я8б- 8я8в- 8я8г- 8я8д-
+8я8е- 8я8ж- 8я8з- 8я8и- 8я8й- 8я8к- 8я8л- 8я8м- 8я8н- 8я8о- 8я8п- 8я8р-
+8я8с- 8я8т- 8я8у- 8я8ф- 8я8х- 8я8ц- 8я8ч- 8я8ш- 8я8щ- 8я8ь- 8я8ю- 8я8я-
+8я8є- 8я8і- 8я8ї- 8я8ґ- 8я8’- 8є8'- 8є8а- 8є8б- 8є8в- 8є8г- 8є8д- 8є8е-
+8є8ж- 8є8з- 8є8и- 8є8й- 8є8к- 8є8л- 8є8м- 8є8н- 8є8о- 8є8п- 8є8р- 8є8с-
+8є8т- 8є8у- 8є8ф- 8є8х- 8є8ц- 8є8ч- 8є8ш- 8є8щ- 8є8ь- 8є8ю- 8є8я- 8є8є-
+8є8і- 8є8ї- 8є8ґ- 8є8’- 8і8'- 8і8а- 8і8б- 8і8в- 8і8г- 8і8д- 8і8е- 8і8ж-
+8і8з- 8і8и- 8і8й- 8і8к- 8і8л- 8і8м- 8і8н- 8і8о- 8і8п- 8і8р- 8і8с- 8і8т-
+8і8у- 8і8ф- 8і8х- 8і8ц- 8і8ч- 8і8ш- 8і8щ- 8і8ь- 8і8ю- 8і8я- 8і8є- 8і8і-
+8і8ї- 8і8ґ- 8і8’- 8ї8'- 8ї8а- 8ї8б- 8ї8в- 8ї8г- 8ї8д- 8ї8е- 8ї8ж- 8ї8з-
+8ї8и- 8ї8й- 8ї8к- 8ї8л- 8ї8м- 8ї8н- 8ї8о- 8ї8п- 8ї8р- 8ї8с- 8ї8т- 8ї8у-
+8ї8ф- 8ї8х- 8ї8ц- 8ї8ч- 8ї8ш- 8ї8щ- 8ї8ь- 8ї8ю- 8ї8я- 8ї8є- 8ї8і- 8ї8ї-
+8ї8ґ- 8ї8’- 8ґ8'- 8ґ8а- 8ґ8б- 8ґ8в- 8ґ8г- 8ґ8д- 8ґ8е- 8ґ8ж- 8ґ8з- 8ґ8и-
+8ґ8й- 8ґ8к- 8ґ8л- 8ґ8м- 8ґ8н- 8ґ8о- 8ґ8п- 8ґ8р- 8ґ8с- 8ґ8т- 8ґ8у- 8ґ8ф-
+8ґ8х- 8ґ8ц- 8ґ8ч- 8ґ8ш- 8ґ8щ- 8ґ8ь- 8ґ8ю- 8ґ8я- 8ґ8є- 8ґ8і- 8ґ8ї- 8ґ8ґ-
+8ґ8’- 8’8а- 8’8б- 8’8в- 8’8г- 8’8д- 8’8е- 8’8ж- 8’8з- 8’8и- 8’8й- 8’8к-
+8’8л- 8’8м- 8’8н- 8’8о- 8’8п- 8’8р- 8’8с- 8’8т- 8’8у- 8’8ф- 8’8х- 8’8ц-
+8’8ч- 8’8ш- 8’8щ- 8’8ь- 8’8ю- 8’8я- 8’8є- 8’8і- 8’8ї- 8’8ґ- 8’8’- а2й а3а
+а3е а3о а3у а3у4дар а3ю а3я а3є а3і а3і4стор а3ї аа4дрес абия4к авої4д аві4а
+ае4тил ае4фект ай4с3берг альбі5он ана3в4ч ао4браз ао4пис ао4пік ао4ха ао4хот
+ао4щад ар4т3афіш ар4т3взвод ар4т3десант ар4т3кафе ар4т3майс ар4т3медіа
+ар4т3мейс ар4т3мейст ар4т3мін ар4т3о4бстр ар4т3о4дин ар4т3о4збр ар4т3під
+ар4т3рин ар4т3у4стан ар4т3факт ар4т3хім ар4т3центр ау4год ау4т3екол ауді4о
+ахої4д б'4єть ба4с3антра ба4с3енер баге4р3мейст бак3а4наліз бактері4о
+бале4т3мейст бальне4о бе4з5і4дей бе5кон без3а4дрес без5і4мен беза4варі
+безві4д3 безе4місі безу4гл безу4пин бей4сбол бйор4нс бло4к3пост бо4г3дан
+бо4р4т3мех бо4р4т3о4пер бо4р4т3про бо4р4т3рад бо4р4т3і4нж бо4є3гол бо4є3гот
+бо4є3зап бо4є3здат бо4є3комп бо4є3пост бо4є3прип бори4с5п бран4д
+бран4д3мейст брі4дж3порт бі4о3 біблі4о б’4єть в3м'4я в3м’4я в3у4дар
+ва4ль4д3мейст ве4ль4т3мейст вер4х3н ви3й4д ви3й4т ви3у4ч вий4м вина3й4д
+вина3й4т виу4ди вия4в вия4сн виї4 во4с5ко во4станнє во4євод во4єнач
+водо5з4бір водо5с4ток воль4т3ампер воль4т3метр ві5д4е4о ві5д4ен ві5д4ом
+від3в'4я від3в’4я від5о4браж від5о4браз від7зна віду4ч ге2ть3ман ге4о гелі4о
+ген3а4наліз го4с4п5роз го4ф3мейст гоме4о гос4п3у4год граф3о4браз гро4с3мейст
+гі4д5ро5мет гіпер3а4кт гіпер3е4місі д3у4сім д4ж д4з д4ні3п4р д4о3й4м
+двох4а5том декре4т3мейст дер4ж3без дер4ж3резерв дер4ж5а4дм дер4ж5а4том
+дер4ж5бюдж дер4ж5вид дер4ж5дум дер4ж5замов дер4ж5ком дер4ж5нафт дер4ж5реєс
+дер4ж5служ дер4ж5стр дея4к джен4тль ди4с3гарм ди4с3квал ди4с3комф ди4с3конт
+ди4с3кред ди4с3крет ди4с3крец ди4с3крим ди4с3кусі ди4с3куту ди4с3лок
+ди4с3парит ди4с3перс ди4с3петч ди4с3пле ди4с3плей ди4с3пози ди4с3проп
+ди4с3пут ди4с3тил ди4с3триб ди4с3троф ди4с3функц дисбаланс до3в'4ю до3в'4є
+до3в’4ю до3в’4є до3з4вол до3з4віл до4к3мейст до5о4р дорого5в4каз доу4к доу4м
+дої4д дої4ж дої4м дої4ст дої4х ді3й4д ді3й4т ді4алог ді4о ді4оген ді4єві4д3
+дій4ма е2й е3а е3в4каз е3е е3м4рій е3о е3о4кисл е3у е3ю е3я е3є е3і е3і4стор
+е3ї еа4варі еа4дрес еа4кт еви3в4ч едо3в4ч ее4місі ее4стет ее4фект ек2с1к
+ек2с1п ек2с1т ек2с1ц ена3в4ч енерго3з4береж енерго3з4беріг ень7о4кисл
+ео4браз ео4бур ео4голош ео4зор ео4пал ео4пис ео4плачув ео4сві ео4ха ео4хот
+ео4цін ео4щад еті4о еу4бог еу4год еу4стр еі4стот жко4м5а4том жона3в4ч з'4єдн
+з'я4в з3а4кт з3а4наліз з3в'4я з3в’4я з3м'4я з3м’4я з3у4дар за3в'4ю за3в'4я
+за3в'4є за3в’4ю за3в’4я за3в’4є за3м'4я за3м’4я за3у4ч за3ю4ш за3я4ло
+за4вві4д3 за4п3част за5о4р зай4м зай4ня зая4в заї4д заї4ж заї4з заї4л заї4м
+заї4х здій4ня зе4кономити зна3й4д зна3й4т зо4ка зо4ке зо4ки зо4ку зо4кі зо6о
+зу4роч зі3в'4ю зі3в'4я зі3в'4є зі3в’4ю зі3в’4я зі3в’4є зі3м'4я зі3м’4я
+з’4єдн з’я4в и2й и3а и3е и3о и3о4кисл и3у и3ю и3я и3є и3і и3і4стор и3ї
+иа4варі игої4д ие4місі ие4стет ий4ня ий4ти й3е4стет й3у4бог йо4сві ка5нал
+каза4х3стан капе4ль3мейст квар4т3плат кварти4р3мейст квої4д киї4венер
+кому4ненерг кон4тр3арг кон4трре контр3у4дар конце4р4т3мейст корої4д коу4роч
+кра4н3мейст м3а4наліз мете4о мо4к5рий мона3в4ч мі4н5е4ко мі4н5е4нер мі4о
+між3а4варі між3а4наліз міжу4соб на3б4лиз на3в'4ю на3в'4я на3в4ряд на3в4ча
+на3в4чен на3в4чіть на3в’4ю на3в’4я на3д4бан на3з4в на3м'4я на3м’4я на3у4ч
+на4д'3 на4д7з4в на4д’3 на4й3а на4й3е на4й3масл на4й3обереж на4й3спри
+на4й3у4бог на4й3якіс на4й7о4бер на4й7о4гид на4й7о4гол на4й7о4гряд на4й7о4пук
+на4й7о4хай на5п4лив над3а4варі наду4роч наді4стор наді4стот най3е4фект
+най3о4браз най3о4станн най3я4сн най3і4стор най4ма най4ня найа4кт найі4стот
+нао4р напоу4м напів3а4варі ная4в наї4вс наї4вш наї4ж наї4з наї4л наї4м наї4с
+наї4х не3в4том не3д4бан не3з4важ не3з4вич не3у4ч не4оклас не4окомун
+не4оландш не4олог не4олібер не4оліт не4омальт не4онац не4офіт неа4би
+неві4д'3 неві4д3 неві4д’3 недо3у4ч недої4 нео4пал непо3в'4я непо3в’4я неу4к
+нею4н нея4к нея4рок нея4сн неї4ст но3м'4я но3м’4я но4к3а4ут нт3а4наліз
+ні4т5рат нія4к о2й о3а о3а4наліз о3в4каз о3е о3о о3о4кисл о3у о3у4дар о3ю
+о3я о3є о3і о3і4стор о3ї оа4варі оа4дрес оа4каці оа4кт оа4на оа4том об'3
+об3в'4я об3в’4я об3м'4я об3м’4я об4л3а4дмін об4лдер4ж об5у4мов обой4м
+обі3в'4є обі3в’4є обі3д4ран обі3й4д обі3й4т обій4м обій4ня об’3 ови3в4ч
+ові4д3 од'3 од’3 ое4ко ое4місі ое4стет ое4фект оза3в4ч онаї4д оної4д оо4б
+оо4браз оо4біг оо4держ оо4динок оо4к оо4ктан оо4пал оо4пис оо4плачув оо4сві
+оо4цін оо4чист оо4чищ ооб'3 ооб3м ооб3ро ооб’3 оу4год оу4ком оу4с оу4стр
+оу4сун оі4зол оі4стот п4о5бере па4н3о4тець пале4о пан3е4стет пар3е4стет
+пед3у4чи пере3в4том пере3й4д пере3й4т пере3м'4я пере3м’4я пере3у4ч пере4д5см
+пере5о4р пере5п4лив перег4ній перед3а4варі перед3о4пла перед3о4станн
+перед3і4стор перед5о4бід перед5у4мов перей4м перей4ня переї4д переї4ж
+переї4з переї4л переї4с переї4х по3б4лизу по3в'4ю по3в'4я по3в'4є по3в4тор
+по3в4ча по3в4чен по3в4чіть по3в’4ю по3в’4я по3в’4є по3д4во по3д4раз по3д4роб
+по3м'4я по3м’4я по4с4т3декр по4с4т3контра по4с4т3менопауз по4с4т3раді
+по4с4т5кому по4с4т5радян по4с4т5соці по4с4тприват по4ш4т3мейст по5ж4ніть
+по5з4бав по5о4р позау4роч полі3а4наліз полі4о полі4т5екон полі4ц3мейст
+пор4т3н пор4т3рет пор4т3фел пос4т3кап пос4т3ком пос4т3нат пос4т3проц
+пос4т3соц пос4т3фікс пост3а4варі пост3і4стор поу4роч поч4не поч4ни поч4ну
+поя4в поя4с поя4сн пої4д пої4зд пр4о5плат пре4й4с при3в'4я при3в’4я при3й4д
+при3й4т при3м'4я при3м’4я при4нцип прий4м прио4р приу4роч приї4 про3б4лем
+про3с4тирад про4ект3н про4м3май про4ф3ві4д3 про4ф3с прой4м проя4сн прої4
+пі5в4ень пі5в4оні пі6д5о4р пів3о4вал пів3у4год пів3і4стор пів5о4с4тр піва4кт
+піво4с під3в'4я під3в’4я під3у4ч під5о4дин піді3м'4я піді3м’4я підій4ня
+пій4м раді4о рай3в4но ре3а4наліз рмої4д ро4з'5єдн ро4з3гром ро4з3лив
+ро4з5вер ро4з5гор ро4з5д4во ро4з5діл ро4з5мінний ро4з5чеп ро4з’5єдн ро5з4йом
+ро5з4о5рам ро5з4ора. ро5з4орах ро5з4ори ро5з4оро ро5з4ору ро5з4орю ро5з4оря
+ро5з4орі ро5з4ум ро6з5о4ри. роз'я4р роз'я4сн роз3у4год роз5вант роз5вин
+роз5вит роз5у4чен роз5і4мен розо4ра розо4ре розо4реш розо4рн роз’я4р
+роз’я4сн руко5с4тиск само3у4ч сан4к4т3 сво4єкорис сво4єрід сво4єчас серцеї4д
+сеї4д сор4тн соці4о спе4ц3ві4д3 спе4ц3кур спе4ц3мон спе4ц3с спе4цпр
+спор4т3вир спор4т3зал спор4т3клуб спор4т3ком спор4т3май спор4т4с3ме
+співві4д3 стат5упр су3м'4я су3м’4я супер3а4варі супер3е4фект супер3о4браз
+сь4квуг те4одоліт те4олог те4ософ те4х3ві4д3 тор4г3пред тран4с3 тур4к3мен
+у2й у3а у3в'4я у3в’4я у3е у3м'4я у3м’4я у3о у3у у3ю у3я у3є у3і у3ї у4к4р
+убої4д укр3а4вт укр3а4гр укр3е4кс укр3і4н4банк уя4в уі3в'4ю уі3в'4є уі3в’4ю
+уі3в’4є фо4р4с4т3мейст фізі4о х3о4кисл хво4є3г4риз хо4р3мейст хімі4о
+ц3а4наліз цен4т4р3енерг цук3ро чорно3б4рив шапі4т3мейст шта4л3мейст ь6о ю2й
+ю3а ю3е ю3о ю3у ю3ю ю3я ю3є ю3і ю3ї я2й я3а я3е я3о я3у я3ю я3я я3є я3і4стор
+я3ї яа4варі яе4місі ясої4д є2й є3у є3ю є3є є3ї єв4р3атом єге4р3мейст
+єпи4с5коп єпі4с5коп і2й і3а і3е і3и і3о і3у і3ю і3я і3є і3і4стор і3ї
+і4л3е4тил і4он іа4дрес іе4тил ій4ти інтер3в'4ю інтер3в’4ю інфор4м3аген
+іо4ктан іона3в4ч ї2й ї3е ї3о ї3ю ’ї4в ’ї4д ’ї4ж ’ї4з ’ї4л ’ї4м ’ї4с ’ї4х
+}
+`
@@ -0,0 +1,256 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ImportOptions is a metadata of imported manuscript,
+// empty fields are detected from text or filled with defaults
+type ImportOptions struct {
+	Title  string
+	Author string
+	Lang   string
+	Genre  string
+	Date   time.Time
+	// DocumentAuthor is a name of creator of FB2 file, program name
+	// by default
+	DocumentAuthor string
+}
+
+const (
+	// programName is used as program-used and default document author
+	programName  = "gofb2"
+	defaultGenre = "prose_contemporary"
+	// chapterLevel is a section level of chapters, parts and volumes are above
+	chapterLevel = 3
+	// verseLength is a maximum length of verse line
+	verseLength = 60
+)
+
+// romanNumberRe match roman numbers from 1 to 3999 and empty string,
+// match of number is checked to be non-empty
+const romanNumberRe = `m{0,3}(?:cm|cd|d?c{0,3})(?:xc|xl|l?x{0,3})(?:ix|iv|v?i{0,3})`
+
+// chapterRomanRe match roman numbers of chapters from 1 to 399 and empty
+// string, words like "MIX" or "DIV" are not chapter numbers
+const chapterRomanRe = `C{0,3}(?:XC|XL|L?X{0,3})(?:IX|IV|V?I{0,3})`
+
+// chapterNumberWords are numbers of chapters written in words
+var chapterNumberWords = strings.Fields(`
+	one two three four five six seven eight nine ten eleven twelve
+	first second third fourth fifth sixth seventh eighth ninth tenth
+	eleventh twelfth last final
+	один два три четыре пять шесть семь восемь девять десять
+	первая вторая третья четвертая четвёртая пятая шестая седьмая
+	восьмая девятая десятая последняя заключительная
+	первый второй третий четвертый четвёртый пятый шестой седьмой
+	восьмой девятый десятый последний заключительный
+`)
+
+var (
+	chapterHeadingRe = regexp.MustCompile(`(?i)^(том|книга|volume|book|часть|part|глава|chapter)\s+([0-9]+|` + romanNumberRe + `|` + strings.Join(chapterNumberWords, "|") + `)(?:\.?$|\s*[.:—–]\s*\S.*$|\s+-\s+\S.*$)`)
+	namedHeadingRe   = regexp.MustCompile(`(?i)^(пролог|эпилог|предисловие|послесловие|вступление|введение|заключение|prologue|epilogue|preface|foreword|afterword|introduction|conclusion)(?:\.?$|\s*[.:—–-]\s*\S.*$)`)
+	numberHeadingRe  = regexp.MustCompile(`^([0-9]{1,3}|` + chapterRomanRe + `)\.?$`)
+	sceneBreakRe     = regexp.MustCompile(`^[*#~=-]+(?:\s*[*#~=-]+)*$`)
+	listMarkerRe     = regexp.MustCompile(`^(?:[-•*+]|[0-9]+[.)])\s`)
+)
+
+// headingLevel return section level of chapter heading line or 0
+func headingLevel(line string) int {
+	line = strings.TrimSpace(line)
+	if line == "" || utf8.RuneCountInString(line) > 100 {
+		return 0
+	}
+	if m := chapterHeadingRe.FindStringSubmatch(line); m != nil && m[2] != "" {
+		switch strings.ToLower(m[1]) {
+		case "том", "книга", "volume", "book":
+			return chapterLevel - 2
+		case "часть", "part":
+			return chapterLevel - 1
+		}
+		return chapterLevel
+	}
+	if namedHeadingRe.MatchString(line) {
+		return chapterLevel
+	}
+	// lone "I" is a pronoun more often than a chapter number
+	if m := numberHeadingRe.FindStringSubmatch(line); m != nil && m[1] != "" && line != "I" {
+		return chapterLevel
+	}
+	return 0
+}
+
+// fillEmptySections add empty line to sections without content and
+// subsections, such sections are invalid in FictionBook
+func fillEmptySections(sections []*Section) {
+	for _, s := range sections {
+		if len(s.Sections) > 0 {
+			fillEmptySections(s.Sections)
+		} else if len(s.Content) == 0 {
+			el := &EmptyLine{}
+			el.SetXMLName(xml.Name{Local: "empty-line"})
+			s.appendContent(el)
+		}
+	}
+}
+
+// isCapsLine report whether line is written in capitals and may be a heading
+func isCapsLine(line string) bool {
+	if utf8.RuneCountInString(line) > verseLength || isDialogue(line) {
+		return false
+	}
+	letters := 0
+	for _, r := range line {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 3
+}
+
+// isDialogue report whether line starts with a dash
+func isDialogue(line string) bool {
+	return strings.HasPrefix(line, "—") || strings.HasPrefix(line, "–") || strings.HasPrefix(line, "- ")
+}
+
+// isVerse report whether lines look like a stanza: short lines mostly
+// starting with a capital letter and not ending with a sentence
+func isVerse(lines []string, maxLength int) bool {
+	if len(lines) < 2 {
+		return false
+	}
+	capitals, sentences, dialogues := 0, 0, 0
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		n := utf8.RuneCountInString(l)
+		if n == 0 || n > maxLength || listMarkerRe.MatchString(l) {
+			return false
+		}
+		if isDialogue(l) {
+			dialogues++
+		}
+		for _, r := range l {
+			if unicode.IsLetter(r) {
+				if unicode.IsUpper(r) {
+					capitals++
+				}
+				break
+			}
+		}
+		switch r, _ := utf8.DecodeLastRuneInString(strings.TrimRight(l, `"'»“”)`)); r {
+		case '.', '!', '?', '…':
+			sentences++
+		}
+	}
+	return capitals*2 > len(lines) && sentences*2 <= len(lines) && dialogues*2 <= len(lines)
+}
+
+// textAuthorLine return author name from line like "— Author"
+func textAuthorLine(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !isDialogue(line) || utf8.RuneCountInString(line) > verseLength {
+		return "", false
+	}
+	author := strings.TrimLeft(line, "—–- ")
+	return author, author != ""
+}
+
+// detectLang return language of text by its letters
+func detectLang(text string) string {
+	cyrillic, latin, ukrainian := 0, 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune("іїєґІЇЄҐ", r) {
+				ukrainian++
+			}
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	switch {
+	case cyrillic > latin && ukrainian*100 > cyrillic:
+		return "uk"
+	case cyrillic > latin:
+		return "ru"
+	}
+	return "en"
+}
+
+// importDescription return description with fields required by FictionBook schema
+func importDescription(opts ImportOptions, source []byte) *Description {
+	d := &Description{}
+	d.SetXMLName(xml.Name{Local: "description"})
+	ti := &TitleInfo{Lang: opts.Lang}
+	ti.SetXMLName(xml.Name{Local: "title-info"})
+	d.TitleInfo = ti
+	if opts.Genre != "" {
+		genre := &Genre{Genre: opts.Genre}
+		genre.SetXMLName(xml.Name{Local: "genre"})
+		ti.Genres = []*Genre{genre}
+	}
+	if strings.TrimSpace(opts.Author) != "" {
+		ti.Authors = []*Author{personAuthor("author", opts.Author, "")}
+	}
+	if strings.TrimSpace(opts.Title) != "" {
+		ti.BookTitle = textField("book-title", opts.Title)
+	}
+	fillDescription(d, opts.Date, opts.DocumentAuthor, source)
+	return d
+}
+
+// fillDescription add missing fields required by FictionBook schema,
+// document id is generated from source
+func fillDescription(d *Description, date time.Time, creator string, source []byte) {
+	if d.TitleInfo == nil {
+		d.TitleInfo = &TitleInfo{}
+		d.TitleInfo.SetXMLName(xml.Name{Local: "title-info"})
+	}
+	ti := d.TitleInfo
+	if len(ti.Genres) == 0 {
+		genre := &Genre{Genre: defaultGenre}
+		genre.SetXMLName(xml.Name{Local: "genre"})
+		ti.Genres = []*Genre{genre}
+	}
+	if len(ti.Authors) == 0 {
+		ti.Authors = []*Author{personAuthor("author", "Unknown", "")}
+	}
+	if ti.BookTitle == nil || strings.TrimSpace(ti.BookTitle.Value) == "" {
+		ti.BookTitle = textField("book-title", "Untitled")
+	}
+	if ti.Lang == "" {
+		ti.Lang = detectLang(string(source))
+	}
+
+	if d.DocumentInfo == nil {
+		d.DocumentInfo = newDocumentInfo(date, creator, source)
+	}
+}
+
+// newDocumentInfo return document-info of new file with id generated from
+// source, zero date means current time
+func newDocumentInfo(date time.Time, creator string, source []byte) *DocumentInfo {
+	if date.IsZero() {
+		date = time.Now()
+	}
+	di := &DocumentInfo{ID: hashUUID(source), Version: 1}
+	di.SetXMLName(xml.Name{Local: "document-info"})
+	// author of document is a creator of file, not of book
+	if strings.TrimSpace(creator) == "" {
+		creator = programName
+	}
+	di.Authors = []*Author{personAuthor("author", creator, "")}
+	di.ProgramUsed = textField("program-used", programName)
+	di.Date = &Date{Value: &XMLDate{Time: date}, StrValue: date.Format(dateFormat)}
+	di.Date.SetXMLName(xml.Name{Local: "date"})
+	return di
+}
@@ -0,0 +1,79 @@
+package gofb2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeadingLevel(t *testing.T) {
+	for line, want := range map[string]int{
+		"Chapter 5":               chapterLevel,
+		"Chapter XIV. The Storm":  chapterLevel,
+		"Глава первая":            chapterLevel,
+		"Глава 3 - Встреча":       chapterLevel,
+		"Часть вторая":            chapterLevel - 1,
+		"Part II: The End":        chapterLevel - 1,
+		"Book One":                chapterLevel - 2,
+		"Пролог":                  chapterLevel,
+		"12":                      chapterLevel,
+		"Book review":             0,
+		"Part time job":           0,
+		"Chapter and verse":       0,
+		"Part mid-term":           0,
+		"Part one-sided argument": 0,
+		"Глава семейства вернулся.": 0,
+		"Книга — лучший подарок.":   0,
+		"Глава — это голова.":       0,
+		"Part — of the deal":        0,
+		"Chapter . foo":             0,
+		"Том : два":                 0,
+		"Chapter":                   0,
+		"XIV.":                      chapterLevel,
+		"II":                        chapterLevel,
+		"I.":                        chapterLevel,
+		"I":                         0,
+		"MIX":                       0,
+		"IIII":                      0,
+		".":                         0,
+	} {
+		if got := headingLevel(line); got != want {
+			t.Errorf("headingLevel(%q) = %d, want %d", line, got, want)
+		}
+	}
+}
+
+func TestReadHTMLIDs(t *testing.T) {
+	fb, err := ReadHTML(strings.NewReader(`<html><body><h1>Book</h1>
+<header id="top"><p>Head</p></header><p>Text</p>
+<h2 id="two">Two</h2><p>More</p></body></html>`), ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]string{}
+	walk(bookContent(fb), func(c Contenter) bool {
+		if id := nodeID(c); id != "" {
+			ids[id] = c.GetXMLName().Local
+		}
+		return true
+	})
+	if ids["top"] != "p" || ids["two"] != "section" {
+		t.Errorf("ids = %v", ids)
+	}
+}
+
+func TestReadTextEmpty(t *testing.T) {
+	for _, s := range []string{"", " \n\n\t\n"} {
+		if _, err := ReadText(strings.NewReader(s), ImportOptions{}); err == nil {
+			t.Errorf("no error for %q", s)
+		}
+	}
+	fb, err := ReadText(strings.NewReader("Chapter 1\n"), ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range fb.Body.Sections {
+		if len(s.Content) == 0 && len(s.Sections) == 0 {
+			t.Error("empty section")
+		}
+	}
+}
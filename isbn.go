@@ -0,0 +1,259 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// isbnPrefixRe match prefixes like "ISBN", "ISBN:" or "ISBN-"
+var isbnPrefixRe = regexp.MustCompile(`(?i)^isbn[- ]?`)
+
+// isbnLabelRe match labels "10" and "13" after prefix like "ISBN-13:"
+var isbnLabelRe = regexp.MustCompile(`^1[03](:?)\s*`)
+
+// ISBN is an International Standard Book Number in 13 digit form
+// without hyphens
+type ISBN string
+
+// ParseISBN parse ISBN-10 or ISBN-13 with optional "ISBN" prefix, hyphens
+// and spaces, check digit is validated and ISBN-10 is converted to ISBN-13
+func ParseISBN(s string) (ISBN, error) {
+	orig := s
+	s = isbnPrefixRe.ReplaceAllString(strings.TrimSpace(s), "")
+	if m := isbnLabelRe.FindStringSubmatch(s); m != nil {
+		// "10" of "ISBN 1032123451" is a part of number
+		rest := s[len(m[0]):]
+		if m[1] != "" || !isbnLength(s) && isbnLength(rest) {
+			s = rest
+		}
+	}
+	s = strings.TrimSpace(strings.TrimPrefix(s, ":"))
+	digits := make([]byte, 0, 13)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case (c == 'X' || c == 'x') && len(digits) == 9 && i == len(s)-1:
+			digits = append(digits, 'X')
+		case c == '-' || c == ' ':
+		default:
+			return "", fmt.Errorf("invalid ISBN %q", orig)
+		}
+	}
+	switch len(digits) {
+	case 10:
+		if isbn10Check(digits[:9]) != digits[9] {
+			return "", fmt.Errorf("invalid ISBN checksum %q", orig)
+		}
+		isbn := append([]byte("978"), digits[:9]...)
+		return ISBN(append(isbn, isbn13Check(isbn))), nil
+	case 13:
+		if digits[12] == 'X' || isbn13Check(digits[:12]) != digits[12] {
+			return "", fmt.Errorf("invalid ISBN checksum %q", orig)
+		}
+		if p := string(digits[:3]); p != "978" && p != "979" {
+			return "", fmt.Errorf("invalid ISBN prefix %q", orig)
+		}
+		return ISBN(digits), nil
+	}
+	return "", fmt.Errorf("invalid ISBN length %q", orig)
+}
+
+// isbnLength report whether s has 10 or 13 digits
+func isbnLength(s string) bool {
+	n := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' || c == 'X' || c == 'x' {
+			n++
+		}
+	}
+	return n == 10 || n == 13
+}
+
+func isbn10Check(digits []byte) byte {
+	sum := 0
+	for i, d := range digits {
+		sum += (10 - i) * int(d-'0')
+	}
+	switch c := (11 - sum%11) % 11; c {
+	case 10:
+		return 'X'
+	default:
+		return byte('0' + c)
+	}
+}
+
+func isbn13Check(digits []byte) byte {
+	sum := 0
+	for i, d := range digits {
+		w := 1
+		if i%2 == 1 {
+			w = 3
+		}
+		sum += w * int(d-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// ISBN10 return ISBN in 10 digit form, ISBNs with 979 prefix
+// have no such form
+func (i ISBN) ISBN10() (string, bool) {
+	if len(i) != 13 || i[:3] != "978" {
+		return "", false
+	}
+	d := []byte(i[3:12])
+	return string(append(d, isbn10Check(d))), true
+}
+
+// String return hyphenated ISBN or digits when registration group
+// is unknown
+func (i ISBN) String() string {
+	if h, ok := i.Hyphenate(); ok {
+		return h
+	}
+	return string(i)
+}
+
+// Hyphenate return ISBN with hyphens between prefix, registration group,
+// registrant, publication and check digit
+func (i ISBN) Hyphenate() (string, bool) {
+	if len(i) != 13 {
+		return "", false
+	}
+	ranges := loadISBNRanges()
+	s := string(i)
+	for n := 1; n <= 5; n++ {
+		group := s[:3] + "-" + s[3:3+n]
+		rules, ok := ranges[group]
+		if !ok {
+			continue
+		}
+		rest := s[3+n : 12]
+		for _, r := range rules {
+			l := len(r.lo)
+			if l >= len(rest) || rest[:l] < r.lo || rest[:l] > r.hi {
+				continue
+			}
+			return group + "-" + rest[:l] + "-" + rest[l:] + "-" + s[12:], true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// isbnRe match ISBN candidates in text
+var isbnRe = regexp.MustCompile(`\d[\d\- ]{8,}[\dXx]\b`)
+
+// ExtractISBNs return valid ISBNs found in text without duplicates
+func ExtractISBNs(s string) []ISBN {
+	var res []ISBN
+	seen := map[ISBN]bool{}
+	add := func(c string) bool {
+		isbn, err := ParseISBN(c)
+		if err != nil {
+			return false
+		}
+		if !seen[isbn] {
+			seen[isbn] = true
+			res = append(res, isbn)
+		}
+		return true
+	}
+	for _, c := range isbnRe.FindAllString(s, -1) {
+		if !add(c) {
+			// numbers separated by spaces
+			for _, f := range strings.Fields(c) {
+				add(f)
+			}
+		}
+	}
+	return res
+}
+
+// ISBNs return valid ISBNs of publish-info
+func (pi *PublishInfo) ISBNs() []ISBN {
+	if pi.ISBN == nil {
+		return nil
+	}
+	return ExtractISBNs(pi.ISBN.Value)
+}
+
+// NormalizeISBN replace ISBN of publish-info by hyphenated ISBN-13 list,
+// field is kept when it has no valid ISBN
+func NormalizeISBN(pi *PublishInfo) {
+	isbns := pi.ISBNs()
+	if len(isbns) == 0 {
+		return
+	}
+	s := make([]string, len(isbns))
+	for i, isbn := range isbns {
+		s[i] = isbn.String()
+	}
+	pi.ISBN.Value = strings.Join(s, ", ")
+}
+
+type isbnRange struct {
+	lo, hi string
+}
+
+var (
+	isbnRangesMu     sync.Mutex
+	isbnRangesParsed map[string][]isbnRange
+)
+
+func loadISBNRanges() map[string][]isbnRange {
+	isbnRangesMu.Lock()
+	defer isbnRangesMu.Unlock()
+	if isbnRangesParsed == nil {
+		isbnRangesParsed = map[string][]isbnRange{}
+		for group, rules := range isbnRanges {
+			for _, r := range strings.Fields(rules) {
+				bounds := strings.SplitN(r, "-", 2)
+				isbnRangesParsed[group] = append(isbnRangesParsed[group], isbnRange{bounds[0], bounds[1]})
+			}
+		}
+	}
+	return isbnRangesParsed
+}
+
+// LoadISBNRanges load registration groups from RangeMessage.xml published
+// by International ISBN Agency, it replace bundled table
+func LoadISBNRanges(r io.Reader) error {
+	var msg struct {
+		Groups []struct {
+			Prefix string `xml:"Prefix"`
+			Rules  []struct {
+				Range  string `xml:"Range"`
+				Length int    `xml:"Length"`
+			} `xml:"Rules>Rule"`
+		} `xml:"RegistrationGroups>Group"`
+	}
+	if err := xml.NewDecoder(r).Decode(&msg); err != nil {
+		return err
+	}
+	ranges := map[string][]isbnRange{}
+	for _, g := range msg.Groups {
+		for _, rule := range g.Rules {
+			bounds := strings.SplitN(rule.Range, "-", 2)
+			if rule.Length == 0 {
+				// range is not in use
+				continue
+			}
+			if len(bounds) != 2 || len(bounds[0]) < rule.Length || len(bounds[1]) < rule.Length {
+				return fmt.Errorf("invalid ISBN range %q of group %s", rule.Range, g.Prefix)
+			}
+			ranges[g.Prefix] = append(ranges[g.Prefix], isbnRange{bounds[0][:rule.Length], bounds[1][:rule.Length]})
+		}
+	}
+	if len(ranges) == 0 {
+		return fmt.Errorf("no ISBN registration groups")
+	}
+	isbnRangesMu.Lock()
+	defer isbnRangesMu.Unlock()
+	isbnRangesParsed = ranges
+	return nil
+}
@@ -0,0 +1,39 @@
+package gofb2
+
+// isbnRanges are registrant ranges of registration groups, length of
+// registrant element is a length of range bounds. Full table can be loaded
+// with LoadISBNRanges.
+var isbnRanges = map[string]string{
+	// English language
+	"978-0": "00-19 200-699 7000-8499 85000-89999 900000-949999 9500000-9999999",
+	"978-1": "00-09 100-399 4000-5499 55000-86979 869800-998999 9990000-9999999",
+	// French language
+	"978-2": "00-19 200-349 35000-39999 400-699 7000-8399 84000-89999 900000-949999 9500000-9999999",
+	// German language
+	"978-3": "00-02 030-033 0340-0369 03700-03999 04-19 200-699 7000-8499 85000-89999 900000-949999 9500000-9539999 95400-96999 9700000-9849999 98500-99999",
+	// Japan
+	"978-4": "00-19 200-699 7000-8499 85000-89999 900000-949999 9500000-9999999",
+	// former U.S.S.R
+	"978-5": "00000-00499 0050-0099 01-19 200-420 4210-4299 430-430 4310-4399 440-440 4410-4499 450-603 6040000-6049999 605-699 7000-8499 85000-89999 900000-909999 91000-91999 9200-9299 93000-94999 9500000-9500999 9501-9799 98000-98999 9900000-9909999 9910-9999",
+	// China
+	"978-7": "00-09 100-499 5000-7999 80000-89999 900000-999999",
+	// Czech Republic and Slovakia
+	"978-80": "00-19 200-699 7000-8499 85000-89999 900000-999999",
+	// Poland
+	"978-83": "00-19 200-599 60000-69999 7000-8499 85000-89999 900000-999999",
+	// Spain
+	"978-84": "00-13 140-149 15000-19999 200-699 7000-8499 85000-89999 9000-9199 920000-923999 92400-92999 930000-949999 95000-96999 9700-9999",
+	// Netherlands
+	"978-90": "00-19 200-499 5000-6999 70000-79999 800000-849999 8500-8999 90-90 910000-939999 94-94 950000-999999",
+	// Kazakhstan
+	"978-601": "00-19 200-699 7000-7999 80000-84999 85-99",
+	// Ukraine
+	"978-617": "00-49 500-699 7000-8999 90000-99999",
+	"978-966": "00-12 130-139 14-14 1500-1699 170-199 2000-2789 279-289 2900-2999 300-699 7000-8999 90000-90999 910-949 95000-97999 980-999",
+	// Belarus
+	"978-985": "00-39 400-599 6000-8999 90000-99999",
+	// France
+	"979-10": "00-19 200-699 7000-8999 90000-97599 976000-999999",
+	// Korea
+	"979-11": "00-24 250-549 5500-8499 85000-94999 950000-999999",
+}
@@ -0,0 +1,83 @@
+package gofb2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseISBN(t *testing.T) {
+	tests := []struct {
+		in, want, hyphenated, isbn10 string
+	}{
+		{"0-306-40615-2", "9780306406157", "978-0-306-40615-7", "0306406152"},
+		{"ISBN 080442957X", "9780804429573", "978-0-8044-2957-3", "080442957X"},
+		{"isbn-13: 978 5 699 12014 7", "9785699120147", "978-5-699-12014-7", "5699120149"},
+		{"9791032305690", "9791032305690", "979-10-323-0569-0", ""},
+		{"ISBN 1032123451", "9781032123455", "978-1-03-212345-5", "1032123451"},
+		{"ISBN1300000007", "9781300000006", "978-1-300-00000-6", "1300000007"},
+		{"ISBN-10 0-306-40615-2", "9780306406157", "978-0-306-40615-7", "0306406152"},
+		{"ISBN: 0-306-40615-2", "9780306406157", "978-0-306-40615-7", "0306406152"},
+		{"ISBN-13:9780306406157", "9780306406157", "978-0-306-40615-7", "0306406152"},
+	}
+	for _, tt := range tests {
+		isbn, err := ParseISBN(tt.in)
+		if err != nil {
+			t.Errorf("ParseISBN(%q): %v", tt.in, err)
+			continue
+		}
+		if string(isbn) != tt.want {
+			t.Errorf("ParseISBN(%q) = %s, want %s", tt.in, isbn, tt.want)
+		}
+		if isbn.String() != tt.hyphenated {
+			t.Errorf("%s hyphenated = %s, want %s", isbn, isbn, tt.hyphenated)
+		}
+		if s, ok := isbn.ISBN10(); s != tt.isbn10 || ok != (tt.isbn10 != "") {
+			t.Errorf("%s ISBN10 = %s %v, want %s", isbn, s, ok, tt.isbn10)
+		}
+	}
+	for _, s := range []string{"", "0-306-40615-3", "9780306406158", "1234567890123", "0-306-4061X-2", "ISBN 0-306"} {
+		if isbn, err := ParseISBN(s); err == nil {
+			t.Errorf("ParseISBN(%q) = %s", s, isbn)
+		}
+	}
+}
+
+func TestExtractISBNs(t *testing.T) {
+	got := ExtractISBNs("ISBN 1-56619-909-3 (hardcover), 978-1-56619-909-4; 0 306 40615 2, 0-306-40615-3")
+	var s []string
+	for _, isbn := range got {
+		s = append(s, string(isbn))
+	}
+	if want := "9781566199094 9780306406157"; strings.Join(s, " ") != want {
+		t.Errorf("ExtractISBNs = %v, want %s", s, want)
+	}
+}
+
+func TestLoadISBNRanges(t *testing.T) {
+	defer func() {
+		isbnRangesMu.Lock()
+		isbnRangesParsed = nil
+		isbnRangesMu.Unlock()
+	}()
+	err := LoadISBNRanges(strings.NewReader(`<ISBNRangeMessage><RegistrationGroups>
+<Group><Prefix>978-0</Prefix><Rules>
+<Rule><Range>0000000-1999999</Range><Length>2</Length></Rule>
+<Rule><Range>2000000-2279999</Range><Length>4</Length></Rule>
+<Rule><Range>2280000-6999999</Range><Length>0</Length></Rule>
+</Rules></Group>
+</RegistrationGroups></ISBNRangeMessage>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	isbn, _ := ParseISBN("0-306-40615-2")
+	if s, ok := isbn.Hyphenate(); ok {
+		t.Errorf("%s is hyphenated by unused range: %s", isbn, s)
+	}
+	isbn, _ = ParseISBN("0-2001-2345-9")
+	if s, _ := isbn.Hyphenate(); s != "978-0-2001-2345-7" {
+		t.Errorf("hyphenated = %s", s)
+	}
+	if err := LoadISBNRanges(strings.NewReader(`<ISBNRangeMessage/>`)); err == nil {
+		t.Error("no error for empty message")
+	}
+}
@@ -0,0 +1,539 @@
+package gofb2
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"unicode"
+)
+
+// JSON representation of the book.
+//
+// Every node is a JSON object with fields named after struct fields in
+// lowerCamelCase, zero values are omitted. A node that has a tag name has
+// "kind" field with it ("p", "subtitle", "emphasis", "section" and so on).
+// Mixed and block content is stored in "content" array, where raw text is
+// a string and any other node is an object with "kind" field, the kind
+// selects node type the same way the XML tag does. JSONSchema returns
+// JSON schema of the format.
+const jsonKindField = "kind"
+
+var (
+	contentBaseType = reflect.TypeOf(contentBase{})
+	baseNodeType    = reflect.TypeOf(baseNode{})
+	xmlNameType     = reflect.TypeOf(xml.Name{})
+)
+
+// jsonField is a struct field visible in JSON
+type jsonField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// jsonFields return fields of struct type t, embedded structs are flattened
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	var collect func(t reflect.Type, index []int)
+	collect = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			idx := append(append([]int{}, index...), i)
+			switch {
+			case f.Type == baseNodeType || f.Type == xmlNameType:
+			case f.Anonymous && f.Type.Kind() == reflect.Struct && f.Type != contentBaseType:
+				collect(f.Type, idx)
+			case f.Type == contentBaseType:
+				cf, _ := contentBaseType.FieldByName("Content")
+				fields = append(fields, jsonField{
+					name:  "content",
+					index: append(idx, cf.Index...),
+					typ:   cf.Type,
+				})
+			case f.PkgPath == "":
+				fields = append(fields, jsonField{name: lowerCamel(f.Name), index: idx, typ: f.Type})
+			}
+		}
+	}
+	collect(t, nil)
+	return fields
+}
+
+// lowerCamel convert field name like XlinkHref or ID to xlinkHref or id
+func lowerCamel(s string) string {
+	r := []rune(s)
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+func marshalJSON(n Node) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	first := true
+	write := func(key string, value []byte) {
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		k, _ := json.Marshal(key)
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	if kind := n.GetXMLName().Local; kind != "" {
+		k, _ := json.Marshal(kind)
+		write(jsonKindField, k)
+	}
+	v := reflect.ValueOf(n).Elem()
+	for _, f := range jsonFields(v.Type()) {
+		fv := v.FieldByIndex(f.index)
+		if fv.IsZero() {
+			continue
+		}
+		var (
+			data []byte
+			err  error
+		)
+		if f.name == "content" {
+			data, err = marshalContent(fv.Interface().([]Contenter))
+		} else {
+			data, err = json.Marshal(fv.Interface())
+		}
+		if err != nil {
+			return nil, err
+		}
+		write(f.name, data)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func marshalContent(cont []Contenter) ([]byte, error) {
+	items := make([]json.RawMessage, len(cont))
+	for i, c := range cont {
+		var (
+			data []byte
+			err  error
+		)
+		if cd, ok := c.(CharData); ok {
+			data, err = json.Marshal(string(cd))
+		} else {
+			data, err = json.Marshal(c)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items[i] = data
+	}
+	return json.Marshal(items)
+}
+
+func unmarshalJSON(data []byte, n Node) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if raw, ok := obj[jsonKindField]; ok {
+		var kind string
+		if err := json.Unmarshal(raw, &kind); err != nil {
+			return err
+		}
+		n.SetXMLName(xml.Name{Local: kind})
+	}
+	v := reflect.ValueOf(n).Elem()
+	for _, f := range jsonFields(v.Type()) {
+		raw, ok := obj[f.name]
+		if !ok {
+			continue
+		}
+		if f.name == "content" {
+			if err := unmarshalContent(raw, n); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := json.Unmarshal(raw, v.FieldByIndex(f.index).Addr().Interface()); err != nil {
+			return fmt.Errorf("%s: %s", f.name, err)
+		}
+	}
+	return nil
+}
+
+// unmarshalContent create content nodes the same way as parser does for tags
+func unmarshalContent(data []byte, parent Node) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		var text string
+		if json.Unmarshal(item, &text) == nil {
+			if err := parent.charDataCallback(xml.CharData(text)); err != nil {
+				return err
+			}
+			continue
+		}
+		var head struct {
+			Kind string `json:"kind"`
+		}
+		if err := json.Unmarshal(item, &head); err != nil {
+			return err
+		}
+		child, err := jsonChild(parent, head.Kind)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(item, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsonChild(parent Node, kind string) (Node, error) {
+	// image in content of section stays in content even at the top,
+	// section image is a separate field in JSON
+	if s, ok := parent.(*Section); ok && kind == "image" && len(s.Content) == 0 {
+		i := &Image{}
+		s.appendContent(i)
+		return i, nil
+	}
+	child, err := parent.tagCallback(xml.StartElement{Name: xml.Name{Local: kind}})
+	if err != nil {
+		return nil, err
+	}
+	child.SetXMLName(xml.Name{Local: kind})
+	return child, nil
+}
+
+// MarshalJSON marshal FictionBook to JSON
+func (f *FictionBook) MarshalJSON() ([]byte, error) { return marshalJSON(f) }
+
+// UnmarshalJSON unmarshal JSON to FictionBook
+func (f *FictionBook) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, f) }
+
+// MarshalJSON marshal Body to JSON
+func (b *Body) MarshalJSON() ([]byte, error) { return marshalJSON(b) }
+
+// UnmarshalJSON unmarshal JSON to Body
+func (b *Body) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, b) }
+
+// MarshalJSON marshal NotesBody to JSON
+func (b *NotesBody) MarshalJSON() ([]byte, error) { return marshalJSON(b) }
+
+// UnmarshalJSON unmarshal JSON to NotesBody
+func (b *NotesBody) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, b) }
+
+// MarshalJSON marshal Stylesheet to JSON
+func (s *Stylesheet) MarshalJSON() ([]byte, error) { return marshalJSON(s) }
+
+// UnmarshalJSON unmarshal JSON to Stylesheet
+func (s *Stylesheet) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, s) }
+
+// MarshalJSON marshal Description to JSON
+func (d *Description) MarshalJSON() ([]byte, error) { return marshalJSON(d) }
+
+// UnmarshalJSON unmarshal JSON to Description
+func (d *Description) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, d) }
+
+// MarshalJSON marshal DocumentInfo to JSON
+func (di *DocumentInfo) MarshalJSON() ([]byte, error) { return marshalJSON(di) }
+
+// UnmarshalJSON unmarshal JSON to DocumentInfo
+func (di *DocumentInfo) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, di) }
+
+// MarshalJSON marshal PublishInfo to JSON
+func (pi *PublishInfo) MarshalJSON() ([]byte, error) { return marshalJSON(pi) }
+
+// UnmarshalJSON unmarshal JSON to PublishInfo
+func (pi *PublishInfo) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, pi) }
+
+// MarshalJSON marshal CustomInfo to JSON
+func (ci *CustomInfo) MarshalJSON() ([]byte, error) { return marshalJSON(ci) }
+
+// UnmarshalJSON unmarshal JSON to CustomInfo
+func (ci *CustomInfo) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, ci) }
+
+// MarshalJSON marshal Binary to JSON, value is encoded to base64
+func (b *Binary) MarshalJSON() ([]byte, error) { return marshalJSON(b) }
+
+// UnmarshalJSON unmarshal JSON to Binary
+func (b *Binary) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, b) }
+
+// MarshalJSON marshal Author to JSON
+func (a *Author) MarshalJSON() ([]byte, error) { return marshalJSON(a) }
+
+// UnmarshalJSON unmarshal JSON to Author
+func (a *Author) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, a) }
+
+// MarshalJSON marshal TextField to JSON
+func (t *TextField) MarshalJSON() ([]byte, error) { return marshalJSON(t) }
+
+// UnmarshalJSON unmarshal JSON to TextField
+func (t *TextField) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, t) }
+
+// MarshalJSON marshal Date to JSON
+func (d *Date) MarshalJSON() ([]byte, error) { return marshalJSON(d) }
+
+// UnmarshalJSON unmarshal JSON to Date
+func (d *Date) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, d) }
+
+// MarshalJSON marshal Sequence to JSON
+func (s *Sequence) MarshalJSON() ([]byte, error) { return marshalJSON(s) }
+
+// UnmarshalJSON unmarshal JSON to Sequence
+func (s *Sequence) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, s) }
+
+// MarshalJSON marshal TitleInfo to JSON
+func (ti *TitleInfo) MarshalJSON() ([]byte, error) { return marshalJSON(ti) }
+
+// UnmarshalJSON unmarshal JSON to TitleInfo
+func (ti *TitleInfo) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, ti) }
+
+// MarshalJSON marshal Genre to JSON
+func (g *Genre) MarshalJSON() ([]byte, error) { return marshalJSON(g) }
+
+// UnmarshalJSON unmarshal JSON to Genre
+func (g *Genre) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, g) }
+
+// MarshalJSON marshal Coverpage to JSON
+func (c *Coverpage) MarshalJSON() ([]byte, error) { return marshalJSON(c) }
+
+// UnmarshalJSON unmarshal JSON to Coverpage
+func (c *Coverpage) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, c) }
+
+// MarshalJSON marshal ShareInstruction to JSON
+func (si *ShareInstruction) MarshalJSON() ([]byte, error) { return marshalJSON(si) }
+
+// UnmarshalJSON unmarshal JSON to ShareInstruction
+func (si *ShareInstruction) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, si) }
+
+// MarshalJSON marshal PartShareInstruction to JSON
+func (psi *PartShareInstruction) MarshalJSON() ([]byte, error) { return marshalJSON(psi) }
+
+// UnmarshalJSON unmarshal JSON to PartShareInstruction
+func (psi *PartShareInstruction) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, psi) }
+
+// MarshalJSON marshal OutPutDocument to JSON
+func (od *OutPutDocument) MarshalJSON() ([]byte, error) { return marshalJSON(od) }
+
+// UnmarshalJSON unmarshal JSON to OutPutDocument
+func (od *OutPutDocument) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, od) }
+
+// MarshalJSON marshal Title to JSON
+func (t *Title) MarshalJSON() ([]byte, error) { return marshalJSON(t) }
+
+// UnmarshalJSON unmarshal JSON to Title
+func (t *Title) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, t) }
+
+// MarshalJSON marshal Image to JSON
+func (i *Image) MarshalJSON() ([]byte, error) { return marshalJSON(i) }
+
+// UnmarshalJSON unmarshal JSON to Image
+func (i *Image) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, i) }
+
+// MarshalJSON marshal P to JSON
+func (p *P) MarshalJSON() ([]byte, error) { return marshalJSON(p) }
+
+// UnmarshalJSON unmarshal JSON to P
+func (p *P) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, p) }
+
+// MarshalJSON marshal Cite to JSON
+func (c *Cite) MarshalJSON() ([]byte, error) { return marshalJSON(c) }
+
+// UnmarshalJSON unmarshal JSON to Cite
+func (c *Cite) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, c) }
+
+// MarshalJSON marshal Poem to JSON
+func (p *Poem) MarshalJSON() ([]byte, error) { return marshalJSON(p) }
+
+// UnmarshalJSON unmarshal JSON to Poem
+func (p *Poem) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, p) }
+
+// MarshalJSON marshal Stanza to JSON
+func (s *Stanza) MarshalJSON() ([]byte, error) { return marshalJSON(s) }
+
+// UnmarshalJSON unmarshal JSON to Stanza
+func (s *Stanza) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, s) }
+
+// MarshalJSON marshal Epigraph to JSON
+func (ep *Epigraph) MarshalJSON() ([]byte, error) { return marshalJSON(ep) }
+
+// UnmarshalJSON unmarshal JSON to Epigraph
+func (ep *Epigraph) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, ep) }
+
+// MarshalJSON marshal Annotation to JSON
+func (a *Annotation) MarshalJSON() ([]byte, error) { return marshalJSON(a) }
+
+// UnmarshalJSON unmarshal JSON to Annotation
+func (a *Annotation) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, a) }
+
+// MarshalJSON marshal Section to JSON
+func (s *Section) MarshalJSON() ([]byte, error) { return marshalJSON(s) }
+
+// UnmarshalJSON unmarshal JSON to Section
+func (s *Section) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, s) }
+
+// MarshalJSON marshal StyleType to JSON
+func (s *StyleType) MarshalJSON() ([]byte, error) { return marshalJSON(s) }
+
+// UnmarshalJSON unmarshal JSON to StyleType
+func (s *StyleType) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, s) }
+
+// MarshalJSON marshal NamedStyleType to JSON
+func (s *NamedStyleType) MarshalJSON() ([]byte, error) { return marshalJSON(s) }
+
+// UnmarshalJSON unmarshal JSON to NamedStyleType
+func (s *NamedStyleType) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, s) }
+
+// MarshalJSON marshal Link to JSON
+func (l *Link) MarshalJSON() ([]byte, error) { return marshalJSON(l) }
+
+// UnmarshalJSON unmarshal JSON to Link
+func (l *Link) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, l) }
+
+// MarshalJSON marshal StyleLinkType to JSON
+func (s *StyleLinkType) MarshalJSON() ([]byte, error) { return marshalJSON(s) }
+
+// UnmarshalJSON unmarshal JSON to StyleLinkType
+func (s *StyleLinkType) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, s) }
+
+// MarshalJSON marshal Table to JSON
+func (t *Table) MarshalJSON() ([]byte, error) { return marshalJSON(t) }
+
+// UnmarshalJSON unmarshal JSON to Table
+func (t *Table) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, t) }
+
+// MarshalJSON marshal TR to JSON
+func (t *TR) MarshalJSON() ([]byte, error) { return marshalJSON(t) }
+
+// UnmarshalJSON unmarshal JSON to TR
+func (t *TR) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, t) }
+
+// MarshalJSON marshal TD to JSON
+func (t *TD) MarshalJSON() ([]byte, error) { return marshalJSON(t) }
+
+// UnmarshalJSON unmarshal JSON to TD
+func (t *TD) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, t) }
+
+// MarshalJSON marshal InlineImage to JSON
+func (i *InlineImage) MarshalJSON() ([]byte, error) { return marshalJSON(i) }
+
+// UnmarshalJSON unmarshal JSON to InlineImage
+func (i *InlineImage) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, i) }
+
+// MarshalJSON marshal EmptyLine to JSON
+func (e *EmptyLine) MarshalJSON() ([]byte, error) { return marshalJSON(e) }
+
+// UnmarshalJSON unmarshal JSON to EmptyLine
+func (e *EmptyLine) UnmarshalJSON(data []byte) error { return unmarshalJSON(data, e) }
+
+// jsonKinds is a list of tags that can be used as content kind
+var jsonKinds = []string{
+	"p", "subtitle", "v", "text-author", "title", "epigraph", "image",
+	"annotation", "section", "poem", "stanza", "cite", "empty-line",
+	"table", "tr", "th", "td", "strong", "emphasis", "style", "a",
+	"strikethrough", "sub", "sup", "code",
+}
+
+var nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+
+// JSONSchema return JSON schema (draft-07) of FictionBook JSON representation
+func JSONSchema() ([]byte, error) {
+	defs := map[string]interface{}{}
+	root := jsonSchemaFor(reflect.TypeOf(FictionBook{}), defs)
+	root["$schema"] = "http://json-schema.org/draft-07/schema#"
+	root["title"] = "FictionBook"
+	root["definitions"] = defs
+	return json.MarshalIndent(root, "", "  ")
+}
+
+func jsonSchemaFor(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(XMLDate{}):
+		// year, month, day or date and time
+		return map[string]interface{}{"type": "string", "pattern": `^\d{4}(-\d{2}(-\d{2}.*)?)?$`}
+	case t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(nodeType):
+		ref := map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+		if _, ok := defs[t.Name()]; ok {
+			return ref
+		}
+		// reserve name before fields to stop recursion
+		defs[t.Name()] = nil
+		props := map[string]interface{}{
+			jsonKindField: map[string]interface{}{"type": "string"},
+		}
+		for _, f := range jsonFields(t) {
+			if f.name == "content" {
+				props[f.name] = jsonContentSchema(t, defs)
+			} else {
+				props[f.name] = jsonSchemaFor(f.typ, defs)
+			}
+		}
+		defs[t.Name()] = map[string]interface{}{
+			"type":                 "object",
+			"properties":           props,
+			"additionalProperties": false,
+		}
+		return ref
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+	case t.Kind() == reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": jsonSchemaFor(t.Elem(), defs)}
+	case t.Kind() == reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{"type": "integer"}
+	}
+}
+
+// jsonContentSchema return schema of content array of type t.
+// Allowed kinds are found by asking a new node for every known tag.
+func jsonContentSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	items := []interface{}{}
+	if jsonMixed(t) {
+		items = append(items, map[string]interface{}{"type": "string"})
+	}
+	for _, kind := range jsonKinds {
+		n := reflect.New(t).Interface().(Node)
+		child, err := jsonChild(n, kind)
+		if err != nil || len(n.(Contenter).GetContent()) == 0 {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			"allOf": []interface{}{
+				jsonSchemaFor(reflect.TypeOf(child), defs),
+				map[string]interface{}{
+					"properties": map[string]interface{}{
+						jsonKindField: map[string]interface{}{"const": kind},
+					},
+					"required": []string{jsonKindField},
+				},
+			},
+		})
+	}
+	return map[string]interface{}{"type": "array", "items": map[string]interface{}{"oneOf": items}}
+}
+
+// jsonMixed check if content of type t can contain raw text
+func jsonMixed(t reflect.Type) bool {
+	n := reflect.New(t).Interface().(Node)
+	if err := n.charDataCallback(xml.CharData("text")); err != nil {
+		return false
+	}
+	return len(n.(Contenter).GetContent()) > 0
+}
@@ -0,0 +1,168 @@
+package gofb2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	src := readBook(t, "sample.fb2")
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fb := &FictionBook{}
+	if err := json.Unmarshal(data, fb); err != nil {
+		t.Fatal(err)
+	}
+	again, err := json.Marshal(fb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("JSON differ after round trip:\n%s\n%s", data, again)
+	}
+	if changes := Diff(src, fb); len(changes) != 0 {
+		t.Errorf("changes after round trip: %v", changes)
+	}
+	if contentText(bookContent(src)) != contentText(bookContent(fb)) {
+		t.Error("text differ after round trip")
+	}
+}
+
+func TestJSONSectionImage(t *testing.T) {
+	fb := parseBook(t, `<FictionBook xmlns:l="http://www.w3.org/1999/xlink"><body>
+<section><image l:href="#a"/><p>text</p><image l:href="#b"/></section>
+</body></FictionBook>`)
+	data, err := json.Marshal(fb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := &FictionBook{}
+	if err := json.Unmarshal(data, res); err != nil {
+		t.Fatal(err)
+	}
+	s := res.Body.Sections[0]
+	if s.Image == nil || s.Image.XlinkHref != "#a" || len(s.Content) != 2 {
+		t.Errorf("section image is moved: %s", data)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	book, err := json.Marshal(readBook(t, "sample.fb2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(book, &v); err != nil {
+		t.Fatal(err)
+	}
+	defs := schema["definitions"].(map[string]interface{})
+	if err := validateJSON(schema, defs, v, ""); err != nil {
+		t.Error(err)
+	}
+	var bad interface{}
+	json.Unmarshal([]byte(`{"body":{"sections":[{"content":[{"kind":"section"}]}]}}`), &bad)
+	if err := validateJSON(schema, defs, bad, ""); err == nil {
+		t.Error("section in content of section is valid")
+	}
+}
+
+// validateJSON check value against subset of JSON schema used by JSONSchema
+func validateJSON(schema, defs map[string]interface{}, v interface{}, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		return validateJSON(defs[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{}), defs, v, path)
+	}
+	if c, ok := schema["const"]; ok && c != v {
+		return fmt.Errorf("%s: %v is not %v", path, v, c)
+	}
+	for _, s := range list(schema["allOf"]) {
+		if err := validateJSON(s.(map[string]interface{}), defs, v, path); err != nil {
+			return err
+		}
+	}
+	if oneOf := list(schema["oneOf"]); len(oneOf) > 0 {
+		n := 0
+		for _, s := range oneOf {
+			if validateJSON(s.(map[string]interface{}), defs, v, path) == nil {
+				n++
+			}
+		}
+		if n != 1 {
+			return fmt.Errorf("%s: %d of oneOf schemas match", path, n)
+		}
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: not an object", path)
+		}
+		for _, r := range list(schema["required"]) {
+			if _, ok := m[r.(string)]; !ok {
+				return fmt.Errorf("%s: no %s", path, r)
+			}
+		}
+		for k, child := range m {
+			p, ok := props[k].(map[string]interface{})
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unknown property %s", path, k)
+				}
+				continue
+			}
+			if err := validateJSON(p, defs, child, path+"/"+k); err != nil {
+				return err
+			}
+		}
+	}
+	switch typ, _ := schema["type"].(string); typ {
+	case "object":
+		if _, ok := v.(map[string]interface{}); !ok {
+			return fmt.Errorf("%s: not an object", path)
+		}
+	case "array":
+		a, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: not an array", path)
+		}
+		for i, item := range a {
+			if err := validateJSON(schema["items"].(map[string]interface{}), defs, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: not a string", path)
+		}
+		if p, ok := schema["pattern"].(string); ok && !regexp.MustCompile(p).MatchString(s) {
+			return fmt.Errorf("%s: %q does not match %s", path, s, p)
+		}
+	case "number", "integer":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s: not a number", path)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: not a boolean", path)
+		}
+	}
+	return nil
+}
+
+func list(v interface{}) []interface{} {
+	a, _ := v.([]interface{})
+	return a
+}
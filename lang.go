@@ -0,0 +1,162 @@
+package gofb2
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LangSpan is a fragment of book in one language
+type LangSpan struct {
+	Lang string
+	// Node is a root of fragment: body, annotation or node with xml:lang
+	// different from language of its parent
+	Node Node
+	// Chars is a count of non-space characters of fragment,
+	// nested fragments are not counted
+	Chars int
+}
+
+// nodeLang return xml:lang of node or empty string
+func nodeLang(n Node) string {
+	switch e := n.(type) {
+	case *Body:
+		return e.Lang
+	case *NotesBody:
+		return e.Lang
+	case *Section:
+		return e.Lang
+	case *Title:
+		return e.Lang
+	case *Cite:
+		return e.Lang
+	case *Stanza:
+		return e.Lang
+	case *Annotation:
+		return e.Lang
+	case *StyleType:
+		return e.Lang
+	case *P:
+		return e.Lang
+	case *TD:
+		return e.Lang
+	case *NamedStyleType:
+		return e.Lang
+	case *TextField:
+		return e.Lang
+	case *Date:
+		return e.Lang
+	}
+	return ""
+}
+
+func inheritLang(lang, parent string) string {
+	if lang != "" {
+		return lang
+	}
+	return parent
+}
+
+// baseLang return primary subtag of language tag like "en-US" in lower case
+func baseLang(lang string) string {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// bookLang return language of book from title-info
+func bookLang(fb *FictionBook) string {
+	if d := fb.Description; d != nil && d.TitleInfo != nil {
+		return d.TitleInfo.Lang
+	}
+	return ""
+}
+
+// Lang return effective language of node: xml:lang of node or of its
+// nearest ancestor, for nodes without it language of book is returned
+func (t *Tree) Lang(n Contenter) string {
+	node, _ := n.(Node)
+	for node != nil {
+		if lang := nodeLang(node); lang != "" {
+			return lang
+		}
+		node = t.parents[node]
+	}
+	return bookLang(t.fb)
+}
+
+// walkLang traverse annotations and bodies of book in depth-first order
+// passing effective language of nodes to fn, children are skipped
+// when fn return false
+func walkLang(fb *FictionBook, fn func(c Contenter, lang string) bool) {
+	var visit func(cont []Contenter, lang string)
+	visit = func(cont []Contenter, lang string) {
+		for _, c := range cont {
+			l := lang
+			if n, ok := c.(Node); ok {
+				l = inheritLang(nodeLang(n), lang)
+			}
+			if fn(c, l) {
+				visit(children(c), l)
+			}
+		}
+	}
+	lang := bookLang(fb)
+	if fb.Description != nil {
+		visit(descriptionContent(fb.Description), lang)
+	}
+	if fb.Body != nil {
+		visit(bodyChildren(fb.Body), inheritLang(fb.Body.Lang, lang))
+	}
+	if fb.NotesBody != nil {
+		visit(bodyChildren(&fb.NotesBody.Body), inheritLang(fb.NotesBody.Lang, lang))
+	}
+}
+
+// LangSpans return fragments of book in document order, every body and
+// annotation starts a fragment, nodes with other language start
+// nested fragments
+func LangSpans(fb *FictionBook) []LangSpan {
+	s := &langSpans{}
+	lang := bookLang(fb)
+	if fb.Description != nil {
+		for _, c := range descriptionContent(fb.Description) {
+			s.node(c.(Node), children(c), lang, -1)
+		}
+	}
+	if fb.Body != nil {
+		s.node(fb.Body, bodyChildren(fb.Body), lang, -1)
+	}
+	if fb.NotesBody != nil {
+		s.node(fb.NotesBody, bodyChildren(&fb.NotesBody.Body), lang, -1)
+	}
+	return s.spans
+}
+
+type langSpans struct {
+	spans []LangSpan
+}
+
+// node add characters of node content to span or start new span
+// when language is changed
+func (s *langSpans) node(n Node, cont []Contenter, parentLang string, span int) {
+	lang := inheritLang(nodeLang(n), parentLang)
+	if span < 0 || lang != parentLang {
+		s.spans = append(s.spans, LangSpan{Lang: lang, Node: n})
+		span = len(s.spans) - 1
+	}
+	for _, c := range cont {
+		if cd, ok := c.(CharData); ok {
+			for _, r := range string(cd) {
+				if !unicode.IsSpace(r) {
+					s.spans[span].Chars++
+				}
+			}
+			continue
+		}
+		if child, ok := c.(Node); ok {
+			s.node(child, children(c), lang, span)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package gofb2
+
+import "testing"
+
+const langBook = `<FictionBook>
+<description><title-info><annotation><p>аннотация</p></annotation><lang>ru</lang></title-info></description>
+<body><section><p>один два</p>
+<section xml:lang="en"><title><p>Title</p></title><p>one <emphasis>two</emphasis></p><p xml:lang="de">drei</p></section>
+<p>три</p></section></body>
+<body name="notes" xml:lang="en"><section id="n1"><p>note</p></section></body>
+</FictionBook>`
+
+func TestTreeLang(t *testing.T) {
+	fb := parseBook(t, langBook)
+	tree := NewTree(fb)
+	s := fb.Body.Sections[0]
+	sub := s.Sections[0]
+	note := fb.NotesBody.Sections[0]
+	tests := []struct {
+		n    Contenter
+		want string
+	}{
+		{fb.Description.TitleInfo.Annotation.Content[0], "ru"},
+		{s.Content[0], "ru"},
+		{sub.Title.Content[0], "en"},
+		{sub.Content[0], "en"},
+		{sub.Content[0].(*P).Content[1], "en"},
+		{sub.Content[1], "de"},
+		{s.Content[1], "ru"},
+		{note.Content[0], "en"},
+	}
+	for i, tt := range tests {
+		if got := tree.Lang(tt.n); got != tt.want {
+			t.Errorf("node %d has language %q, want %q", i, got, tt.want)
+		}
+	}
+}
+
+func TestLangSpans(t *testing.T) {
+	fb := parseBook(t, langBook)
+	want := []struct {
+		lang  string
+		chars int
+	}{
+		{"ru", 9},  // annotation
+		{"ru", 10}, // body without subsection
+		{"en", 11}, // subsection without p in de
+		{"de", 4},
+		{"en", 4}, // notes
+	}
+	spans := LangSpans(fb)
+	if len(spans) != len(want) {
+		t.Fatalf("got %d spans, want %d", len(spans), len(want))
+	}
+	for i, s := range spans {
+		if s.Lang != want[i].lang || s.Chars != want[i].chars {
+			t.Errorf("span %d is %s with %d chars, want %s with %d chars", i, s.Lang, s.Chars, want[i].lang, want[i].chars)
+		}
+	}
+	if spans[2].Node != fb.Body.Sections[0].Sections[0] {
+		t.Errorf("span of subsection starts at %T", spans[2].Node)
+	}
+}
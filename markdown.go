@@ -0,0 +1,467 @@
+package gofb2
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var markdownInline = map[string][2]string{
+	"strong":        {"**", "**"},
+	"emphasis":      {"*", "*"},
+	"strikethrough": {"~~", "~~"},
+	"sub":           {"<sub>", "</sub>"},
+	"sup":           {"<sup>", "</sup>"},
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	"|", `\|`,
+)
+
+// WriteMarkdown write book to w as GitHub flavored markdown.
+// Sections become headings by depth, footnotes from NotesBody are
+// written as [^n] references with definitions at the end.
+func WriteMarkdown(w io.Writer, fb *FictionBook) error {
+	if fb == nil {
+		return fmt.Errorf("no book to write")
+	}
+	m := &mdWriter{w: w, notes: newFootnotes(fb)}
+	if fb.Body != nil {
+		if fb.Body.Title != nil {
+			m.heading(fb.Body.Title, 1)
+		} else if d := fb.Description; d != nil && d.TitleInfo != nil && d.TitleInfo.BookTitle != nil {
+			m.separate()
+			m.line("# " + markdownEscape(collapseSpace(d.TitleInfo.BookTitle.Value)))
+		}
+		if fb.Body.Image != nil {
+			m.image(fb.Body.Image)
+		}
+		for _, ep := range fb.Body.Epigraphs {
+			m.quote(ep.Content, ep.TextAuthor)
+		}
+		for _, s := range fb.Body.Sections {
+			m.section(s, 2)
+		}
+	}
+	m.footnotes()
+	return m.err
+}
+
+type mdWriter struct {
+	w       io.Writer
+	notes   *footnotes
+	prefix  string
+	started bool
+	pending bool
+	err     error
+}
+
+func (m *mdWriter) line(s string) {
+	if m.err != nil {
+		return
+	}
+	m.flush()
+	m.write(strings.TrimRight(m.prefix+s, " "))
+	m.started = true
+}
+
+// flush write requested empty line
+func (m *mdWriter) flush() {
+	if m.pending {
+		m.pending = false
+		m.write(strings.TrimRight(m.prefix, " "))
+	}
+}
+
+func (m *mdWriter) write(s string) {
+	if m.err == nil {
+		_, m.err = io.WriteString(m.w, s+"\n")
+	}
+}
+
+// separate request empty line before next block
+func (m *mdWriter) separate() {
+	m.pending = m.started
+}
+
+func (m *mdWriter) indent(prefix string) func() {
+	old := m.prefix
+	m.prefix += prefix
+	return func() { m.prefix = old }
+}
+
+func (m *mdWriter) heading(t *Title, level int) {
+	if level > 6 {
+		level = 6
+	}
+	var parts []string
+	for _, c := range t.Content {
+		if p, ok := c.(*P); ok {
+			if s := m.inline(p.Content); s != "" {
+				parts = append(parts, s)
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return
+	}
+	m.separate()
+	m.line(strings.Repeat("#", level) + " " + strings.Join(parts, " "))
+	m.separate()
+}
+
+func (m *mdWriter) section(s *Section, level int) {
+	if s.Title != nil {
+		m.heading(s.Title, level)
+	}
+	for _, ep := range s.Epigraphs {
+		m.quote(ep.Content, ep.TextAuthor)
+	}
+	if s.Image != nil {
+		m.image(s.Image)
+	}
+	if s.Annotation != nil {
+		m.quote(s.Annotation.Content, nil)
+	}
+	for _, cs := range s.Sections {
+		m.section(cs, level+1)
+	}
+	m.blocks(s.Content, level)
+}
+
+func (m *mdWriter) blocks(cont []Contenter, level int) {
+	for _, c := range cont {
+		switch e := c.(type) {
+		case *P:
+			if e.GetXMLName().Local == "subtitle" {
+				m.paragraph("**" + m.inline(e.Content) + "**")
+			} else {
+				m.paragraph(markdownBlockEscape(m.inline(e.Content)))
+			}
+		case *EmptyLine:
+			m.separate()
+		case *Image:
+			m.image(e)
+		case *Poem:
+			m.poem(e)
+		case *Cite:
+			m.quote(e.Content, e.TextAuthor)
+		case *Table:
+			m.table(e)
+		case *Section:
+			m.section(e, level+1)
+		default:
+			m.blocks(c.GetContent(), level)
+		}
+	}
+}
+
+func (m *mdWriter) paragraph(s string) {
+	if strings.Trim(s, "*") == "" {
+		return
+	}
+	m.separate()
+	m.line(s)
+	m.separate()
+}
+
+func (m *mdWriter) quote(cont []Contenter, authors []*P) {
+	m.separate()
+	m.flush()
+	restore := m.indent("> ")
+	m.started = false
+	m.blocks(cont, 6)
+	for _, a := range authors {
+		m.paragraph("— *" + m.inline(a.Content) + "*")
+	}
+	restore()
+	m.separate()
+}
+
+func (m *mdWriter) poem(p *Poem) {
+	if p.Title != nil {
+		for _, c := range p.Title.Content {
+			if tp, ok := c.(*P); ok {
+				m.paragraph("**" + m.inline(tp.Content) + "**")
+			}
+		}
+	}
+	for _, ep := range p.Epigraphs {
+		m.quote(ep.Content, ep.TextAuthor)
+	}
+	for _, c := range p.Content {
+		switch e := c.(type) {
+		case *Stanza:
+			var lines []string
+			if e.Title != nil {
+				for _, tc := range e.Title.Content {
+					if tp, ok := tc.(*P); ok {
+						lines = append(lines, "**"+m.inline(tp.Content)+"**")
+					}
+				}
+			}
+			if e.Subtitle != nil {
+				lines = append(lines, "*"+m.inline(e.Subtitle.Content)+"*")
+			}
+			for _, v := range e.V {
+				lines = append(lines, markdownBlockEscape(m.inline(v.Content)))
+			}
+			if len(lines) == 0 {
+				continue
+			}
+			m.separate()
+			for i, l := range lines {
+				if i < len(lines)-1 {
+					l += `\`
+				}
+				m.line(l)
+			}
+			m.separate()
+		case *P:
+			m.paragraph("**" + m.inline(e.Content) + "**")
+		}
+	}
+}
+
+func (m *mdWriter) image(i *Image) {
+	m.paragraph(fmt.Sprintf("![%s](%s)", markdownEscape(i.Alt), markdownImageURL(i.XlinkHref)))
+}
+
+func markdownImageURL(href string) string {
+	if id, ok := localID(href); ok {
+		return markdownURL(id)
+	}
+	return markdownURL(href)
+}
+
+var markdownAngleEscaper = strings.NewReplacer("<", "%3C", ">", "%3E")
+
+// markdownURL return link destination, URL with spaces or parentheses
+// is enclosed in angle brackets
+func markdownURL(href string) string {
+	if strings.ContainsAny(href, " \t\n()<>") {
+		return "<" + markdownAngleEscaper.Replace(strings.Join(strings.Fields(href), "%20")) + ">"
+	}
+	return href
+}
+
+func (m *mdWriter) table(t *Table) {
+	var rows [][]string
+	var aligns []string
+	cols := 0
+	occupied := map[int]int{}
+	for _, tr := range t.TR {
+		var row []string
+		next := map[int]int{}
+		for _, c := range tr.Content {
+			td, ok := c.(*TD)
+			if !ok {
+				continue
+			}
+			for occupied[len(row)] > 0 {
+				row = append(row, "")
+			}
+			span := 1
+			if td.Colspan > 1 {
+				span = td.Colspan
+			}
+			if td.Rowspan > 1 {
+				for i := len(row); i < len(row)+span; i++ {
+					next[i] = td.Rowspan - 1
+				}
+			}
+			align := td.Align
+			if align == "" {
+				align = tr.Align
+			}
+			for len(aligns) <= len(row) {
+				aligns = append(aligns, "")
+			}
+			if aligns[len(row)] == "" {
+				aligns[len(row)] = align
+			}
+			row = append(row, m.inline(td.Content))
+			for i := 1; i < span; i++ {
+				row = append(row, "")
+			}
+		}
+		for c, n := range occupied {
+			if n > 1 {
+				next[c] = n - 1
+			}
+		}
+		occupied = next
+		if len(row) > cols {
+			cols = len(row)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 || cols == 0 {
+		return
+	}
+	m.separate()
+	for i, row := range rows {
+		for len(row) < cols {
+			row = append(row, "")
+		}
+		m.line("| " + strings.Join(row, " | ") + " |")
+		if i == 0 {
+			sep := make([]string, cols)
+			for j := range sep {
+				a := ""
+				if j < len(aligns) {
+					a = aligns[j]
+				}
+				switch a {
+				case "right":
+					sep[j] = "---:"
+				case "center":
+					sep[j] = ":---:"
+				default:
+					sep[j] = "---"
+				}
+			}
+			m.line("| " + strings.Join(sep, " | ") + " |")
+		}
+	}
+	m.separate()
+}
+
+// inline return markdown for inline content with collapsed whitespace
+func (m *mdWriter) inline(cont []Contenter) string {
+	var b strings.Builder
+	m.inlineTo(&b, cont)
+	return collapseSpace(b.String())
+}
+
+func (m *mdWriter) inlineTo(b *strings.Builder, cont []Contenter) {
+	for _, c := range cont {
+		switch e := c.(type) {
+		case CharData:
+			b.WriteString(markdownEscape(string(e)))
+		case *Link:
+			if n, ok := m.notes.number(e.XlinkHref); ok {
+				fmt.Fprintf(b, "[^%d]", n)
+			} else {
+				b.WriteString("[" + m.inline(e.Content) + "](" + markdownURL(e.XlinkHref) + ")")
+			}
+		case *InlineImage:
+			fmt.Fprintf(b, "![%s](%s)", markdownEscape(e.Alt), markdownImageURL(e.XlinkHref))
+		default:
+			name := c.GetXMLName().Local
+			if name == "code" {
+				b.WriteString(markdownCode(contentText(c.GetContent()), rawText(c.GetContent())))
+			} else if marks, ok := markdownInline[name]; ok {
+				b.WriteString(markdownWrap(marks[0], marks[1], m.inlineRaw(c.GetContent())))
+			} else {
+				m.inlineTo(b, c.GetContent())
+			}
+		}
+	}
+}
+
+// inlineRaw return markdown for inline content keeping edge whitespace
+func (m *mdWriter) inlineRaw(cont []Contenter) string {
+	var b strings.Builder
+	m.inlineTo(&b, cont)
+	return b.String()
+}
+
+// markdownWrap put markers around trimmed s and keep edge whitespace outside
+func markdownWrap(open, close, s string) string {
+	trimmed := strings.TrimFunc(s, isXMLSpace)
+	if trimmed == "" {
+		return s
+	}
+	start := strings.Index(s, trimmed)
+	return s[:start] + open + trimmed + close + s[start+len(trimmed):]
+}
+
+func markdownCode(text, raw string) string {
+	if text == "" {
+		return raw
+	}
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		text = " " + text + " "
+	}
+	lead := raw[:len(raw)-len(strings.TrimLeftFunc(raw, isXMLSpace))]
+	trail := raw[len(strings.TrimRightFunc(raw, isXMLSpace)):]
+	return lead + fence + text + fence + trail
+}
+
+// rawText return text of inline nodes as is
+func rawText(cont []Contenter) string {
+	var b strings.Builder
+	for _, c := range cont {
+		if cd, ok := c.(CharData); ok {
+			b.Write(cd)
+		} else {
+			b.WriteString(rawText(c.GetContent()))
+		}
+	}
+	return b.String()
+}
+
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// markdownBlockEscape escape characters at line start that would start a block
+func markdownBlockEscape(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '#', '>', '-', '+', '=':
+		return `\` + s
+	}
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i > 0 && i < len(s) && (s[i] == '.' || s[i] == ')') {
+		return s[:i] + `\` + s[i:]
+	}
+	return s
+}
+
+func (m *mdWriter) footnotes() {
+	for _, id := range m.notes.list() {
+		first := true
+		var paragraphs []string
+		walk(m.notes.content(id), func(c Contenter) bool {
+			if p, ok := c.(*P); ok {
+				if s := m.inline(p.Content); s != "" {
+					paragraphs = append(paragraphs, s)
+				}
+				return false
+			}
+			return true
+		})
+		m.separate()
+		if len(paragraphs) == 0 {
+			m.line(fmt.Sprintf("[^%d]:", m.notes.numbers[id]))
+			continue
+		}
+		for _, p := range paragraphs {
+			if first {
+				m.line(fmt.Sprintf("[^%d]: %s", m.notes.numbers[id], p))
+				first = false
+			} else {
+				m.line("")
+				m.line("    " + p)
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package gofb2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownLinks(t *testing.T) {
+	fb := parseBook(t, `<FictionBook xmlns:l="http://www.w3.org/1999/xlink"><body><section>
+<p><a l:href="http://example.com/a b">space</a> <a l:href="http://example.com/x_(y)">paren</a> <a l:href="http://example.com/">plain</a></p>
+</section></body></FictionBook>`)
+	var b strings.Builder
+	if err := WriteMarkdown(&b, fb); err != nil {
+		t.Fatal(err)
+	}
+	for _, link := range []string{
+		"[space](<http://example.com/a%20b>)",
+		"[paren](<http://example.com/x_(y)>)",
+		"[plain](http://example.com/)",
+	} {
+		if !strings.Contains(b.String(), link) {
+			t.Errorf("no %s in %s", link, b.String())
+		}
+	}
+	if err := WriteMarkdown(&b, nil); err == nil {
+		t.Error("no error for nil book")
+	}
+}
@@ -0,0 +1,598 @@
+package gofb2
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Conflict is a part of book changed differently in both versions
+type Conflict struct {
+	Path string
+	// Node is a cite with both versions inserted into merged book, it is nil
+	// for conflicts of description and binaries, our version is kept for them
+	Node *Cite
+	// Ours and Theirs are texts or values of conflicting versions
+	Ours, Theirs string
+}
+
+// Conflict markers are subtitles of conflict cite
+const (
+	conflictOurs   = "<<<<<<< ours"
+	conflictSep    = "======="
+	conflictTheirs = ">>>>>>> theirs"
+)
+
+// Merge3 merge changes made in ours and theirs versions of base book.
+// Fields of description, sections, paragraphs and binaries changed only
+// in one version are taken from it, order of sections follows ours.
+// Paragraphs changed in both versions are replaced by a cite with
+// both versions between conflict markers. Version of document is
+// increased and merge is recorded in history.
+func Merge3(base, ours, theirs *FictionBook) (*FictionBook, []Conflict, error) {
+	c, err := cloneNode(ours)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &merger3{res: c.(*FictionBook), ids: map[string]bool{}}
+	walk(bookContent(m.res), func(c Contenter) bool {
+		if id := nodeID(c); id != "" {
+			m.ids[id] = true
+		}
+		return true
+	})
+
+	m.description(base, ours, theirs)
+	if m.res.Body == nil && base.Body == nil && theirs.Body != nil {
+		m.res.Body = m.clone(theirs.Body).(*Body)
+	} else if m.res.Body != nil {
+		m.sections("body", mainSections(base), mainSections(theirs), &m.res.Body.Sections)
+	}
+	if m.res.NotesBody == nil && base.NotesBody == nil && theirs.NotesBody != nil {
+		m.res.NotesBody = m.clone(theirs.NotesBody).(*NotesBody)
+	} else if m.res.NotesBody != nil {
+		m.sections("notes", noteSections(base), noteSections(theirs), &m.res.NotesBody.Sections)
+	}
+	m.binaries(base.Binary, theirs.Binary)
+	m.history(ours, theirs)
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return m.res, m.conflicts, nil
+}
+
+type merger3 struct {
+	// res is a merged book, it is a copy of ours with changes of theirs
+	res       *FictionBook
+	ids       map[string]bool
+	conflicts []Conflict
+	// conflictSections are first subsections added for conflicts of
+	// sections with subsections
+	conflictSections map[*Section]*Section
+	err              error
+}
+
+func (m *merger3) clone(n Node) Node {
+	c, err := cloneNode(n)
+	if err != nil {
+		if m.err == nil {
+			m.err = err
+		}
+		return n
+	}
+	return c
+}
+
+// cloneValue return deep copy of field value
+func (m *merger3) cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if n, ok := v.Interface().(Node); ok && !v.IsNil() {
+			return reflect.ValueOf(m.clone(n))
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(m.cloneValue(v.Index(i)))
+		}
+		return res
+	}
+	return v
+}
+
+// nodeKey return JSON of value used to compare versions
+func nodeKey(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// field merge value of field, res is a field of merged book that has
+// our value. It return false when field is changed in both versions.
+func (m *merger3) field(res, base, theirs reflect.Value) bool {
+	kb, ko, kt := nodeKey(base.Interface()), nodeKey(res.Interface()), nodeKey(theirs.Interface())
+	switch {
+	case ko == kb && kt != kb:
+		res.Set(m.cloneValue(theirs))
+	case kt != kb && ko != kt:
+		return false
+	}
+	return true
+}
+
+func (m *merger3) description(base, ours, theirs *FictionBook) {
+	res := reflect.ValueOf(m.res).Elem().FieldByName("Description")
+	b := reflect.ValueOf(base.Description)
+	t := reflect.ValueOf(theirs.Description)
+	if base.Description == nil || theirs.Description == nil || m.res.Description == nil {
+		m.descriptionField("description", res, b, t)
+		return
+	}
+	m.fields("description", res.Elem(), b.Elem(), t.Elem())
+}
+
+// fields merge exported fields of structs, parts of description
+// existing in all versions are merged by fields
+func (m *merger3) fields(path string, res, base, theirs reflect.Value) {
+	for i := 0; i < res.NumField(); i++ {
+		f := res.Type().Field(i)
+		if f.PkgPath != "" || f.Anonymous {
+			continue
+		}
+		switch f.Name {
+		case "Version", "History":
+			// set on merge
+			continue
+		}
+		name := path + "/" + kebabCase(f.Name)
+		rf, bf, tf := res.Field(i), base.Field(i), theirs.Field(i)
+		switch f.Type {
+		case reflect.TypeOf((*TitleInfo)(nil)), reflect.TypeOf((*DocumentInfo)(nil)), reflect.TypeOf((*PublishInfo)(nil)):
+			if !rf.IsNil() && !bf.IsNil() && !tf.IsNil() {
+				m.fields(name, rf.Elem(), bf.Elem(), tf.Elem())
+				continue
+			}
+		}
+		m.descriptionField(name, rf, bf, tf)
+	}
+}
+
+func (m *merger3) descriptionField(path string, res, base, theirs reflect.Value) {
+	ours := valueText(res)
+	if !m.field(res, base, theirs) {
+		m.conflicts = append(m.conflicts, Conflict{Path: path, Ours: ours, Theirs: valueText(theirs)})
+	}
+}
+
+// valueText return string or JSON of value
+func valueText(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return nodeKey(v.Interface())
+}
+
+// sections merge sections of body, res is a list of sections of merged body
+func (m *merger3) sections(root string, base, theirs []*Section, res *[]*Section) {
+	fb, fr, ft := flattenSections(root, base), flattenSections(root, *res), flattenSections(root, theirs)
+	mr, mt := matchSections(fb, fr), matchSections(fb, ft)
+	// sections of theirs matched with base or copied to result
+	inResult := map[*diffSection]*Section{}
+	byNode := map[*Section]*diffSection{}
+	for _, t := range ft {
+		byNode[t.s] = t
+	}
+	for _, b := range fb {
+		r, inR := mr[b]
+		t, inT := mt[b]
+		switch {
+		case inR && inT:
+			inResult[t] = r.s
+			m.section(r.path, b.s, t.s, r.s)
+		case inR && nodeKey(b.s) == nodeKey(r.s):
+			// removed in theirs
+			m.removeSection(r, res)
+		case inR:
+			// removed in theirs and changed in ours
+			m.sectionConflict(r.path, r.s, nil, []Contenter{removedNote()})
+			m.conflicts[len(m.conflicts)-1].Ours = collapseSpace(sectionText(r.s))
+		case inT && nodeKey(b.s) != nodeKey(t.s):
+			// removed in ours and changed in theirs, theirs is added back
+			if s := m.insertSection(t, theirs, byNode, inResult, res); s != nil {
+				m.sectionConflict(t.path, s, []Contenter{removedNote()}, nil)
+				m.conflicts[len(m.conflicts)-1].Theirs = collapseSpace(sectionText(t.s))
+			}
+		}
+	}
+	matched := map[*diffSection]bool{}
+	for _, t := range mt {
+		matched[t] = true
+	}
+	for _, t := range ft {
+		if !matched[t] && inResult[t] == nil {
+			m.insertSection(t, theirs, byNode, inResult, res)
+		}
+	}
+}
+
+// insertSection add copy of section of theirs after the nearest preceding
+// sibling existing in result, it return nil when parent is removed in ours
+func (m *merger3) insertSection(t *diffSection, theirs []*Section, byNode map[*Section]*diffSection,
+	inResult map[*diffSection]*Section, res *[]*Section) *Section {
+	list, siblings := res, theirs
+	if t.parent != nil {
+		parent := inResult[t.parent]
+		if parent == nil {
+			return nil
+		}
+		list, siblings = &parent.Sections, t.parent.s.Sections
+	}
+	pos := 0
+	for i := t.index - 1; i >= 0 && pos == 0; i-- {
+		prev := inResult[byNode[siblings[i]]]
+		for j, rs := range *list {
+			if rs == prev {
+				pos = j + 1
+			}
+		}
+	}
+	s := m.clone(t.s).(*Section)
+	m.renameIDs(s)
+	*list = append(*list, nil)
+	copy((*list)[pos+1:], (*list)[pos:])
+	(*list)[pos] = s
+	// subsections are copied with section
+	var mark func(ds *diffSection, s *Section)
+	mark = func(ds *diffSection, s *Section) {
+		inResult[ds] = s
+		for i, sub := range ds.s.Sections {
+			mark(byNode[sub], s.Sections[i])
+		}
+	}
+	mark(t, s)
+	return s
+}
+
+// removeSection remove section from its parent in result
+func (m *merger3) removeSection(r *diffSection, res *[]*Section) {
+	list := res
+	if r.parent != nil {
+		list = &r.parent.s.Sections
+	}
+	for i, s := range *list {
+		if s == r.s {
+			*list = append((*list)[:i], (*list)[i+1:]...)
+			return
+		}
+	}
+}
+
+// section merge title, epigraphs, image, annotation and content
+// of section, res is our version of section in merged book
+func (m *merger3) section(path string, base, theirs, res *Section) {
+	rv, bv, tv := reflect.ValueOf(res).Elem(), reflect.ValueOf(base).Elem(), reflect.ValueOf(theirs).Elem()
+	for _, name := range []string{"Title", "Epigraphs", "Image", "Annotation", "ID", "Lang"} {
+		rf := rv.FieldByName(name)
+		if m.field(rf, bv.FieldByName(name), tv.FieldByName(name)) {
+			continue
+		}
+		if name == "ID" || name == "Lang" {
+			m.conflicts = append(m.conflicts, Conflict{Path: path + "/" + kebabCase(name), Ours: valueText(rf), Theirs: valueText(tv.FieldByName(name))})
+			continue
+		}
+		// our version stays in its place, copy is shown in conflict
+		ours := fieldContent(m.cloneValue(rf))
+		m.sectionConflict(path+"/"+kebabCase(name), res, ours, fieldContent(tv.FieldByName(name)))
+	}
+	res.Content = m.blocks(path, base.Content, res.Content, theirs.Content)
+}
+
+// fieldContent return nodes of section field
+func fieldContent(v reflect.Value) []Contenter {
+	var res []Contenter
+	switch e := v.Interface().(type) {
+	case *Title:
+		if e != nil {
+			res = append(res, e.Content...)
+		}
+	case []*Epigraph:
+		for _, ep := range e {
+			res = append(res, ep)
+		}
+	case *Image:
+		if e != nil {
+			res = append(res, e)
+		}
+	case *Annotation:
+		if e != nil {
+			res = append(res, e.Content...)
+		}
+	}
+	return res
+}
+
+// removedNote return paragraph used in conflict instead of removed section
+func removedNote() Contenter {
+	p := newP("p")
+	p.Content = []Contenter{CharData("(section is removed)")}
+	return p
+}
+
+// citeContent return nodes allowed in cite: epigraphs and cites are
+// replaced by their content with text authors as paragraphs and images
+// by a paragraph with image reference
+func citeContent(cont []Contenter) []Contenter {
+	var res []Contenter
+	authors := func(ps []*P) {
+		for _, a := range ps {
+			p := newP("p")
+			p.Content = a.Content
+			res = append(res, p)
+		}
+	}
+	for _, c := range cont {
+		switch e := c.(type) {
+		case *Epigraph:
+			res = append(res, citeContent(e.Content)...)
+			authors(e.TextAuthor)
+		case *Cite:
+			res = append(res, citeContent(e.Content)...)
+			authors(e.TextAuthor)
+		case *Image:
+			p := newP("p")
+			p.Content = []Contenter{CharData("(image " + strings.TrimPrefix(e.XlinkHref, "#") + ")")}
+			res = append(res, p)
+		default:
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// sectionConflict insert conflict cite at the beginning of section content,
+// for section with subsections cite is put to a new first subsection
+func (m *merger3) sectionConflict(path string, s *Section, ours, theirs []Contenter) {
+	c := m.conflict(path, ours, theirs)
+	if len(s.Sections) == 0 {
+		s.Content = append([]Contenter{c}, s.Content...)
+		return
+	}
+	sub := m.conflictSections[s]
+	if sub == nil {
+		sub = &Section{}
+		sub.SetXMLName(xml.Name{Local: "section"})
+		s.Sections = append([]*Section{sub}, s.Sections...)
+		if m.conflictSections == nil {
+			m.conflictSections = map[*Section]*Section{}
+		}
+		m.conflictSections[s] = sub
+	}
+	sub.Content = append(sub.Content, c)
+}
+
+// blocks merge content of section, chunks changed in both versions
+// are replaced by conflict cite
+func (m *merger3) blocks(path string, base, ours, theirs []Contenter) []Contenter {
+	kb, ko, kt := nodeKeys(base), nodeKeys(ours), nodeKeys(theirs)
+	mo := baseMatches(diffKeys(kb, ko), len(kb))
+	mt := baseMatches(diffKeys(kb, kt), len(kb))
+	paths := blockPaths(path, ours)
+	var res []Contenter
+	i, jo, jt := 0, 0, 0
+	for i < len(kb) || jo < len(ko) || jt < len(kt) {
+		if i < len(kb) && mo[i] == jo && mt[i] == jt {
+			res = append(res, ours[jo])
+			i, jo, jt = i+1, jo+1, jt+1
+			continue
+		}
+		// next block of base kept in both versions
+		k := i
+		for k < len(kb) && (mo[k] < 0 || mt[k] < 0) {
+			k++
+		}
+		eo, et := len(ko), len(kt)
+		if k < len(kb) {
+			eo, et = mo[k], mt[k]
+		}
+		cb, co, ct := kb[i:k], ko[jo:eo], kt[jt:et]
+		switch {
+		case equalKeys(co, cb):
+			for _, c := range theirs[jt:et] {
+				res = append(res, m.clone(c.(Node)).(Contenter))
+			}
+		case equalKeys(ct, cb) || equalKeys(co, ct):
+			res = append(res, ours[jo:eo]...)
+		default:
+			p := path
+			if jo < len(paths) {
+				p = paths[jo]
+			}
+			res = append(res, m.conflict(p, ours[jo:eo], theirs[jt:et]))
+		}
+		i, jo, jt = k, eo, et
+	}
+	return res
+}
+
+func nodeKeys(cont []Contenter) []string {
+	res := make([]string, len(cont))
+	for i, c := range cont {
+		res[i] = nodeKey(c)
+	}
+	return res
+}
+
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// baseMatches return indexes of kept blocks of base in other version,
+// -1 for removed blocks
+func baseMatches(ops []diffOp, n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = -1
+	}
+	for _, op := range ops {
+		if op.op == '=' {
+			res[op.a] = op.b
+		}
+	}
+	return res
+}
+
+// conflict return cite with both versions between conflict markers
+func (m *merger3) conflict(path string, ours, theirs []Contenter) *Cite {
+	c := &Cite{ID: m.uniqueID("conflict-" + strconv.Itoa(len(m.conflicts)+1))}
+	c.SetXMLName(xml.Name{Local: "cite"})
+	marker := func(text string) Contenter {
+		p := newP("subtitle")
+		p.Content = []Contenter{CharData(text)}
+		return p
+	}
+	c.Content = append(c.Content, marker(conflictOurs))
+	c.Content = append(c.Content, citeContent(ours)...)
+	c.Content = append(c.Content, marker(conflictSep))
+	for _, t := range theirs {
+		n := m.clone(t.(Node)).(Contenter)
+		// ids are already used by our version
+		walk([]Contenter{n}, func(c Contenter) bool {
+			setNodeID(c, "")
+			return true
+		})
+		c.Content = append(c.Content, citeContent([]Contenter{n})...)
+	}
+	c.Content = append(c.Content, marker(conflictTheirs))
+	m.conflicts = append(m.conflicts, Conflict{
+		Path:   path,
+		Node:   c,
+		Ours:   collapseSpace(contentText(ours)),
+		Theirs: collapseSpace(contentText(theirs)),
+	})
+	return c
+}
+
+func (m *merger3) uniqueID(prefix string) string {
+	id := prefix
+	for n := 2; m.ids[id]; n++ {
+		id = fmt.Sprintf("%s-%d", prefix, n)
+	}
+	m.ids[id] = true
+	return id
+}
+
+// renameIDs make ids of copied section unique in merged book
+func (m *merger3) renameIDs(s *Section) {
+	walk([]Contenter{s}, func(c Contenter) bool {
+		if id := nodeID(c); id != "" && m.ids[id] {
+			setNodeID(c, m.uniqueID(id))
+		} else if id != "" {
+			m.ids[id] = true
+		}
+		return true
+	})
+}
+
+func (m *merger3) binaries(base, theirs []*Binary) {
+	index := func(binaries []*Binary) map[string]*Binary {
+		res := map[string]*Binary{}
+		for _, b := range binaries {
+			res[b.ID] = b
+		}
+		return res
+	}
+	ib, it := index(base), index(theirs)
+	var res []*Binary
+	for _, r := range m.res.Binary {
+		b, inB := ib[r.ID]
+		t, inT := it[r.ID]
+		switch {
+		case !inB:
+		case !inT && binaryHash(b) == binaryHash(r):
+			// removed in theirs
+			continue
+		case !inT:
+			m.conflicts = append(m.conflicts, Conflict{Path: "binary/" + r.ID, Ours: binaryHash(r)})
+		case binaryHash(b) == binaryHash(r):
+			r = m.clone(t).(*Binary)
+		case binaryHash(t) != binaryHash(b) && binaryHash(t) != binaryHash(r):
+			m.conflicts = append(m.conflicts, Conflict{Path: "binary/" + r.ID, Ours: binaryHash(r), Theirs: binaryHash(t)})
+		}
+		res = append(res, r)
+	}
+	ir := index(m.res.Binary)
+	for _, t := range theirs {
+		if _, ok := ir[t.ID]; ok {
+			continue
+		}
+		if b, ok := ib[t.ID]; !ok {
+			res = append(res, m.clone(t).(*Binary))
+		} else if binaryHash(b) != binaryHash(t) {
+			// removed in ours and changed in theirs
+			res = append(res, m.clone(t).(*Binary))
+			m.conflicts = append(m.conflicts, Conflict{Path: "binary/" + t.ID, Theirs: binaryHash(t)})
+		}
+	}
+	m.res.Binary = res
+}
+
+// history increase version of document and add merge to history
+func (m *merger3) history(ours, theirs *FictionBook) {
+	d := m.res.Description
+	if d == nil {
+		d = &Description{}
+		d.SetXMLName(xml.Name{Local: "description"})
+		m.res.Description = d
+	}
+	if d.DocumentInfo == nil {
+		d.DocumentInfo = &DocumentInfo{}
+		d.DocumentInfo.SetXMLName(xml.Name{Local: "document-info"})
+	}
+	di := d.DocumentInfo
+	version := di.Version
+	if theirs.Description != nil && theirs.Description.DocumentInfo != nil {
+		version = math.Max(version, theirs.Description.DocumentInfo.Version)
+	}
+	di.Version = math.Round((version+0.1)*100) / 100
+
+	if di.History == nil {
+		di.History = &Annotation{}
+		di.History.SetXMLName(xml.Name{Local: "history"})
+	}
+	// entries added in theirs
+	if theirs.Description != nil && theirs.Description.DocumentInfo != nil && theirs.Description.DocumentInfo.History != nil {
+		keys := map[string]bool{}
+		for _, k := range nodeKeys(di.History.Content) {
+			keys[k] = true
+		}
+		for _, c := range theirs.Description.DocumentInfo.History.Content {
+			if !keys[nodeKey(c)] {
+				di.History.Content = append(di.History.Content, m.clone(c.(Node)).(Contenter))
+			}
+		}
+	}
+	text := strconv.FormatFloat(di.Version, 'f', -1, 64) + " — merge of concurrent edits"
+	if len(m.conflicts) > 0 {
+		text += fmt.Sprintf(", conflicts: %d", len(m.conflicts))
+	}
+	p := newP("p")
+	p.Content = []Contenter{CharData(text)}
+	di.History.Content = append(di.History.Content, p)
+}
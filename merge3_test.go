@@ -0,0 +1,124 @@
+package gofb2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const merge3Base = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0" xmlns:l="http://www.w3.org/1999/xlink">
+<description><title-info><book-title>Book</book-title><lang>en</lang></title-info>
+<document-info><id>book</id><version>1</version></document-info></description>
+<body>
+<section id="a"><title><p>One</p></title>
+<epigraph><p>Old saying</p><text-author>Someone</text-author></epigraph>
+<image l:href="#a.png"/>
+<p>first</p><p>second</p><p>third</p>
+</section>
+<section id="b"><title><p>Two</p></title><p>fourth</p></section>
+</body>
+</FictionBook>`
+
+func TestMerge3(t *testing.T) {
+	base := parseBook(t, merge3Base)
+	ours := parseBook(t, strings.NewReplacer(
+		"<book-title>Book", "<book-title>Our book",
+		"Old saying", "Our saying",
+		"#a.png", "#ours.png",
+		"<p>second</p>", "<p>our second</p>",
+	).Replace(merge3Base))
+	theirs := parseBook(t, strings.NewReplacer(
+		"<lang>en", "<lang>de",
+		"Old saying", "Their saying",
+		"#a.png", "#theirs.png",
+		"<p>second</p>", "<p>their second</p>",
+		"<p>fourth</p>", "<p>their fourth</p>",
+	).Replace(merge3Base))
+
+	res, conflicts, err := Merge3(base, ours, theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ti := res.Description.TitleInfo
+	if fieldValue(ti.BookTitle) != "Our book" || ti.Lang != "de" {
+		t.Errorf("description is not merged: %q %q", fieldValue(ti.BookTitle), ti.Lang)
+	}
+	if got := contentText(res.Body.Sections[1].Content); got != "their fourth" {
+		t.Errorf("their change is lost: %q", got)
+	}
+	var paths []string
+	for _, c := range conflicts {
+		paths = append(paths, c.Path)
+	}
+	want := "body/section[1]#a/epigraphs body/section[1]#a/image body/section[1]#a/p[2]"
+	if strings.Join(paths, " ") != want {
+		t.Errorf("conflicts = %q, want %q", paths, want)
+	}
+	if c := conflicts[2]; c.Ours != "our second" || c.Theirs != "their second" {
+		t.Errorf("conflict = %q %q", c.Ours, c.Theirs)
+	}
+
+	// conflict cites contain only nodes allowed in cite
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fb := &FictionBook{}
+	if err := json.Unmarshal(data, fb); err != nil {
+		t.Fatal(err)
+	}
+	text := contentText(fb.Body.Sections[0].Content)
+	for _, s := range []string{"Our saying", "Their saying", "Someone", "(image theirs.png)", conflictOurs, conflictTheirs} {
+		if !strings.Contains(text, s) {
+			t.Errorf("no %q in %q", s, text)
+		}
+	}
+}
+
+func TestMerge3NoChanges(t *testing.T) {
+	base := parseBook(t, merge3Base)
+	res, conflicts, err := Merge3(base, base, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts = %v", conflicts)
+	}
+	// only version and history of document are changed
+	for _, c := range Diff(base, res) {
+		if !strings.HasPrefix(c.Path, "description/document-info/") {
+			t.Errorf("change %v", c)
+		}
+	}
+}
+
+func TestMerge3ParentConflict(t *testing.T) {
+	const book = `<FictionBook><body>
+<section id="p"><title><p>Part</p></title><epigraph><p>saying</p></epigraph>
+<section id="c"><title><p>Chapter</p></title><p>text</p></section></section>
+</body></FictionBook>`
+	base := parseBook(t, book)
+	ours := parseBook(t, strings.NewReplacer("Part", "Our part", "saying", "our saying").Replace(book))
+	theirs := parseBook(t, strings.NewReplacer("Part", "Their part", "saying", "their saying").Replace(book))
+	res, conflicts, err := Merge3(base, ours, theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %v", conflicts)
+	}
+	p := res.Body.Sections[0]
+	if len(p.Content) != 0 {
+		t.Errorf("section with subsections has content %q", contentText(p.Content))
+	}
+	if len(p.Sections) != 2 || p.Sections[1].ID != "c" {
+		t.Fatalf("subsections are not conflict and chapter: %d", len(p.Sections))
+	}
+	cont := p.Sections[0].Content
+	if len(cont) != 2 || cont[0] != conflicts[0].Node || cont[1] != conflicts[1].Node {
+		t.Errorf("conflict section does not contain conflicts")
+	}
+	if got := titleText(p.Title); got != "Our part" {
+		t.Errorf("title = %q", got)
+	}
+}
@@ -0,0 +1,462 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata edit description of book: setters validate values and create
+// missing nodes, changed fields are recorded to document history by Commit
+//
+//	m := NewMetadata(fb)
+//	check(m.SetTitle("War and Peace"))
+//	check(m.SetAuthors("Leo Tolstoy"))
+//	m.Commit()
+type Metadata struct {
+	fb      *FictionBook
+	changes []string
+	version bool
+}
+
+// NewMetadata return metadata editor of book
+func NewMetadata(fb *FictionBook) *Metadata {
+	return &Metadata{fb: fb}
+}
+
+func (m *Metadata) description() *Description {
+	if m.fb.Description == nil {
+		m.fb.Description = &Description{}
+		m.fb.Description.SetXMLName(xml.Name{Local: "description"})
+	}
+	return m.fb.Description
+}
+
+func (m *Metadata) titleInfo() *TitleInfo {
+	d := m.description()
+	if d.TitleInfo == nil {
+		d.TitleInfo = &TitleInfo{}
+		d.TitleInfo.SetXMLName(xml.Name{Local: "title-info"})
+	}
+	return d.TitleInfo
+}
+
+func (m *Metadata) publishInfo() *PublishInfo {
+	d := m.description()
+	if d.PublishInfo == nil {
+		d.PublishInfo = &PublishInfo{}
+		d.PublishInfo.SetXMLName(xml.Name{Local: "publish-info"})
+	}
+	return d.PublishInfo
+}
+
+func (m *Metadata) documentInfo() *DocumentInfo {
+	d := m.description()
+	if d.DocumentInfo == nil {
+		d.DocumentInfo = newDocumentInfo(time.Time{}, "", []byte(m.Title()+"\n"+strings.Join(m.Authors(), "\n")))
+	}
+	return d.DocumentInfo
+}
+
+// changed record change of field when value differ
+func (m *Metadata) changed(field string, old, value interface{}) {
+	if fmt.Sprint(old) == fmt.Sprint(value) {
+		return
+	}
+	for _, c := range m.changes {
+		if c == field {
+			return
+		}
+	}
+	m.changes = append(m.changes, field)
+}
+
+// Changes return fields changed since last Commit
+func (m *Metadata) Changes() []string {
+	return append([]string(nil), m.changes...)
+}
+
+// Commit add paragraph listing changed fields to document history,
+// version is incremented by 0.1 unless it was set, nothing is done
+// without changes
+func (m *Metadata) Commit() {
+	if len(m.changes) == 0 {
+		return
+	}
+	di := m.documentInfo()
+	if !m.version {
+		di.Version = math.Round((di.Version+0.1)*100) / 100
+	}
+	if di.History == nil {
+		di.History = &Annotation{}
+		di.History.SetXMLName(xml.Name{Local: "history"})
+	}
+	p := newP("p")
+	text := strconv.FormatFloat(di.Version, 'f', -1, 64) + " — changed " + strings.Join(m.changes, ", ")
+	p.Content = []Contenter{CharData(text)}
+	di.History.Content = append(di.History.Content, p)
+	m.changes, m.version = nil, false
+}
+
+// Title return book title
+func (m *Metadata) Title() string {
+	if d := m.fb.Description; d != nil && d.TitleInfo != nil {
+		return fieldValue(d.TitleInfo.BookTitle)
+	}
+	return ""
+}
+
+// SetTitle set book title, title is required
+func (m *Metadata) SetTitle(title string) error {
+	title = strings.TrimSpace(collapseSpace(title))
+	if title == "" {
+		return fmt.Errorf("empty book title")
+	}
+	m.changed("title", m.Title(), title)
+	setField(&m.titleInfo().BookTitle, "book-title", title)
+	return nil
+}
+
+func authorNames(authors []*Author) []string {
+	var res []string
+	for _, a := range authors {
+		res = append(res, authorName(a))
+	}
+	return res
+}
+
+// newAuthors return normalized authors by names like "First Middle Last"
+// or "Last, First Middle"
+func newAuthors(tag string, names []string) ([]*Author, error) {
+	var res []*Author
+	for _, name := range names {
+		name = strings.TrimSpace(collapseSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("empty %s name", tag)
+		}
+		a := personAuthor(tag, name, name)
+		a.Normalize()
+		res = append(res, a)
+	}
+	return normalizeAuthors(res), nil
+}
+
+// Authors return names of book authors
+func (m *Metadata) Authors() []string {
+	if d := m.fb.Description; d != nil && d.TitleInfo != nil {
+		return authorNames(d.TitleInfo.Authors)
+	}
+	return nil
+}
+
+// SetAuthors replace book authors, at least one author is required
+func (m *Metadata) SetAuthors(names ...string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("no authors")
+	}
+	authors, err := newAuthors("author", names)
+	if err != nil {
+		return err
+	}
+	m.changed("authors", m.Authors(), authorNames(authors))
+	m.titleInfo().Authors = authors
+	return nil
+}
+
+// Translators return names of translators
+func (m *Metadata) Translators() []string {
+	if d := m.fb.Description; d != nil && d.TitleInfo != nil {
+		return authorNames(d.TitleInfo.Translators)
+	}
+	return nil
+}
+
+// SetTranslators replace translators, no names remove them
+func (m *Metadata) SetTranslators(names ...string) error {
+	translators, err := newAuthors("translator", names)
+	if err != nil {
+		return err
+	}
+	m.changed("translators", m.Translators(), authorNames(translators))
+	m.titleInfo().Translators = translators
+	return nil
+}
+
+// Genres return genre codes of book
+func (m *Metadata) Genres() []string {
+	var res []string
+	if d := m.fb.Description; d != nil && d.TitleInfo != nil {
+		for _, g := range d.TitleInfo.Genres {
+			res = append(res, strings.TrimSpace(g.Genre))
+		}
+	}
+	return res
+}
+
+// SetGenres replace genres by canonical codes, genres must be in genre
+// list and at least one genre is required
+func (m *Metadata) SetGenres(codes ...string) error {
+	if len(codes) == 0 {
+		return fmt.Errorf("no genres")
+	}
+	ti := &TitleInfo{}
+	for _, code := range codes {
+		g := &Genre{Genre: code}
+		g.SetXMLName(xml.Name{Local: "genre"})
+		ti.Genres = append(ti.Genres, g)
+	}
+	if err := ValidateGenres(ti); err != nil {
+		return err
+	}
+	CanonicalGenres(ti)
+	old := m.Genres()
+	m.titleInfo().Genres = ti.Genres
+	m.changed("genres", old, m.Genres())
+	return nil
+}
+
+// Annotation return text of annotation, paragraphs are separated
+// by new lines
+func (m *Metadata) Annotation() string {
+	d := m.fb.Description
+	if d == nil || d.TitleInfo == nil || d.TitleInfo.Annotation == nil {
+		return ""
+	}
+	var paragraphs []string
+	for _, c := range d.TitleInfo.Annotation.Content {
+		if s := strings.TrimSpace(contentText([]Contenter{c})); s != "" {
+			paragraphs = append(paragraphs, s)
+		}
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+// SetAnnotation replace annotation by paragraphs of text separated by
+// new lines, empty text remove annotation
+func (m *Metadata) SetAnnotation(text string) {
+	var paragraphs []Contenter
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(collapseSpace(line)); line != "" {
+			p := newP("p")
+			p.Content = []Contenter{CharData(line)}
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	old := m.Annotation()
+	ti := m.titleInfo()
+	if len(paragraphs) == 0 {
+		ti.Annotation = nil
+	} else {
+		ti.Annotation = &Annotation{}
+		ti.Annotation.SetXMLName(xml.Name{Local: "annotation"})
+		ti.Annotation.Content = paragraphs
+	}
+	m.changed("annotation", old, m.Annotation())
+}
+
+// Keywords return comma separated keywords
+func (m *Metadata) Keywords() []string {
+	var res []string
+	if d := m.fb.Description; d != nil && d.TitleInfo != nil {
+		for _, k := range strings.Split(fieldValue(d.TitleInfo.Keywords), ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				res = append(res, k)
+			}
+		}
+	}
+	return res
+}
+
+// SetKeywords replace keywords, keywords can not contain commas
+func (m *Metadata) SetKeywords(keywords ...string) error {
+	var res []string
+	for _, k := range keywords {
+		k = strings.TrimSpace(collapseSpace(k))
+		if strings.Contains(k, ",") {
+			return fmt.Errorf("keyword %q contains comma", k)
+		}
+		if k != "" {
+			res = append(res, k)
+		}
+	}
+	m.changed("keywords", m.Keywords(), res)
+	setField(&m.titleInfo().Keywords, "keywords", strings.Join(res, ", "))
+	return nil
+}
+
+// Series return series of title-info and publish-info
+func (m *Metadata) Series() []SeriesEntry {
+	if m.fb.Description == nil {
+		return nil
+	}
+	return m.fb.Description.Series()
+}
+
+// SetSeries replace series of title-info, names of entry become nested
+// sequences and number is set to the innermost one
+func (m *Metadata) SetSeries(entries ...SeriesEntry) error {
+	var seqs []*Sequence
+	for _, e := range entries {
+		var number float64
+		if e.Number != "" {
+			n, err := ParseSequenceNumber(e.Number)
+			if err != nil {
+				return err
+			}
+			number = n
+		}
+		var outer *Sequence
+		for _, name := range e.Names {
+			if name = strings.TrimSpace(collapseSpace(name)); name == "" {
+				return fmt.Errorf("empty sequence name")
+			}
+			s := &Sequence{Name: name}
+			s.SetXMLName(xml.Name{Local: "sequence"})
+			if outer == nil {
+				seqs = append(seqs, s)
+			} else {
+				outer.Sequences = append(outer.Sequences, s)
+			}
+			outer = s
+		}
+		if outer == nil {
+			return fmt.Errorf("empty sequence name")
+		}
+		if e.Number != "" {
+			outer.SetNumber(number)
+		}
+	}
+	ti := m.titleInfo()
+	m.changed("series", FlattenSequences(ti.Sequences), FlattenSequences(seqs))
+	ti.Sequences = seqs
+	return nil
+}
+
+// langRe match language tags like "en", "ru" or "pt-BR"
+var langRe = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{1,8})*$`)
+
+// Lang return book language
+func (m *Metadata) Lang() string {
+	if d := m.fb.Description; d != nil && d.TitleInfo != nil {
+		return strings.TrimSpace(d.TitleInfo.Lang)
+	}
+	return ""
+}
+
+// SetLang set book language, lang is a language tag like "en" or "pt-BR"
+func (m *Metadata) SetLang(lang string) error {
+	lang = strings.TrimSpace(lang)
+	if !langRe.MatchString(lang) {
+		return fmt.Errorf("invalid language %q", lang)
+	}
+	m.changed("lang", m.Lang(), lang)
+	m.titleInfo().Lang = lang
+	return nil
+}
+
+// ISBNs return valid ISBNs of publish-info
+func (m *Metadata) ISBNs() []ISBN {
+	if d := m.fb.Description; d != nil && d.PublishInfo != nil {
+		return d.PublishInfo.ISBNs()
+	}
+	return nil
+}
+
+// SetISBN replace ISBN of publish-info by hyphenated ISBN-13 forms of
+// values, no values remove ISBN
+func (m *Metadata) SetISBN(values ...string) error {
+	var res []string
+	var isbns []ISBN
+	for _, v := range values {
+		isbn, err := ParseISBN(v)
+		if err != nil {
+			return err
+		}
+		isbns = append(isbns, isbn)
+		res = append(res, isbn.String())
+	}
+	m.changed("isbn", m.ISBNs(), isbns)
+	setField(&m.publishInfo().ISBN, "isbn", strings.Join(res, ", "))
+	return nil
+}
+
+// Publisher return publisher of paper book
+func (m *Metadata) Publisher() string {
+	if d := m.fb.Description; d != nil && d.PublishInfo != nil {
+		return fieldValue(d.PublishInfo.Publisher)
+	}
+	return ""
+}
+
+// SetPublisher set publisher of paper book, empty name remove it
+func (m *Metadata) SetPublisher(name string) {
+	name = strings.TrimSpace(collapseSpace(name))
+	m.changed("publisher", m.Publisher(), name)
+	setField(&m.publishInfo().Publisher, "publisher", name)
+}
+
+// Year return year of paper book publication, zero if unknown
+func (m *Metadata) Year() int {
+	if d := m.fb.Description; d != nil && d.PublishInfo != nil {
+		if r, ok := ParseDateRange(d.PublishInfo.Year); ok {
+			return r.From.Year()
+		}
+	}
+	return 0
+}
+
+// SetYear set year of paper book publication, zero remove it
+func (m *Metadata) SetYear(year int) error {
+	if year < 0 || year > 9999 {
+		return fmt.Errorf("invalid year %d", year)
+	}
+	m.changed("year", m.Year(), year)
+	pi := m.publishInfo()
+	pi.Year = ""
+	if year > 0 {
+		pi.Year = strconv.Itoa(year)
+	}
+	return nil
+}
+
+// DocumentID return unique identifier of document
+func (m *Metadata) DocumentID() string {
+	if d := m.fb.Description; d != nil && d.DocumentInfo != nil {
+		return strings.TrimSpace(d.DocumentInfo.ID)
+	}
+	return ""
+}
+
+// SetDocumentID set unique identifier of document
+func (m *Metadata) SetDocumentID(id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, " \t\n") {
+		return fmt.Errorf("invalid document id %q", id)
+	}
+	m.changed("id", m.DocumentID(), id)
+	m.documentInfo().ID = id
+	return nil
+}
+
+// Version return version of document
+func (m *Metadata) Version() float64 {
+	if d := m.fb.Description; d != nil && d.DocumentInfo != nil {
+		return d.DocumentInfo.Version
+	}
+	return 0
+}
+
+// SetVersion set version of document, version can not decrease
+func (m *Metadata) SetVersion(version float64) error {
+	if version <= 0 || version < m.Version() || math.IsInf(version, 0) || math.IsNaN(version) {
+		return fmt.Errorf("invalid version %v", version)
+	}
+	m.changed("version", m.Version(), version)
+	m.documentInfo().Version = version
+	m.version = true
+	return nil
+}
@@ -0,0 +1,87 @@
+package gofb2
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMetadataCommit(t *testing.T) {
+	fb := parseBook(t, `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0">
+<description><title-info><book-title>Book</book-title><lang>en</lang></title-info></description>
+<body><section><p>text</p></section></body>
+</FictionBook>`)
+	m := NewMetadata(fb)
+	if err := m.SetTitle("  War and   Peace "); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetAuthors("Leo Tolstoy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetSeries(SeriesEntry{Names: []string{"Epic", "Volume"}, Number: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(m.Changes(), " "); got != "title authors series" {
+		t.Errorf("changes = %q", got)
+	}
+	m.Commit()
+
+	di := fb.Description.DocumentInfo
+	if di == nil {
+		t.Fatal("no document-info")
+	}
+	if di.ID == "" || di.Date == nil || di.ProgramUsed == nil {
+		t.Error("document-info is not filled")
+	}
+	// author of document is a creator of file, not of book
+	if got := authorNames(di.Authors); len(got) != 1 || got[0] != programName {
+		t.Errorf("document authors = %v", got)
+	}
+	if di.Version != 1.1 {
+		t.Errorf("version = %v", di.Version)
+	}
+	if got := contentText(di.History.Content); got != "1.1 — changed title, authors, series" {
+		t.Errorf("history = %q", got)
+	}
+	if m.Title() != "War and Peace" || len(m.Changes()) != 0 {
+		t.Errorf("title = %q, changes = %v", m.Title(), m.Changes())
+	}
+	if s := FlattenSequences(fb.Description.TitleInfo.Sequences); len(s) != 1 || s[0].String() != "Epic › Volume #2" {
+		t.Errorf("series = %v", s)
+	}
+
+	// setting the same value is not a change
+	if err := m.SetTitle("War and Peace"); err != nil {
+		t.Fatal(err)
+	}
+	m.Commit()
+	if di.Version != 1.1 {
+		t.Errorf("version is changed without changes: %v", di.Version)
+	}
+}
+
+func TestMetadataValidation(t *testing.T) {
+	m := NewMetadata(&FictionBook{})
+	errs := []error{
+		m.SetTitle(" "),
+		m.SetAuthors(),
+		m.SetGenres("no-such-genre"),
+		m.SetLang("english language"),
+		m.SetYear(-1),
+		m.SetDocumentID("a b"),
+		m.SetSeries(SeriesEntry{Names: []string{""}}),
+		m.SetISBN("0-306-40615-3"),
+		m.SetSeries(SeriesEntry{Names: []string{"Series"}, Number: "NaN"}),
+		m.SetVersion(math.NaN()),
+		m.SetVersion(math.Inf(1)),
+		m.SetVersion(0),
+	}
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("no error %d", i)
+		}
+	}
+	if len(m.Changes()) != 0 {
+		t.Errorf("invalid values are recorded: %v", m.Changes())
+	}
+}
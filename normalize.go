@@ -0,0 +1,169 @@
+package gofb2
+
+import "strings"
+
+// NormalizeOptions set optional conversions of Normalize
+type NormalizeOptions struct {
+	// NBSP replace non-breaking spaces with ordinary ones
+	NBSP bool
+	// SoftHyphens remove soft hyphens
+	SoftHyphens bool
+}
+
+// Normalize clean text of paragraphs, verses, table cells and other mixed
+// content: whitespace runs become single space, adjacent text is merged,
+// paragraph edges are trimmed and empty styles are removed.
+// Content of code spans is not changed.
+func Normalize(fb *FictionBook, opts NormalizeOptions) {
+	n := &normalizer{opts: opts}
+	walk(bookContent(fb), func(c Contenter) bool {
+		switch e := c.(type) {
+		case *P:
+			e.Content = n.paragraph(e.Content)
+			return false
+		case *TD:
+			e.Content = n.paragraph(e.Content)
+			return false
+		}
+		return true
+	})
+}
+
+type normalizer struct {
+	opts NormalizeOptions
+	// space is set when written text ends with space
+	space bool
+}
+
+func (n *normalizer) paragraph(cont []Contenter) []Contenter {
+	n.space = true
+	return trimInlineRight(n.inline(cont))
+}
+
+func (n *normalizer) inline(cont []Contenter) []Contenter {
+	res := make([]Contenter, 0, len(cont))
+	for _, c := range cont {
+		if cd, ok := c.(CharData); ok {
+			res = appendText(res, n.text(string(cd)))
+			continue
+		}
+		if isCode(c) {
+			n.space = false
+			res = append(res, c)
+			continue
+		}
+		if l, ok := c.(*Link); ok {
+			l.Content = n.inline(l.Content)
+			res = append(res, l)
+			continue
+		}
+		content := styleContent(c)
+		if content == nil {
+			n.space = false
+			res = append(res, c)
+			continue
+		}
+		*content = n.inline(*content)
+		switch {
+		case len(*content) == 0:
+		case len(*content) == 1 && isText(*content, " "):
+			// style of space only
+			res = appendText(res, " ")
+		default:
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// text return s with collapsed whitespace and optional conversions
+func (n *normalizer) text(s string) string {
+	if n.opts.NBSP {
+		s = strings.Replace(s, "\u00a0", " ", -1)
+	}
+	if n.opts.SoftHyphens {
+		s = strings.Replace(s, "\u00ad", "", -1)
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if isXMLSpace(r) {
+			if !n.space {
+				b.WriteByte(' ')
+				n.space = true
+			}
+			continue
+		}
+		b.WriteRune(r)
+		n.space = false
+	}
+	return b.String()
+}
+
+// appendText append s to cont merging it with previous text
+func appendText(cont []Contenter, s string) []Contenter {
+	if s == "" {
+		return cont
+	}
+	if last := len(cont) - 1; last >= 0 {
+		if cd, ok := cont[last].(CharData); ok {
+			cont[last] = CharData(string(cd) + s)
+			return cont
+		}
+	}
+	return append(cont, CharData(s))
+}
+
+func isText(cont []Contenter, s string) bool {
+	cd, ok := cont[0].(CharData)
+	return ok && string(cd) == s
+}
+
+// isCode report whether c is a code span
+func isCode(c Contenter) bool {
+	switch c.(type) {
+	case *StyleType, *StyleLinkType:
+		return c.GetXMLName().Local == "code"
+	}
+	return false
+}
+
+// styleContent return content of style node or nil for other nodes
+func styleContent(c Contenter) *[]Contenter {
+	switch e := c.(type) {
+	case *StyleType:
+		return &e.Content
+	case *NamedStyleType:
+		return &e.Content
+	case *StyleLinkType:
+		return &e.Content
+	}
+	return nil
+}
+
+// trimInlineRight remove trailing spaces and styles left empty
+func trimInlineRight(cont []Contenter) []Contenter {
+	for len(cont) > 0 {
+		last := len(cont) - 1
+		if cd, ok := cont[last].(CharData); ok {
+			if s := strings.TrimRight(string(cd), " "); s != "" {
+				cont[last] = CharData(s)
+				return cont
+			}
+			cont = cont[:last]
+			continue
+		}
+		if l, ok := cont[last].(*Link); ok {
+			l.Content = trimInlineRight(l.Content)
+			return cont
+		}
+		content := styleContent(cont[last])
+		if content == nil || isCode(cont[last]) {
+			return cont
+		}
+		if *content = trimInlineRight(*content); len(*content) > 0 {
+			return cont
+		}
+		cont = cont[:last]
+	}
+	return cont
+}
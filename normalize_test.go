@@ -0,0 +1,33 @@
+package gofb2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		opts NormalizeOptions
+		want string
+	}{
+		{"<p>  a \n\t b  </p>", NormalizeOptions{}, `["a b"]`},
+		{"<p>a<emphasis> </emphasis>b<strong></strong> c</p>", NormalizeOptions{}, `["a b c"]`},
+		{"<p>a <emphasis> b </emphasis> c</p>", NormalizeOptions{}, `["a ",{"kind":"emphasis","content":["b "]},"c"]`},
+		{"<p>a <code>x   y</code>  b</p>", NormalizeOptions{}, `["a ",{"kind":"code","content":["x   y"]}," b"]`},
+		{"<p>a&#160;b&#173;c</p>", NormalizeOptions{}, "[\"a\u00a0b\u00adc\"]"},
+		{"<p>a&#160;b&#173;c</p>", NormalizeOptions{NBSP: true, SoftHyphens: true}, `["a bc"]`},
+	}
+	for _, tt := range tests {
+		fb := parseBook(t, `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0"><body><section>`+tt.in+`</section></body></FictionBook>`)
+		Normalize(fb, tt.opts)
+		p := fb.Body.Sections[0].Content[0].(*P)
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"kind":"p","content":` + tt.want + `}`; string(data) != want {
+			t.Errorf("%s normalized to %s, want %s", tt.in, data, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package gofb2
+
+import "strings"
+
+// footnotes numbers notes from NotesBody in order of their first reference
+type footnotes struct {
+	sections map[string]*Section
+	all      []string
+	ids      []string
+	numbers  map[string]int
+}
+
+func newFootnotes(fb *FictionBook) *footnotes {
+	f := &footnotes{
+		sections: map[string]*Section{},
+		numbers:  map[string]int{},
+	}
+	if fb == nil || fb.NotesBody == nil {
+		return f
+	}
+	var walk func([]*Section)
+	walk = func(sections []*Section) {
+		for _, s := range sections {
+			if s.ID != "" {
+				f.sections[s.ID] = s
+				f.all = append(f.all, s.ID)
+			}
+			walk(s.Sections)
+		}
+	}
+	walk(fb.NotesBody.Sections)
+	return f
+}
+
+// localID return id from local href like "#id"
+func localID(href string) (string, bool) {
+	if !strings.HasPrefix(href, "#") || len(href) == 1 {
+		return "", false
+	}
+	return href[1:], true
+}
+
+// number return number of note referenced by href, numbering it on first use
+func (f *footnotes) number(href string) (int, bool) {
+	id, ok := localID(href)
+	if !ok {
+		return 0, false
+	}
+	if _, ok := f.sections[id]; !ok {
+		return 0, false
+	}
+	return f.numberID(id), true
+}
+
+func (f *footnotes) numberID(id string) int {
+	if n, ok := f.numbers[id]; ok {
+		return n
+	}
+	f.ids = append(f.ids, id)
+	f.numbers[id] = len(f.ids)
+	return len(f.ids)
+}
+
+// list return ids of all notes ordered by number.
+// Notes that were never referenced get numbers after referenced ones.
+func (f *footnotes) list() []string {
+	for _, id := range f.all {
+		f.numberID(id)
+	}
+	return f.ids
+}
+
+// content return note content without its title
+func (f *footnotes) content(id string) []Contenter {
+	s := f.sections[id]
+	if s == nil {
+		return nil
+	}
+	c := append([]Contenter{}, s.Content...)
+	for _, cs := range s.Sections {
+		if cs.ID == "" {
+			c = append(c, cs.Content...)
+		}
+	}
+	return c
+}
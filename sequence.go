@@ -0,0 +1,214 @@
+package gofb2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// seriesSeparator separate names of nested series
+const seriesSeparator = " › "
+
+// ParseSequenceNumber parse number of book in series like "3", "1.5",
+// "2,5" or "#4"
+func ParseSequenceNumber(s string) (float64, error) {
+	v := strings.TrimLeft(strings.TrimSpace(s), "#№ ")
+	n, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, fmt.Errorf("invalid sequence number %q", s)
+	}
+	return n, nil
+}
+
+func formatSequenceNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// NumberValue return number of book in sequence, fractional number is
+// taken from NumberText when it agrees with Number, false is returned
+// for sequence without number
+func (s *Sequence) NumberValue() (float64, bool) {
+	if n, err := ParseSequenceNumber(s.NumberText); err == nil && int(n) == s.Number {
+		return n, true
+	}
+	return float64(s.Number), s.Number != 0
+}
+
+// SetNumber set number of book in sequence
+func (s *Sequence) SetNumber(n float64) {
+	s.Number = int(n)
+	s.NumberText = formatSequenceNumber(n)
+}
+
+// NumberString return number of book in series like "2" or "1.5",
+// unknown number is empty
+func (s *Sequence) NumberString() string {
+	n, ok := s.NumberValue()
+	if !ok {
+		return ""
+	}
+	return formatSequenceNumber(n)
+}
+
+// SeriesEntry is a series of book with names of outer series
+type SeriesEntry struct {
+	// Names of series from the outermost one
+	Names []string
+	// Number of book in the innermost series like "2" or "1.5",
+	// empty if unknown
+	Number string
+}
+
+// Name return names of series like "Series › Subseries"
+func (e SeriesEntry) Name() string {
+	return strings.Join(e.Names, seriesSeparator)
+}
+
+// String return series with number like "Series › Subseries #2"
+func (e SeriesEntry) String() string {
+	if e.Number == "" {
+		return e.Name()
+	}
+	return e.Name() + " #" + e.Number
+}
+
+// Key return key to find the same series written differently
+func (e SeriesEntry) Key() string {
+	keys := make([]string, len(e.Names))
+	for i, name := range e.Names {
+		keys[i] = foldName(name)
+	}
+	return strings.Join(keys, "/")
+}
+
+// FlattenSequences return series of nested sequences, entry is added
+// for every named sequence which has a number or has no subsequences,
+// names of unnamed sequences are skipped
+func FlattenSequences(seqs []*Sequence) []SeriesEntry {
+	var res []SeriesEntry
+	var flatten func(names []string, seqs []*Sequence)
+	flatten = func(names []string, seqs []*Sequence) {
+		for _, s := range seqs {
+			path := names
+			if name := strings.TrimSpace(collapseSpace(s.Name)); name != "" {
+				path = append(names[:len(names):len(names)], name)
+			}
+			if n := s.NumberString(); len(path) > 0 && (n != "" || len(s.Sequences) == 0) {
+				res = append(res, SeriesEntry{Names: path, Number: n})
+			}
+			flatten(path, s.Sequences)
+		}
+	}
+	flatten(nil, seqs)
+	return res
+}
+
+// Series return series of title-info merged with series of publish-info,
+// the same series is returned once and missing number is taken from
+// the other one
+func (d *Description) Series() []SeriesEntry {
+	var seqs [][]*Sequence
+	if d.TitleInfo != nil {
+		seqs = append(seqs, d.TitleInfo.Sequences)
+	}
+	if d.PublishInfo != nil {
+		seqs = append(seqs, d.PublishInfo.Sequences)
+	}
+	var res []SeriesEntry
+	keys := map[string]int{}
+	for _, s := range seqs {
+		for _, e := range FlattenSequences(s) {
+			i, ok := keys[e.Key()]
+			if !ok {
+				keys[e.Key()] = len(res)
+				res = append(res, e)
+			} else if res[i].Number == "" {
+				res[i].Number = e.Number
+			}
+		}
+	}
+	return res
+}
+
+// CompareSeries compare series by names and then by numbers, outer
+// series go before its subseries and entries without number go last,
+// result is -1, 0 or +1
+func CompareSeries(a, b SeriesEntry) int {
+	for i := 0; i < len(a.Names) && i < len(b.Names); i++ {
+		if c := strings.Compare(foldName(a.Names[i]), foldName(b.Names[i])); c != 0 {
+			return c
+		}
+	}
+	if len(a.Names) != len(b.Names) {
+		return compareInt(len(a.Names), len(b.Names))
+	}
+	na, errA := ParseSequenceNumber(a.Number)
+	nb, errB := ParseSequenceNumber(b.Number)
+	switch {
+	case errA != nil || errB != nil:
+		// unknown numbers go last
+		return compareInt(boolInt(errA != nil), boolInt(errB != nil))
+	case na < nb:
+		return -1
+	case na > nb:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// SortSeries sort series entries by CompareSeries
+func SortSeries(entries []SeriesEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return CompareSeries(entries[i], entries[j]) < 0
+	})
+}
+
+// SortBySeries sort books by their first series and number in it, books
+// without series go last, books of the same position are sorted by title
+func SortBySeries(books []*FictionBook) {
+	type item struct {
+		series []SeriesEntry
+		title  string
+	}
+	items := map[*FictionBook]item{}
+	for _, b := range books {
+		var it item
+		if d := b.Description; d != nil {
+			it.series = d.Series()
+			if d.TitleInfo != nil {
+				it.title = foldName(fieldValue(d.TitleInfo.BookTitle))
+			}
+		}
+		items[b] = it
+	}
+	sort.SliceStable(books, func(i, j int) bool {
+		a, b := items[books[i]], items[books[j]]
+		if len(a.series) == 0 || len(b.series) == 0 {
+			if len(a.series) != len(b.series) {
+				return len(b.series) == 0
+			}
+		} else if c := CompareSeries(a.series[0], b.series[0]); c != 0 {
+			return c < 0
+		}
+		return a.title < b.title
+	})
+}
@@ -0,0 +1,99 @@
+package gofb2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSequenceNumber(t *testing.T) {
+	fb := parseBook(t, `<FictionBook><description><title-info>
+<sequence name="A" number="1.5"/><sequence name="B" number="0"/><sequence name="C" number=""/>
+<sequence name="D" number="x"/><sequence name="E" number="#3"/><sequence name="F"/>
+</title-info></description></FictionBook>`)
+	tests := []struct {
+		number int
+		value  float64
+		ok     bool
+		str    string
+	}{
+		{1, 1.5, true, "1.5"},
+		{0, 0, true, "0"},
+		{0, 0, false, ""},
+		{0, 0, false, ""},
+		{3, 3, true, "3"},
+		{0, 0, false, ""},
+	}
+	seqs := fb.Description.TitleInfo.Sequences
+	for i, tt := range tests {
+		s := seqs[i]
+		v, ok := s.NumberValue()
+		if s.Number != tt.number || v != tt.value || ok != tt.ok || s.NumberString() != tt.str {
+			t.Errorf("sequence %s has number %d %v %v %q", s.Name, s.Number, v, ok, s.NumberString())
+		}
+	}
+	// number changed by old code is used instead of text
+	seqs[0].Number = 4
+	if v, ok := seqs[0].NumberValue(); v != 4 || !ok {
+		t.Errorf("changed number is %v %v", v, ok)
+	}
+	seqs[0].SetNumber(2.25)
+	if seqs[0].Number != 2 || seqs[0].NumberString() != "2.25" {
+		t.Errorf("set number is %d %q", seqs[0].Number, seqs[0].NumberString())
+	}
+
+	var got []string
+	for _, e := range FlattenSequences(seqs) {
+		got = append(got, e.String())
+	}
+	if want := "A #2.25|B #0|C|D|E #3|F"; strings.Join(got, "|") != want {
+		t.Errorf("series = %q, want %q", strings.Join(got, "|"), want)
+	}
+}
+
+func TestCompareSeries(t *testing.T) {
+	entries := []SeriesEntry{
+		{Names: []string{"B"}},
+		{Names: []string{"B"}, Number: "2"},
+		{Names: []string{"A", "Sub"}, Number: "1"},
+		{Names: []string{"B"}, Number: "0"},
+		{Names: []string{"A"}, Number: "10"},
+		{Names: []string{"B"}, Number: "1.5"},
+		{Names: []string{"a"}, Number: "9"},
+	}
+	SortSeries(entries)
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.String())
+	}
+	if want := "a #9|A #10|A › Sub #1|B #0|B #1.5|B #2|B"; strings.Join(got, "|") != want {
+		t.Errorf("sorted series = %q, want %q", strings.Join(got, "|"), want)
+	}
+}
+
+func TestDescriptionSeries(t *testing.T) {
+	fb := parseBook(t, `<FictionBook><description>
+<title-info><sequence name="Cycle"/><sequence name="Other" number="0"/></title-info>
+<publish-info><sequence name="cycle" number="3"/><sequence name="Other" number="5"/></publish-info>
+</description></FictionBook>`)
+	var got []string
+	for _, e := range fb.Description.Series() {
+		got = append(got, e.String())
+	}
+	if want := "Cycle #3|Other #0"; strings.Join(got, "|") != want {
+		t.Errorf("series = %q, want %q", strings.Join(got, "|"), want)
+	}
+}
+
+func TestBuildSequence(t *testing.T) {
+	b := NewBook().Title("Book").Sequence("Zero", "0").Sequence("None", "").Sequence("Half", "1,5")
+	seqs := b.Build().Description.TitleInfo.Sequences
+	if len(seqs) != 3 || seqs[0].NumberString() != "0" || seqs[1].NumberString() != "" || seqs[2].NumberString() != "1.5" {
+		t.Errorf("sequences = %v", FlattenSequences(seqs))
+	}
+	if b.Err() != nil {
+		t.Error(b.Err())
+	}
+	if err := NewBook().Sequence("Bad", "-1").Err(); err == nil {
+		t.Error("negative number is accepted")
+	}
+}
@@ -0,0 +1,461 @@
+package gofb2
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// cloneNode return deep copy of node
+func cloneNode(n Node) (Node, error) {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	c := reflect.New(reflect.TypeOf(n).Elem()).Interface().(Node)
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// hrefNodes call fn for every node with href in content and coverpages
+// of book, fn can change href
+func hrefNodes(fb *FictionBook, fn func(href *string)) {
+	walk(bookContent(fb), func(c Contenter) bool {
+		switch e := c.(type) {
+		case *Link:
+			fn(&e.XlinkHref)
+		case *Image:
+			fn(&e.XlinkHref)
+		case *InlineImage:
+			fn(&e.XlinkHref)
+		}
+		return true
+	})
+	if d := fb.Description; d != nil {
+		for _, ti := range []*TitleInfo{d.TitleInfo, d.SrcTitleInfo} {
+			if ti != nil && ti.Coverpage != nil && ti.Coverpage.Image != nil {
+				fn(&ti.Coverpage.Image.XlinkHref)
+			}
+		}
+	}
+}
+
+// ExtractSection return standalone book with copy of section s of fb.
+// Notes referenced from section and binaries used by section and its notes
+// are copied. Description is derived from description of fb: section title
+// become book title, section annotation become book annotation, new
+// document id is generated. Section with subsections is unwrapped: its
+// title, epigraphs and image become body title, epigraphs and image.
+// Untitled section is titled "<book title> — <n>" by its position.
+func ExtractSection(fb *FictionBook, s *Section) (*FictionBook, error) {
+	n, err := cloneNode(s)
+	if err != nil {
+		return nil, err
+	}
+	cs := n.(*Section)
+
+	res := &FictionBook{Stylesheet: fb.Stylesheet}
+	res.SetXMLName(xml.Name{Local: "FictionBook"})
+	res.Body = &Body{}
+	res.Body.SetXMLName(xml.Name{Local: "body"})
+	if fb.Body != nil {
+		res.Body.Lang = fb.Body.Lang
+	}
+	annotation := cs.Annotation
+	if len(cs.Sections) > 0 {
+		res.Body.Title, res.Body.Epigraphs, res.Body.Image = cs.Title, cs.Epigraphs, cs.Image
+		res.Body.Sections = cs.Sections
+		if len(cs.Content) > 0 {
+			// content after subsections
+			tail := &Section{}
+			tail.SetXMLName(xml.Name{Local: "section"})
+			tail.Content = cs.Content
+			res.Body.Sections = append(res.Body.Sections, tail)
+		}
+		if cs.Lang != "" {
+			res.Body.Lang = cs.Lang
+		}
+	} else {
+		cs.Annotation = nil
+		res.Body.Sections = []*Section{cs}
+	}
+
+	if err := extractNotes(fb, res); err != nil {
+		return nil, err
+	}
+
+	title := titleText(s.Title)
+	if fb.Description != nil {
+		n, err := cloneNode(fb.Description)
+		if err != nil {
+			return nil, err
+		}
+		d := n.(*Description)
+		if ti := d.TitleInfo; ti != nil {
+			if book := fieldValue(ti.BookTitle); title == "" && book != "" {
+				if n := sectionNumber(fb, s); n > 0 {
+					title = fmt.Sprintf("%s — %d", book, n)
+				}
+			}
+			if title != "" {
+				ti.BookTitle = textField("book-title", title)
+			}
+			ti.Annotation = annotation
+			if annotation != nil {
+				annotation.SetXMLName(xml.Name{Local: "annotation"})
+			}
+			ti.Coverpage = nil
+			if res.Body.Image != nil {
+				ti.Coverpage = &Coverpage{Image: &InlineImage{XlinkHref: res.Body.Image.XlinkHref}}
+				ti.Coverpage.SetXMLName(xml.Name{Local: "coverpage"})
+				ti.Coverpage.Image.SetXMLName(xml.Name{Local: "image"})
+			}
+		}
+		if d.PublishInfo != nil {
+			d.PublishInfo.ISBN = nil
+		}
+		if d.DocumentInfo != nil {
+			d.DocumentInfo.ID = hashUUID([]byte(d.DocumentInfo.ID + "/" + s.ID + "/" + title))
+			d.DocumentInfo.Version = 1
+		}
+		res.Description = d
+	}
+	var source bytes.Buffer
+	for _, c := range bookContent(res) {
+		source.WriteString(contentText([]Contenter{c}))
+	}
+	if res.Description == nil {
+		res.Description = &Description{}
+		res.Description.SetXMLName(xml.Name{Local: "description"})
+	}
+	fillDescription(res.Description, time.Time{}, "", source.Bytes())
+	res.Binary = usedBinaries(fb, res)
+	return res, nil
+}
+
+// sectionNumber return position of s among its sibling sections starting
+// from 1 or 0 when s is not in main body of fb
+func sectionNumber(fb *FictionBook, s *Section) int {
+	var find func([]*Section) int
+	find = func(sections []*Section) int {
+		for i, sub := range sections {
+			if sub == s {
+				return i + 1
+			}
+			if n := find(sub.Sections); n > 0 {
+				return n
+			}
+		}
+		return 0
+	}
+	if fb.Body == nil {
+		return 0
+	}
+	return find(fb.Body.Sections)
+}
+
+// extractNotes copy notes of fb referenced from res and notes referenced
+// from them to notes body of res
+func extractNotes(fb *FictionBook, res *FictionBook) error {
+	notes := newFootnotes(fb)
+	if len(notes.all) == 0 {
+		return nil
+	}
+	used := map[string]bool{}
+	var queue []string
+	refs := func(cont []Contenter) {
+		walk(cont, func(c Contenter) bool {
+			if l, ok := c.(*Link); ok {
+				if id, ok := localID(l.XlinkHref); ok && notes.sections[id] != nil && !used[id] {
+					used[id] = true
+					queue = append(queue, id)
+				}
+			}
+			return true
+		})
+	}
+	refs(bodyChildren(res.Body))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		refs([]Contenter{notes.sections[id]})
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	nb := &NotesBody{Name: fb.NotesBody.Name}
+	nb.SetXMLName(xml.Name{Local: "body"})
+	nb.Lang = fb.NotesBody.Lang
+	if fb.NotesBody.Title != nil {
+		n, err := cloneNode(fb.NotesBody.Title)
+		if err != nil {
+			return err
+		}
+		nb.Title = n.(*Title)
+	}
+	for _, id := range notes.all {
+		if !used[id] {
+			continue
+		}
+		n, err := cloneNode(notes.sections[id])
+		if err != nil {
+			return err
+		}
+		note := n.(*Section)
+		// nested notes are copied separately
+		var sections []*Section
+		for _, sub := range note.Sections {
+			if !used[sub.ID] {
+				sections = append(sections, sub)
+			}
+		}
+		note.Sections = sections
+		nb.Sections = append(nb.Sections, note)
+	}
+	res.NotesBody = nb
+	return nil
+}
+
+// usedBinaries return binaries of fb referenced from res
+func usedBinaries(fb *FictionBook, res *FictionBook) []*Binary {
+	ids := map[string]bool{}
+	hrefNodes(res, func(href *string) {
+		if id, ok := localID(*href); ok {
+			ids[id] = true
+		}
+	})
+	var binaries []*Binary
+	for _, b := range fb.Binary {
+		if ids[b.ID] {
+			binaries = append(binaries, b)
+		}
+	}
+	return binaries
+}
+
+// Split return books of top level sections of main body of fb
+func Split(fb *FictionBook) ([]*FictionBook, error) {
+	if fb.Body == nil {
+		return nil, nil
+	}
+	var res []*FictionBook
+	for _, s := range fb.Body.Sections {
+		b, err := ExtractSection(fb, s)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+	return res, nil
+}
+
+// MergeOptions set how books are merged
+type MergeOptions struct {
+	// Wrap put sections of every book into section titled with book title,
+	// otherwise sections are appended to the main body
+	Wrap bool
+}
+
+// Merge return new book with content of books. Description is copied from
+// the first book, authors and genres of other books are added. Ids of every
+// next book that are already used are changed, equal binaries are stored
+// once and notes are combined to one notes body.
+func Merge(books []*FictionBook, opts MergeOptions) (*FictionBook, error) {
+	if len(books) == 0 {
+		return nil, fmt.Errorf("no books to merge")
+	}
+	res := &FictionBook{}
+	res.SetXMLName(xml.Name{Local: "FictionBook"})
+	res.Body = &Body{}
+	res.Body.SetXMLName(xml.Name{Local: "body"})
+	m := &merger{res: res, ids: map[string]bool{}}
+	for i, b := range books {
+		n, err := cloneNode(b)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.add(n.(*FictionBook), i == 0, opts); err != nil {
+			return nil, err
+		}
+	}
+	if res.Description == nil {
+		res.Description = &Description{}
+		res.Description.SetXMLName(xml.Name{Local: "description"})
+	}
+	var source bytes.Buffer
+	for _, b := range books {
+		if b.Description != nil && b.Description.DocumentInfo != nil {
+			source.WriteString(b.Description.DocumentInfo.ID + "\n")
+		}
+	}
+	if di := res.Description.DocumentInfo; di != nil {
+		di.ID = hashUUID(source.Bytes())
+		di.Version = 1
+	}
+	fillDescription(res.Description, time.Time{}, "", source.Bytes())
+	return res, nil
+}
+
+type merger struct {
+	res *FictionBook
+	// ids used in merged book
+	ids map[string]bool
+}
+
+// add append copy of book fb to merged book
+func (m *merger) add(fb *FictionBook, first bool, opts MergeOptions) error {
+	m.renameIDs(fb)
+	res := m.res
+	for _, st := range fb.Stylesheet {
+		found := false
+		for _, other := range res.Stylesheet {
+			found = found || other.Type == st.Type && bytes.Equal(other.Value, st.Value)
+		}
+		if !found {
+			res.Stylesheet = append(res.Stylesheet, st)
+		}
+	}
+
+	if first {
+		res.Description = fb.Description
+	} else if fb.Description != nil && fb.Description.TitleInfo != nil {
+		mergeTitleInfo(res.Description, fb.Description.TitleInfo)
+	}
+
+	if body := fb.Body; body != nil {
+		switch {
+		case opts.Wrap:
+			s := &Section{Title: body.Title, Epigraphs: body.Epigraphs, Image: body.Image, Sections: body.Sections}
+			s.SetXMLName(xml.Name{Local: "section"})
+			s.Lang = body.Lang
+			if s.Title == nil {
+				if d := fb.Description; d != nil && d.TitleInfo != nil && d.TitleInfo.BookTitle != nil {
+					s.Title = textTitle(d.TitleInfo.BookTitle.Value)
+				}
+			}
+			res.Body.Sections = append(res.Body.Sections, s)
+		case first:
+			res.Body.Title, res.Body.Epigraphs, res.Body.Image = body.Title, body.Epigraphs, body.Image
+			res.Body.Lang = body.Lang
+			fallthrough
+		default:
+			res.Body.Sections = append(res.Body.Sections, body.Sections...)
+		}
+	}
+
+	if nb := fb.NotesBody; nb != nil {
+		if res.NotesBody == nil {
+			res.NotesBody = &NotesBody{Name: nb.Name}
+			res.NotesBody.SetXMLName(xml.Name{Local: "body"})
+		}
+		if res.NotesBody.Title == nil {
+			res.NotesBody.Title = nb.Title
+		}
+		res.NotesBody.Sections = append(res.NotesBody.Sections, nb.Sections...)
+	}
+	return nil
+}
+
+// renameIDs change ids of fb that are already used in merged book and
+// references to them, binaries equal to already merged ones are dropped
+func (m *merger) renameIDs(fb *FictionBook) {
+	renamed := map[string]string{}
+	var binaries []*Binary
+	for _, b := range fb.Binary {
+		if other := m.equalBinary(b); other != nil {
+			renamed[b.ID] = other.ID
+			continue
+		}
+		if id := m.uniqueID(b.ID); id != b.ID {
+			renamed[b.ID] = id
+			b.ID = id
+		}
+		m.ids[b.ID] = true
+		binaries = append(binaries, b)
+	}
+	m.res.Binary = append(m.res.Binary, binaries...)
+
+	walk(bookContent(fb), func(c Contenter) bool {
+		old := nodeID(c)
+		if old == "" {
+			return true
+		}
+		if id := m.uniqueID(old); id != old {
+			setNodeID(c, id)
+			if _, ok := renamed[old]; !ok {
+				renamed[old] = id
+			}
+		}
+		m.ids[nodeID(c)] = true
+		return true
+	})
+	if len(renamed) == 0 {
+		return
+	}
+	hrefNodes(fb, func(href *string) {
+		if id, ok := localID(*href); ok && renamed[id] != "" {
+			*href = "#" + renamed[id]
+		}
+	})
+}
+
+// uniqueID return id or id with numeric suffix if it is used
+func (m *merger) uniqueID(id string) string {
+	res := id
+	for n := 2; m.ids[res]; n++ {
+		res = fmt.Sprintf("%s-%d", id, n)
+	}
+	return res
+}
+
+func (m *merger) equalBinary(b *Binary) *Binary {
+	for _, other := range m.res.Binary {
+		if other.ContentType == b.ContentType && bytes.Equal(other.Value, b.Value) {
+			return other
+		}
+	}
+	return nil
+}
+
+// mergeTitleInfo add authors, translators and genres of ti missing in d
+func mergeTitleInfo(d *Description, ti *TitleInfo) {
+	if d == nil || d.TitleInfo == nil {
+		return
+	}
+	res := d.TitleInfo
+	for _, a := range ti.Authors {
+		if !hasAuthor(res.Authors, a) {
+			res.Authors = append(res.Authors, a)
+		}
+	}
+	for _, a := range ti.Translators {
+		if !hasAuthor(res.Translators, a) {
+			res.Translators = append(res.Translators, a)
+		}
+	}
+	for _, g := range ti.Genres {
+		found := false
+		for _, other := range res.Genres {
+			found = found || other.Genre == g.Genre
+		}
+		if !found {
+			res.Genres = append(res.Genres, g)
+		}
+	}
+}
+
+func hasAuthor(authors []*Author, a *Author) bool {
+	for _, other := range authors {
+		if strings.EqualFold(authorName(other), authorName(a)) {
+			return true
+		}
+	}
+	return false
+}
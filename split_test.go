@@ -0,0 +1,114 @@
+package gofb2
+
+import "testing"
+
+const omnibusBook = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0" xmlns:l="http://www.w3.org/1999/xlink">
+<description><title-info><genre>prose</genre><author><first-name>Ann</first-name><last-name>Smith</last-name></author><book-title>Omnibus</book-title><lang>en</lang></title-info>
+<document-info><author><nickname>editor</nickname></author><date>2020</date><id>omnibus</id><version>1</version></document-info></description>
+<body>
+<section id="one"><title><p>Book One</p></title><p>Text<a l:href="#n1" type="note">1</a></p><image l:href="#a.png"/></section>
+<section id="two"><title><p>Book Two</p></title><p>Text<a l:href="#n2" type="note">2</a></p></section>
+</body>
+<body name="notes">
+<section id="n1"><p>First note</p></section>
+<section id="n2"><p>Second note</p><image l:href="#b.png"/></section>
+</body>
+<binary id="a.png" content-type="image/png">AAAA</binary>
+<binary id="b.png" content-type="image/png">BBBB</binary>
+</FictionBook>`
+
+func TestSplit(t *testing.T) {
+	books, err := Split(parseBook(t, omnibusBook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(books) != 2 {
+		t.Fatalf("books = %d", len(books))
+	}
+	want := []struct {
+		title, note, binary string
+	}{
+		{"Book One", "n1", "a.png"},
+		{"Book Two", "n2", "b.png"},
+	}
+	for i, b := range books {
+		w := want[i]
+		if got := fieldValue(b.Description.TitleInfo.BookTitle); got != w.title {
+			t.Errorf("book %d title = %q, want %q", i, got, w.title)
+		}
+		if b.NotesBody == nil || len(b.NotesBody.Sections) != 1 || b.NotesBody.Sections[0].ID != w.note {
+			t.Errorf("book %d notes are not %s", i, w.note)
+		}
+		if len(b.Binary) != 1 || b.Binary[0].ID != w.binary {
+			t.Errorf("book %d binaries are not %s", i, w.binary)
+		}
+		if got := authorNames(b.Description.TitleInfo.Authors); len(got) != 1 || got[0] != "Ann Smith" {
+			t.Errorf("book %d authors = %v", i, got)
+		}
+		if b.Description.DocumentInfo.ID == "omnibus" {
+			t.Errorf("book %d has id of omnibus", i)
+		}
+	}
+}
+
+func TestExtractUntitledSection(t *testing.T) {
+	fb := parseBook(t, omnibusBook)
+	fb.Body.Sections[1].Title = nil
+	b, err := ExtractSection(fb, fb.Body.Sections[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fieldValue(b.Description.TitleInfo.BookTitle); got != "Omnibus — 2" {
+		t.Errorf("title = %q, want %q", got, "Omnibus — 2")
+	}
+	one, err := ExtractSection(fb, fb.Body.Sections[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if one.Description.DocumentInfo.ID == b.Description.DocumentInfo.ID {
+		t.Errorf("books have the same id %s", b.Description.DocumentInfo.ID)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	src := parseBook(t, omnibusBook)
+	res, err := Merge([]*FictionBook{src, src}, MergeOptions{Wrap: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Body.Sections) != 2 {
+		t.Fatalf("sections = %d", len(res.Body.Sections))
+	}
+	second := res.Body.Sections[1]
+	if got := titleText(second.Title); got != "Omnibus" {
+		t.Errorf("wrapper title = %q", got)
+	}
+	if len(second.Sections) != 2 || second.Sections[0].ID != "one-2" {
+		t.Errorf("ids of second book are not renamed")
+	}
+	if len(res.NotesBody.Sections) != 4 {
+		t.Errorf("notes = %d", len(res.NotesBody.Sections))
+	}
+	// the second note link point to renamed note
+	var hrefs []string
+	walk(second.Sections[0].Content, func(c Contenter) bool {
+		if l, ok := c.(*Link); ok {
+			hrefs = append(hrefs, l.XlinkHref)
+		}
+		return true
+	})
+	if len(hrefs) != 1 || hrefs[0] != "#n1-2" {
+		t.Errorf("note links = %v", hrefs)
+	}
+	// equal binaries are stored once
+	if len(res.Binary) != 2 {
+		t.Errorf("binaries = %d", len(res.Binary))
+	}
+	if src.Body.Sections[0].ID != "one" {
+		t.Error("source book is changed")
+	}
+
+	if _, err := Merge(nil, MergeOptions{}); err == nil {
+		t.Error("no error for empty list")
+	}
+}
@@ -0,0 +1,220 @@
+package gofb2
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TextStats is a size of text
+type TextStats struct {
+	Words int
+	// Chars is a count of characters with spaces between words
+	Chars int
+	// Paragraphs is a count of paragraphs, subtitles, verses and text-authors,
+	// titles are not counted
+	Paragraphs int
+}
+
+func (s *TextStats) add(o TextStats) {
+	s.Words += o.Words
+	s.Chars += o.Chars
+	s.Paragraphs += o.Paragraphs
+}
+
+// SectionStats is a size of section with its subsections
+type SectionStats struct {
+	ID    string
+	Title string
+	TextStats
+	Sections []*SectionStats
+}
+
+// BinaryStats is a count and size in bytes of binaries of one content type
+type BinaryStats struct {
+	Count int
+	Size  int
+}
+
+// BookStats is a statistics of book. Text of main body is counted in
+// TextStats, text of notes in Notes.
+type BookStats struct {
+	TextStats
+	Notes    TextStats
+	Sections []*SectionStats
+
+	Poems     int
+	Tables    int
+	Images    int
+	Footnotes int
+	// Binaries by content type
+	Binaries map[string]BinaryStats
+	// ReadingTime of main body by language
+	ReadingTime map[string]time.Duration
+}
+
+// readingSpeed is an average silent reading speed in words per minute
+var readingSpeed = map[string]int{
+	"en": 228,
+	"ru": 184,
+	"uk": 184,
+	"be": 184,
+	"de": 179,
+	"fr": 195,
+	"es": 218,
+	"it": 188,
+	"pt": 181,
+	"nl": 202,
+	"pl": 166,
+	"sv": 199,
+	"fi": 161,
+	"tr": 166,
+	"zh": 158,
+	"ja": 193,
+}
+
+const defaultReadingSpeed = 200
+
+// TotalReadingTime return reading time of main body in all languages
+func (s *BookStats) TotalReadingTime() time.Duration {
+	var d time.Duration
+	for _, t := range s.ReadingTime {
+		d += t
+	}
+	return d
+}
+
+// Stats return counts of words, characters and paragraphs of book and its
+// sections, counts of poems, tables, images and footnotes, sizes of binaries
+// and estimated reading time
+func Stats(fb *FictionBook) *BookStats {
+	st := &statsCounter{
+		stats: &BookStats{
+			Binaries:    map[string]BinaryStats{},
+			ReadingTime: map[string]time.Duration{},
+		},
+		words: map[string]int{},
+	}
+	lang := bookLang(fb)
+	if fb.Body != nil {
+		bodyLang := inheritLang(fb.Body.Lang, lang)
+		var text TextStats
+		for _, c := range bodyChildren(fb.Body) {
+			if s, ok := c.(*Section); ok {
+				ss := st.section(s, bodyLang)
+				st.stats.Sections = append(st.stats.Sections, ss)
+				text.add(ss.TextStats)
+				continue
+			}
+			text.add(st.count(c, bodyLang, false))
+		}
+		st.stats.TextStats = text
+	}
+	if fb.NotesBody != nil {
+		st.notes = true
+		notesLang := inheritLang(fb.NotesBody.Lang, lang)
+		for _, c := range bodyChildren(&fb.NotesBody.Body) {
+			st.stats.Notes.add(st.count(c, notesLang, false))
+		}
+	}
+	st.stats.Footnotes = len(newFootnotes(fb).all)
+	for _, b := range fb.Binary {
+		bs := st.stats.Binaries[b.ContentType]
+		bs.Count++
+		bs.Size += len(b.Value)
+		st.stats.Binaries[b.ContentType] = bs
+	}
+	for l, n := range st.words {
+		speed, ok := readingSpeed[baseLang(l)]
+		if !ok {
+			speed = defaultReadingSpeed
+		}
+		st.stats.ReadingTime[l] = time.Duration(n) * time.Minute / time.Duration(speed)
+	}
+	return st.stats
+}
+
+type statsCounter struct {
+	stats *BookStats
+	// words of main body by language
+	words map[string]int
+	notes bool
+}
+
+func (st *statsCounter) section(s *Section, lang string) *SectionStats {
+	lang = inheritLang(s.Lang, lang)
+	ss := &SectionStats{ID: s.ID, Title: titleText(s.Title)}
+	for _, c := range children(s) {
+		if sub, ok := c.(*Section); ok {
+			subStats := st.section(sub, lang)
+			ss.Sections = append(ss.Sections, subStats)
+			ss.add(subStats.TextStats)
+			continue
+		}
+		ss.add(st.count(c, lang, false))
+	}
+	return ss
+}
+
+// count return size of text of node and count poems, tables and images
+func (st *statsCounter) count(c Contenter, lang string, title bool) TextStats {
+	var res TextStats
+	if n, ok := c.(Node); ok {
+		lang = inheritLang(nodeLang(n), lang)
+	}
+	switch e := c.(type) {
+	case *P:
+		res = st.text(e.Content, lang)
+		if !title {
+			res.Paragraphs = 1
+		}
+		st.images(e.Content)
+		return res
+	case *TD:
+		st.images(e.Content)
+		return st.text(e.Content, lang)
+	case *Title:
+		title = true
+	case *Poem:
+		st.stats.Poems++
+	case *Table:
+		st.stats.Tables++
+	case *Image:
+		st.stats.Images++
+	}
+	for _, child := range children(c) {
+		res.add(st.count(child, lang, title))
+	}
+	return res
+}
+
+// text return count of words and characters of inline content,
+// references to notes are not counted
+func (st *statsCounter) text(cont []Contenter, lang string) TextStats {
+	var words []Contenter
+	for _, c := range cont {
+		if l, ok := c.(*Link); !ok || l.Type != "note" {
+			words = append(words, c)
+		}
+	}
+	text := strings.Replace(contentText(words), "\u00ad", "", -1)
+	res := TextStats{Chars: utf8.RuneCountInString(text)}
+	for _, w := range strings.Fields(text) {
+		if strings.IndexFunc(w, isWordRune) >= 0 {
+			res.Words++
+		}
+	}
+	if !st.notes && res.Words > 0 {
+		st.words[lang] += res.Words
+	}
+	return res
+}
+
+func (st *statsCounter) images(cont []Contenter) {
+	walk(cont, func(c Contenter) bool {
+		if _, ok := c.(*InlineImage); ok {
+			st.stats.Images++
+		}
+		return true
+	})
+}
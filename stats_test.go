@@ -0,0 +1,61 @@
+package gofb2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	fb := parseBook(t, `<FictionBook xmlns:l="http://www.w3.org/1999/xlink">
+<description><title-info><lang>ru</lang></title-info></description>
+<body><title><p>Книга</p></title>
+<section id="s1"><title><p>Первая</p></title><p>Раз два<a l:href="#n1" type="note">1</a></p><p>три — че&#173;тыре</p>
+<section><poem><stanza><v>пять шесть</v></stanza></poem><image l:href="#i1"/></section></section>
+<section xml:lang="en"><p>one two <image l:href="#i2"/></p><table><tr><td>three</td></tr></table></section>
+</body>
+<body name="notes"><section id="n1"><p>сноска из слов</p></section></body>
+<binary id="i1" content-type="image/png">AAAA</binary><binary id="i2" content-type="image/png">AAAAAA==</binary>
+<binary id="c" content-type="image/jpeg">AA==</binary>
+</FictionBook>`)
+	st := Stats(fb)
+	if want := (TextStats{Words: 11, Chars: 52, Paragraphs: 4}); st.TextStats != want {
+		t.Errorf("book text is %+v, want %+v", st.TextStats, want)
+	}
+	if want := (TextStats{Words: 3, Chars: 14, Paragraphs: 1}); st.Notes != want {
+		t.Errorf("notes text is %+v, want %+v", st.Notes, want)
+	}
+	if len(st.Sections) != 2 {
+		t.Fatalf("got %d sections, want 2", len(st.Sections))
+	}
+	s := st.Sections[0]
+	if s.ID != "s1" || s.Title != "Первая" || s.Words != 7 || s.Paragraphs != 3 || len(s.Sections) != 1 {
+		t.Errorf("first section is %+v", s)
+	}
+	if sub := s.Sections[0]; sub.Words != 2 || sub.Paragraphs != 1 {
+		t.Errorf("subsection is %+v", sub)
+	}
+	if st.Poems != 1 || st.Tables != 1 || st.Images != 2 || st.Footnotes != 1 {
+		t.Errorf("got %d poems, %d tables, %d images, %d footnotes, want 1, 1, 2, 1", st.Poems, st.Tables, st.Images, st.Footnotes)
+	}
+	if png := st.Binaries["image/png"]; png.Count != 2 || png.Size != 7 {
+		t.Errorf("png binaries are %+v, want 2 of 7 bytes", png)
+	}
+	if jpeg := st.Binaries["image/jpeg"]; jpeg.Count != 1 || jpeg.Size != 1 {
+		t.Errorf("jpeg binaries are %+v, want 1 of 1 byte", jpeg)
+	}
+	want := map[string]time.Duration{
+		"ru": 8 * time.Minute / 184,
+		"en": 3 * time.Minute / 228,
+	}
+	if len(st.ReadingTime) != len(want) {
+		t.Errorf("reading time is %v, want %v", st.ReadingTime, want)
+	}
+	for l, d := range want {
+		if st.ReadingTime[l] != d {
+			t.Errorf("reading time of %s is %v, want %v", l, st.ReadingTime[l], d)
+		}
+	}
+	if got := st.TotalReadingTime(); got != want["ru"]+want["en"] {
+		t.Errorf("total reading time is %v", got)
+	}
+}
@@ -0,0 +1,463 @@
+package gofb2
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextOptions describe layout of plain text export
+type TextOptions struct {
+	// Width wraps lines longer than Width characters, 0 disables wrapping
+	Width int
+
+	// Indent is inserted before the first line of every paragraph
+	Indent string
+
+	// NoNotes disables footnote markers and the notes section
+	NoNotes bool
+}
+
+const (
+	textBlockIndent = "    "
+	textAuthorDash  = "— "
+)
+
+// WriteText write book to w as plain text.
+// Footnotes are replaced with [n] markers and appended as a notes section
+// at the end of the text.
+func WriteText(w io.Writer, fb *FictionBook, opts TextOptions) error {
+	if fb == nil {
+		return fmt.Errorf("no book to write")
+	}
+	t := &textWriter{w: w, opts: opts, notes: newFootnotes(fb), blank: true}
+	if opts.NoNotes {
+		t.notes = newFootnotes(nil)
+	}
+	t.body(fb.Body, fb.Description)
+	if !opts.NoNotes && fb.NotesBody != nil {
+		t.notesSection(fb.NotesBody)
+	}
+	return t.err
+}
+
+type textWriter struct {
+	w      io.Writer
+	opts   TextOptions
+	notes  *footnotes
+	prefix string
+	lead   string
+	blank  bool
+	err    error
+}
+
+func (t *textWriter) line(s string) {
+	if t.err != nil {
+		return
+	}
+	s = strings.TrimRight(t.prefix+s, " ")
+	_, t.err = io.WriteString(t.w, s+"\n")
+	t.blank = s == ""
+}
+
+// separate insert empty line between blocks
+func (t *textWriter) separate() {
+	if !t.blank {
+		t.line("")
+	}
+}
+
+func (t *textWriter) indent(prefix string) func() {
+	old := t.prefix
+	t.prefix += prefix
+	return func() { t.prefix = old }
+}
+
+func (t *textWriter) body(b *Body, d *Description) {
+	if b != nil && b.Title != nil {
+		t.title(b.Title)
+	} else if d != nil && d.TitleInfo != nil && d.TitleInfo.BookTitle != nil {
+		t.separate()
+		t.centered(d.TitleInfo.BookTitle.Value)
+		t.line("")
+	}
+	if b == nil {
+		return
+	}
+	if b.Image != nil {
+		t.image(b.Image)
+	}
+	for _, ep := range b.Epigraphs {
+		t.epigraph(ep)
+	}
+	for _, s := range b.Sections {
+		t.section(s)
+	}
+}
+
+func (t *textWriter) section(s *Section) {
+	if s.Title != nil {
+		t.title(s.Title)
+	}
+	for _, ep := range s.Epigraphs {
+		t.epigraph(ep)
+	}
+	if s.Image != nil {
+		t.image(s.Image)
+	}
+	if s.Annotation != nil {
+		restore := t.indent(textBlockIndent)
+		t.blocks(s.Annotation.Content)
+		restore()
+	}
+	for _, cs := range s.Sections {
+		t.section(cs)
+	}
+	t.blocks(s.Content)
+}
+
+func (t *textWriter) title(title *Title) {
+	t.separate()
+	for _, c := range title.Content {
+		if p, ok := c.(*P); ok {
+			t.centered(t.inlineText(p.Content))
+		} else {
+			t.line("")
+		}
+	}
+	t.line("")
+}
+
+func (t *textWriter) centered(s string) {
+	width := t.opts.Width - utf8.RuneCountInString(t.prefix)
+	for _, l := range t.wrap(s, "", "") {
+		if n := utf8.RuneCountInString(l); width > 0 && n < width {
+			l = strings.Repeat(" ", (width-n)/2) + l
+		}
+		t.line(l)
+	}
+}
+
+func (t *textWriter) epigraph(ep *Epigraph) {
+	t.separate()
+	restore := t.indent(textBlockIndent)
+	t.blocks(ep.Content)
+	for _, a := range ep.TextAuthor {
+		t.paragraph(textAuthorDash+t.inlineText(a.Content), "")
+	}
+	restore()
+	t.separate()
+}
+
+func (t *textWriter) image(i *Image) {
+	alt := i.Title
+	if alt == "" {
+		alt = i.Alt
+	}
+	if alt != "" {
+		t.separate()
+		t.line("[" + alt + "]")
+		t.separate()
+	}
+}
+
+func (t *textWriter) blocks(cont []Contenter) {
+	for _, c := range cont {
+		switch e := c.(type) {
+		case *P:
+			if e.GetXMLName().Local == "subtitle" {
+				t.separate()
+				t.centered(t.inlineText(e.Content))
+				t.line("")
+			} else {
+				t.paragraph(t.inlineText(e.Content), t.opts.Indent)
+			}
+		case *EmptyLine:
+			t.line("")
+		case *Image:
+			t.image(e)
+		case *Poem:
+			t.poem(e)
+		case *Cite:
+			t.separate()
+			restore := t.indent(textBlockIndent)
+			t.blocks(e.Content)
+			for _, a := range e.TextAuthor {
+				t.paragraph(textAuthorDash+t.inlineText(a.Content), "")
+			}
+			restore()
+			t.separate()
+		case *Table:
+			t.table(e)
+		case *Section:
+			t.section(e)
+		default:
+			t.blocks(c.GetContent())
+		}
+	}
+}
+
+func (t *textWriter) poem(p *Poem) {
+	t.separate()
+	restore := t.indent(textBlockIndent)
+	if p.Title != nil {
+		t.title(p.Title)
+	}
+	for _, ep := range p.Epigraphs {
+		t.epigraph(ep)
+	}
+	for _, c := range p.Content {
+		switch e := c.(type) {
+		case *Stanza:
+			t.separate()
+			if e.Title != nil {
+				t.title(e.Title)
+			}
+			if e.Subtitle != nil {
+				t.paragraph(t.inlineText(e.Subtitle.Content), "")
+			}
+			for _, v := range e.V {
+				t.paragraph(t.inlineText(v.Content), "")
+			}
+		case *P:
+			t.separate()
+			t.paragraph(t.inlineText(e.Content), "")
+		}
+	}
+	restore()
+	t.separate()
+}
+
+func (t *textWriter) paragraph(s string, indent string) {
+	if t.lead != "" {
+		indent = t.lead
+		t.lead = ""
+	}
+	for _, l := range t.wrap(s, indent, "") {
+		t.line(l)
+	}
+}
+
+// wrap split s to lines fitting in opts.Width
+func (t *textWriter) wrap(s string, first, rest string) []string {
+	width := t.opts.Width - utf8.RuneCountInString(t.prefix)
+	if t.opts.Width <= 0 || width <= 0 {
+		return []string{first + s}
+	}
+	var lines []string
+	cur, n := first, utf8.RuneCountInString(first)
+	empty := true
+	for _, word := range strings.Split(s, " ") {
+		wn := utf8.RuneCountInString(word)
+		if !empty && n+1+wn > width {
+			lines = append(lines, cur)
+			cur, n, empty = rest, utf8.RuneCountInString(rest), true
+		}
+		if !empty {
+			cur += " "
+			n++
+		}
+		cur += word
+		n += wn
+		empty = false
+	}
+	return append(lines, cur)
+}
+
+func (t *textWriter) inlineText(cont []Contenter) string {
+	var b strings.Builder
+	t.inline(&b, cont)
+	return collapseSpace(b.String())
+}
+
+func (t *textWriter) inline(b *strings.Builder, cont []Contenter) {
+	for _, c := range cont {
+		switch e := c.(type) {
+		case CharData:
+			b.Write(e)
+		case *Link:
+			if n, ok := t.notes.number(e.XlinkHref); ok {
+				fmt.Fprintf(b, "[%d]", n)
+			} else {
+				t.inline(b, e.Content)
+			}
+		case *InlineImage:
+			if e.Alt != "" {
+				b.WriteString("[" + e.Alt + "]")
+			}
+		default:
+			t.inline(b, c.GetContent())
+		}
+	}
+}
+
+func (t *textWriter) notesSection(nb *NotesBody) {
+	ids := t.notes.list()
+	if len(ids) == 0 {
+		return
+	}
+	if nb.Title != nil {
+		t.title(nb.Title)
+	} else {
+		t.separate()
+		t.centered("Notes")
+		t.line("")
+	}
+	for _, id := range ids {
+		t.separate()
+		t.lead = fmt.Sprintf("[%d] ", t.notes.numbers[id])
+		t.blocks(t.notes.content(id))
+		if t.lead != "" {
+			t.line(strings.TrimSpace(t.lead))
+			t.lead = ""
+		}
+	}
+}
+
+type textCell struct {
+	text  string
+	align string
+	col   int
+	span  int
+}
+
+func (t *textWriter) table(tbl *Table) {
+	var (
+		rows   [][]textCell
+		header []bool
+		widths []int
+	)
+	// columns occupied by cells with rowspan from previous rows
+	occupied := map[int]int{}
+	for _, tr := range tbl.TR {
+		var row []textCell
+		next := map[int]int{}
+		col, th := 0, len(tr.Content) > 0
+		for _, c := range tr.Content {
+			td, ok := c.(*TD)
+			if !ok {
+				continue
+			}
+			for occupied[col] > 0 {
+				col++
+			}
+			cell := textCell{
+				text:  t.inlineText(td.Content),
+				align: td.Align,
+				col:   col,
+				span:  1,
+			}
+			if cell.align == "" {
+				cell.align = tr.Align
+			}
+			if td.Colspan > 1 {
+				cell.span = td.Colspan
+			}
+			if td.Rowspan > 1 {
+				for i := col; i < col+cell.span; i++ {
+					next[i] = td.Rowspan - 1
+				}
+			}
+			if td.GetXMLName().Local != "th" {
+				th = false
+			}
+			row = append(row, cell)
+			col += cell.span
+		}
+		for c, n := range occupied {
+			if n > 1 {
+				next[c] = n - 1
+			}
+		}
+		occupied = next
+		for len(widths) < col {
+			widths = append(widths, 0)
+		}
+		rows = append(rows, row)
+		header = append(header, th)
+	}
+	for _, row := range rows {
+		for _, c := range row {
+			if n := utf8.RuneCountInString(c.text); c.span == 1 && n > widths[c.col] {
+				widths[c.col] = n
+			}
+		}
+	}
+	for _, row := range rows {
+		for _, c := range row {
+			if n, w := utf8.RuneCountInString(c.text), spanWidth(widths, c); n > w {
+				widths[c.col+c.span-1] += n - w
+			}
+		}
+	}
+
+	t.separate()
+	for i, row := range rows {
+		var b strings.Builder
+		col := 0
+		for _, c := range row {
+			for ; col < c.col; col++ {
+				b.WriteString(strings.Repeat(" ", widths[col]) + " | ")
+			}
+			b.WriteString(alignText(c.text, c.align, spanWidth(widths, c)))
+			b.WriteString(" | ")
+			col += c.span
+		}
+		t.line(strings.TrimSuffix(b.String(), " | "))
+		if header[i] {
+			sep := make([]string, len(widths))
+			for j, w := range widths {
+				sep[j] = strings.Repeat("-", w)
+			}
+			t.line(strings.Join(sep, "-+-"))
+		}
+	}
+	t.separate()
+}
+
+func spanWidth(widths []int, c textCell) int {
+	w := 0
+	for i := c.col; i < c.col+c.span; i++ {
+		w += widths[i]
+	}
+	return w + 3*(c.span-1)
+}
+
+func alignText(s, align string, width int) string {
+	pad := width - utf8.RuneCountInString(s)
+	if pad <= 0 {
+		return s
+	}
+	switch align {
+	case "right":
+		return strings.Repeat(" ", pad) + s
+	case "center":
+		return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+	default:
+		return s + strings.Repeat(" ", pad)
+	}
+}
+
+// collapseSpace replace runs of xml whitespace with single space and trim
+// the result. Non-breaking spaces are kept.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if isXMLSpace(r) {
+			space = true
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isXMLSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
@@ -0,0 +1,363 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ReadText convert plain text manuscript to FictionBook. Lines like
+// "Глава N", "Chapter N" and lines in capitals become section titles,
+// blocks of short lines become poems, blocks quoted with ">" or indented
+// become cites. Paragraphs are separated by blank lines or, in wrapped text,
+// by indentation, extra blank lines become empty lines. Short lines before
+// the first heading are taken as book title.
+func ReadText(r io.Reader, opts ImportOptions) (*FictionBook, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimPrefix(strings.ToValidUTF8(string(data), "\uFFFD"), "\uFEFF")
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text)
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("no text to import")
+	}
+
+	t := newTextBuilder(splitBlocks(text, true), false)
+	nodes := t.build()
+
+	fb := &FictionBook{}
+	fb.SetXMLName(xml.Name{Local: "FictionBook"})
+	h := newHTMLImporter()
+	h.startFile("")
+	h.blocks(nodes)
+	fb.Body = h.body
+	fillEmptySections(fb.Body.Sections)
+	if len(t.title) > 0 {
+		fb.Body.Title = textTitle(t.title...)
+		if opts.Title == "" {
+			opts.Title = strings.Join(t.title, " ")
+		}
+	}
+	if opts.Lang == "" {
+		opts.Lang = detectLang(text)
+	}
+	fb.Description = importDescription(opts, data)
+	return fb, nil
+}
+
+type textLine struct {
+	indent int
+	text   string
+}
+
+// textBlock is a group of lines between blank lines
+type textBlock struct {
+	lines []textLine
+	// count of blank lines before block
+	blank int
+}
+
+// splitBlocks split text to blocks by blank lines, when headings is set
+// heading lines also start new block
+func splitBlocks(text string, headings bool) []textBlock {
+	var blocks []textBlock
+	blank := 0
+	for _, l := range strings.Split(text, "\n") {
+		l = strings.TrimRightFunc(strings.Replace(l, "\t", "    ", -1), unicode.IsSpace)
+		text := strings.TrimLeftFunc(l, unicode.IsSpace)
+		if text == "" {
+			blank++
+			continue
+		}
+		line := textLine{indent: utf8.RuneCountInString(l) - utf8.RuneCountInString(text), text: text}
+		if blank > 0 || len(blocks) == 0 || headings && headingLevel(text) > 0 {
+			blocks = append(blocks, textBlock{blank: blank})
+			blank = 0
+		}
+		last := &blocks[len(blocks)-1]
+		last.lines = append(last.lines, line)
+	}
+	return blocks
+}
+
+// textBuilder convert text blocks to HTML nodes for htmlImporter
+type textBuilder struct {
+	blocks []textBlock
+	// width of wrapped text or 0 if every line is a paragraph
+	width int
+	// usual indent of blocks
+	indent int
+	// blank lines separate paragraphs, only extra lines are empty lines
+	blankSep bool
+	// quote is set for text of cite, headings are not detected
+	quote bool
+
+	title   []string
+	nodes   []*htmlNode
+	content bool
+}
+
+func newTextBuilder(blocks []textBlock, quote bool) *textBuilder {
+	t := &textBuilder{blocks: blocks, quote: quote}
+	var lengths []int
+	indents := map[int]int{}
+	for _, b := range blocks {
+		for _, l := range b.lines {
+			lengths = append(lengths, lineLength(l))
+		}
+		if len(b.lines) > 1 {
+			indents[minIndent(b.lines)]++
+		}
+	}
+	if len(lengths) >= 5 {
+		sort.Ints(lengths)
+		width := lengths[len(lengths)*9/10]
+		full := 0
+		for _, n := range lengths {
+			if n >= width*3/4 && n <= width {
+				full++
+			}
+		}
+		if width >= 40 && width <= 100 && full*5 >= len(lengths)*2 {
+			t.width = width
+		}
+	}
+	for indent, n := range indents {
+		if n > indents[t.indent] || n == indents[t.indent] && indent < t.indent {
+			t.indent = indent
+		}
+	}
+	paragraphs := 0
+	for _, b := range blocks {
+		paragraphs += len(t.paragraphs(b.lines))
+	}
+	t.blankSep = paragraphs*2 <= len(blocks)*3
+	return t
+}
+
+func lineLength(l textLine) int {
+	return l.indent + utf8.RuneCountInString(l.text)
+}
+
+func minIndent(lines []textLine) int {
+	min := lines[0].indent
+	for _, l := range lines {
+		if l.indent < min {
+			min = l.indent
+		}
+	}
+	return min
+}
+
+func lineTexts(lines []textLine) []string {
+	res := make([]string, len(lines))
+	for i, l := range lines {
+		res[i] = l.text
+	}
+	return res
+}
+
+// paragraphs join lines of wrapped text, new paragraph starts with
+// indented or dialogue line or after short line
+func (t *textBuilder) paragraphs(lines []textLine) [][]string {
+	min := minIndent(lines)
+	var res [][]string
+	for i, l := range lines {
+		if i == 0 || t.width == 0 || l.indent > min || isDialogue(l.text) || lineLength(lines[i-1]) < t.width*2/3 {
+			res = append(res, nil)
+		}
+		res[len(res)-1] = append(res[len(res)-1], l.text)
+	}
+	return res
+}
+
+func textNode(s string) *htmlNode {
+	return &htmlNode{text: s}
+}
+
+func elementNode(name, class string, children ...*htmlNode) *htmlNode {
+	n := &htmlNode{name: name, children: children}
+	if class != "" {
+		n.attrs = []xml.Attr{{Name: xml.Name{Local: "class"}, Value: class}}
+	}
+	return n
+}
+
+// linesNode return inline nodes of lines separated by <br/>
+func linesNode(lines []string) []*htmlNode {
+	var res []*htmlNode
+	for i, l := range lines {
+		if i > 0 {
+			res = append(res, elementNode("br", ""))
+		}
+		res = append(res, textNode(l))
+	}
+	return res
+}
+
+func (t *textBuilder) build() []*htmlNode {
+	hasHeadings := false
+	for _, b := range t.blocks {
+		if headingLevel(b.lines[0].text) > 0 {
+			hasHeadings = true
+		}
+	}
+	for i, b := range t.blocks {
+		lines, first := b.lines, b.lines[0].text
+		if quoted(lines) {
+			t.emptyLines(b.blank)
+			t.cite(unquote(lines))
+			continue
+		}
+		if t.quote {
+			t.block(lines, b.blank)
+			continue
+		}
+		if level := headingLevel(first); level > 0 {
+			n := 1
+			for n < len(lines) && n < 3 && isTitleLine(lines[n].text, n == len(lines)-1) {
+				n++
+			}
+			t.heading(level, lineTexts(lines[:n]))
+			if n < len(lines) {
+				t.block(lines[n:], 0)
+			}
+			continue
+		}
+		if i == 0 && hasHeadings && len(lines) <= 3 {
+			title := true
+			for j, l := range lines {
+				title = title && isTitleLine(l.text, j == len(lines)-1)
+			}
+			if title {
+				t.title = lineTexts(lines)
+				continue
+			}
+		}
+		if len(lines) == 1 && isCapsLine(first) && !sceneBreakRe.MatchString(first) {
+			if last := t.nodes; len(last) > 0 && !t.content && isHeadingTag(last[len(last)-1].name) {
+				// second line of previous heading
+				h := last[len(last)-1]
+				h.children = append(h.children, elementNode("br", ""), textNode(first))
+				continue
+			}
+			t.heading(chapterLevel, []string{first})
+			continue
+		}
+		t.block(lines, b.blank)
+	}
+	return t.nodes
+}
+
+// isTitleLine report whether line can continue a heading
+func isTitleLine(line string, last bool) bool {
+	if utf8.RuneCountInString(line) > verseLength || isDialogue(line) {
+		return false
+	}
+	return last || !strings.HasSuffix(line, ".")
+}
+
+func quoted(lines []textLine) bool {
+	for _, l := range lines {
+		if !strings.HasPrefix(l.text, ">") {
+			return false
+		}
+	}
+	return true
+}
+
+// unquote return text of lines without ">" prefix
+func unquote(lines []textLine) string {
+	var b strings.Builder
+	for _, l := range lines {
+		s := strings.TrimPrefix(l.text[1:], " ")
+		b.WriteString(s + "\n")
+	}
+	return b.String()
+}
+
+// dedent return text of lines without common indent
+func dedent(lines []textLine) string {
+	min := minIndent(lines)
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(strings.Repeat(" ", l.indent-min) + l.text + "\n")
+	}
+	return b.String()
+}
+
+func (t *textBuilder) heading(level int, lines []string) {
+	t.nodes = append(t.nodes, elementNode("h"+strconv.Itoa(level), "", linesNode(lines)...))
+	t.content = false
+}
+
+// emptyLines add empty line for blank lines that don't separate paragraphs
+func (t *textBuilder) emptyLines(blank int) {
+	if t.blankSep {
+		blank--
+	}
+	if blank > 0 && t.content {
+		t.nodes = append(t.nodes, elementNode("hr", ""))
+	}
+}
+
+func (t *textBuilder) block(lines []textLine, blank int) {
+	texts := lineTexts(lines)
+	maxLength := verseLength
+	if t.width > 0 && t.width*3/4 < maxLength {
+		maxLength = t.width * 3 / 4
+	}
+	switch {
+	case len(lines) == 1 && sceneBreakRe.MatchString(texts[0]):
+		t.emptyLines(blank)
+		t.nodes = append(t.nodes, elementNode("p", "subtitle", textNode(texts[0])))
+	case isVerse(texts, maxLength):
+		stanza := elementNode("div", "stanza")
+		for _, l := range texts {
+			stanza.children = append(stanza.children, elementNode("p", "v", textNode(l)))
+		}
+		if last := t.nodes; len(last) > 0 && t.content && blank <= 2 && hasClass(last[len(last)-1], "poem") {
+			p := last[len(last)-1]
+			p.children = append(p.children, stanza)
+			return
+		}
+		t.emptyLines(blank)
+		t.nodes = append(t.nodes, elementNode("div", "poem", stanza))
+	case !t.quote && len(lines) > 1 && minIndent(lines) >= t.indent+4:
+		t.emptyLines(blank)
+		t.cite(dedent(lines))
+	default:
+		t.emptyLines(blank)
+		for _, p := range t.paragraphs(lines) {
+			t.nodes = append(t.nodes, elementNode("p", "", textNode(strings.Join(p, " "))))
+		}
+	}
+	t.content = true
+}
+
+// cite add blockquote with structured text, last line like "— Author"
+// is an author of citation
+func (t *textBuilder) cite(text string) {
+	lines := strings.Split(strings.TrimRightFunc(text, unicode.IsSpace), "\n")
+	var author *htmlNode
+	if len(lines) > 1 {
+		if name, ok := textAuthorLine(lines[len(lines)-1]); ok {
+			author = elementNode("p", "text-author", textNode(name))
+			lines = lines[:len(lines)-1]
+		}
+	}
+	q := newTextBuilder(splitBlocks(strings.Join(lines, "\n"), false), true)
+	nodes := q.build()
+	if author != nil {
+		nodes = append(nodes, author)
+	}
+	t.nodes = append(t.nodes, elementNode("blockquote", "", nodes...))
+	t.content = true
+}
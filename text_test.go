@@ -0,0 +1,61 @@
+package gofb2
+
+import (
+	"strings"
+	"testing"
+)
+
+const textBook = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0" xmlns:l="http://www.w3.org/1999/xlink">
+<description><title-info><book-title>Book</book-title></title-info></description>
+<body>
+<section><title><p>One</p></title>
+<p>The quick brown fox jumps over the lazy dog<a l:href="#n2" type="note">x</a>.</p>
+<p>Second<a l:href="#n1" type="note">y</a> and again<a l:href="#n2" type="note">z</a>.</p>
+</section>
+</body>
+<body name="notes">
+<section id="n1"><p>First note.</p></section>
+<section id="n2"><p>Second note.</p></section>
+</body>
+</FictionBook>`
+
+func TestWriteText(t *testing.T) {
+	var b strings.Builder
+	if err := WriteText(&b, parseBook(t, textBook), TextOptions{Width: 20, Indent: "  "}); err != nil {
+		t.Fatal(err)
+	}
+	// lines are wrapped at 20 characters, notes are numbered in order of
+	// the first reference and repeated reference keep its number
+	want := `        Book
+
+        One
+
+  The quick brown
+fox jumps over the
+lazy dog[1].
+  Second[2] and
+again[1].
+
+       Notes
+
+[1] Second note.
+
+[2] First note.
+`
+	if b.String() != want {
+		t.Errorf("text:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteTextNoNotes(t *testing.T) {
+	var b strings.Builder
+	if err := WriteText(&b, parseBook(t, textBook), TextOptions{NoNotes: true}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "[1]") || strings.Contains(b.String(), "Notes") {
+		t.Errorf("notes are written:\n%s", b.String())
+	}
+	if err := WriteText(&b, nil, TextOptions{}); err == nil {
+		t.Error("no error for nil book")
+	}
+}
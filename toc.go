@@ -0,0 +1,257 @@
+package gofb2
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TOCEntry is an entry of table of contents
+type TOCEntry struct {
+	// Title is a flattened text of section title
+	Title string
+	// ID of section, empty for section without id
+	ID string
+	// Anchor is an ID or generated anchor like "toc-1-2", empty for entry
+	// pointing to the beginning of File
+	Anchor string
+	// File is a name of document with section when book is split to
+	// several documents, empty for single document
+	File string
+	// Depth of entry, top level entries have depth 1
+	Depth int
+	// Words in section with subsections
+	Words    int
+	Section  *Section
+	Children []*TOCEntry
+}
+
+// TableOfContents is a tree of sections of book
+type TableOfContents struct {
+	Title   string
+	Entries []*TOCEntry
+}
+
+// untitledTOC is a title of entries of sections without title
+const untitledTOC = "* * *"
+
+// TOC return table of contents of book. Untitled sections with subsections
+// are skipped and their subsections take their place, untitled sections
+// without subsections get "* * *" title. Notes body is a single entry.
+func TOC(fb *FictionBook) *TableOfContents {
+	t := &tocBuilder{
+		ids:   map[string]bool{},
+		stats: &statsCounter{stats: &BookStats{}, words: map[string]int{}},
+	}
+	walk(bookContent(fb), func(c Contenter) bool {
+		if id := nodeID(c); id != "" {
+			t.ids[id] = true
+		}
+		return true
+	})
+	toc := &TableOfContents{}
+	if d := fb.Description; d != nil && d.TitleInfo != nil && d.TitleInfo.BookTitle != nil {
+		toc.Title = strings.TrimSpace(d.TitleInfo.BookTitle.Value)
+	}
+	if fb.Body != nil {
+		if toc.Title == "" {
+			toc.Title = titleText(fb.Body.Title)
+		}
+		for i, s := range fb.Body.Sections {
+			ss := t.stats.section(s, "")
+			toc.Entries = append(toc.Entries, t.section(s, ss, 1, []int{i + 1})...)
+		}
+	}
+	if nb := fb.NotesBody; nb != nil && len(nb.Sections) > 0 {
+		title := titleText(nb.Title)
+		if title == "" {
+			title = "Notes"
+		}
+		var words TextStats
+		for _, c := range bodyChildren(&nb.Body) {
+			words.add(t.stats.count(c, "", false))
+		}
+		// entry of notes body point to the first note
+		first := nb.Sections[0]
+		anchor := first.ID
+		if anchor == "" {
+			anchor = t.anchor("toc-" + nb.Name)
+		}
+		toc.Entries = append(toc.Entries, &TOCEntry{
+			Title:   title,
+			ID:      first.ID,
+			Anchor:  anchor,
+			Depth:   1,
+			Words:   words.Words,
+			Section: first,
+		})
+	}
+	return toc
+}
+
+type tocBuilder struct {
+	// ids used in book and generated anchors
+	ids   map[string]bool
+	stats *statsCounter
+}
+
+// section return entries of section, ss is a statistics of section and
+// path is a position of section in body
+func (t *tocBuilder) section(s *Section, ss *SectionStats, depth int, path []int) []*TOCEntry {
+	title := titleText(s.Title)
+	if title == "" && len(s.Sections) > 0 {
+		var res []*TOCEntry
+		for i, cs := range s.Sections {
+			res = append(res, t.section(cs, ss.Sections[i], depth, append(path[:len(path):len(path)], i+1))...)
+		}
+		return res
+	}
+	if title == "" {
+		title = untitledTOC
+	}
+	e := &TOCEntry{Title: title, ID: s.ID, Anchor: s.ID, Depth: depth, Words: ss.Words, Section: s}
+	if e.Anchor == "" {
+		parts := make([]string, len(path))
+		for i, n := range path {
+			parts[i] = strconv.Itoa(n)
+		}
+		e.Anchor = t.anchor("toc-" + strings.Join(parts, "-"))
+	}
+	for i, cs := range s.Sections {
+		e.Children = append(e.Children, t.section(cs, ss.Sections[i], depth+1, append(path[:len(path):len(path)], i+1))...)
+	}
+	return []*TOCEntry{e}
+}
+
+// anchor return unique anchor with prefix
+func (t *tocBuilder) anchor(prefix string) string {
+	a := prefix
+	for n := 2; t.ids[a]; n++ {
+		a = fmt.Sprintf("%s-%d", prefix, n)
+	}
+	t.ids[a] = true
+	return a
+}
+
+// AssignIDs set generated anchors as ids of sections without id,
+// so anchors can be used in exported book
+func (t *TableOfContents) AssignIDs() {
+	t.walk(func(e *TOCEntry) {
+		if e.Section != nil && e.Section.ID == "" {
+			e.Section.ID = e.Anchor
+		}
+		if e.Section != nil {
+			e.ID = e.Section.ID
+		}
+	})
+}
+
+func (t *TableOfContents) walk(fn func(e *TOCEntry)) {
+	var walk func([]*TOCEntry)
+	walk = func(entries []*TOCEntry) {
+		for _, e := range entries {
+			fn(e)
+			walk(e.Children)
+		}
+	}
+	walk(t.Entries)
+}
+
+// WriteText write entries as lines indented by depth
+func (t *TableOfContents) WriteText(w io.Writer) error {
+	h := &htmlWriter{w: w}
+	t.walk(func(e *TOCEntry) {
+		h.write(strings.Repeat("  ", e.Depth-1), e.Title, "\n")
+	})
+	return h.err
+}
+
+// WriteHTML write nav element with nested lists of links to anchors
+func (t *TableOfContents) WriteHTML(w io.Writer) error {
+	h := &htmlWriter{w: w}
+	h.write(`<nav class="toc">`, "\n")
+	if t.Title != "" {
+		h.write("<h1>")
+		h.text(t.Title)
+		h.write("</h1>\n")
+	}
+	t.list(h, t.Entries)
+	h.write("</nav>\n")
+	return h.err
+}
+
+// list write nested lists of links to entries
+func (t *TableOfContents) list(h *htmlWriter, entries []*TOCEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	h.write("<ol>\n")
+	for _, e := range entries {
+		h.write("<li>")
+		h.open("a", "href", e.href(""))
+		h.text(e.Title)
+		h.close("a")
+		if len(e.Children) > 0 {
+			h.write("\n")
+			t.list(h, e.Children)
+		}
+		h.write("</li>\n")
+	}
+	h.write("</ol>\n")
+}
+
+// href return link to entry, file is used for entry without File
+func (e *TOCEntry) href(file string) string {
+	if e.File != "" {
+		file = e.File
+	}
+	if e.Anchor == "" {
+		return file
+	}
+	return file + "#" + e.Anchor
+}
+
+// WriteNCX write EPUB 2 navigation document, uid is an identifier of book
+// and file is a name of document with anchors of entries without File
+func (t *TableOfContents) WriteNCX(w io.Writer, uid, file string) error {
+	h := &htmlWriter{w: w}
+	depth := 0
+	t.walk(func(e *TOCEntry) {
+		if e.Depth > depth {
+			depth = e.Depth
+		}
+	})
+	h.write(`<?xml version="1.0" encoding="utf-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+<head>
+`)
+	h.empty("meta", "name", "dtb:uid", "content", uid)
+	h.write("\n")
+	h.empty("meta", "name", "dtb:depth", "content", strconv.Itoa(depth))
+	h.write(`
+<meta name="dtb:totalPageCount" content="0"/>
+<meta name="dtb:maxPageNumber" content="0"/>
+</head>
+<docTitle><text>`)
+	h.text(t.Title)
+	h.write("</text></docTitle>\n<navMap>\n")
+	order := 0
+	var points func([]*TOCEntry)
+	points = func(entries []*TOCEntry) {
+		for _, e := range entries {
+			order++
+			h.open("navPoint", "id", "np-"+strconv.Itoa(order), "playOrder", strconv.Itoa(order))
+			h.write("<navLabel><text>")
+			h.text(e.Title)
+			h.write("</text></navLabel>")
+			h.empty("content", "src", e.href(file))
+			h.write("\n")
+			points(e.Children)
+			h.write("</navPoint>\n")
+		}
+	}
+	points(t.Entries)
+	h.write("</navMap>\n</ncx>\n")
+	return h.err
+}
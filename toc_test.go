@@ -0,0 +1,76 @@
+package gofb2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const tocBook = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0">
+<body>
+<section id="one"><title><p>One</p></title>
+ <section><title><p>One.1</p></title><p>a b</p></section>
+ <section><p>c</p></section>
+</section>
+<section><section><title><p>Two</p></title><p>d e f</p></section></section>
+</body>
+<body name="notes"><section id="n1"><p>note</p></section></body>
+</FictionBook>`
+
+func TestTOC(t *testing.T) {
+	toc := TOC(parseBook(t, tocBook))
+	var b strings.Builder
+	if err := toc.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := "One\n  One.1\n  * * *\nTwo\nNotes\n"
+	if b.String() != want {
+		t.Errorf("toc = %q, want %q", b.String(), want)
+	}
+	var anchors []string
+	toc.walk(func(e *TOCEntry) {
+		anchors = append(anchors, e.Anchor)
+	})
+	if got := strings.Join(anchors, " "); got != "one toc-1-1 toc-1-2 toc-2-1 n1" {
+		t.Errorf("anchors = %q", got)
+	}
+}
+
+func TestWriteNCXFiles(t *testing.T) {
+	toc := TOC(parseBook(t, tocBook))
+	toc.Entries[1].File = "two.html"
+	var b strings.Builder
+	if err := toc.WriteNCX(&b, "urn:x", "book.html"); err != nil {
+		t.Fatal(err)
+	}
+	for _, src := range []string{`"book.html#one"`, `"book.html#toc-1-1"`, `"two.html#toc-2-1"`} {
+		if !strings.Contains(b.String(), "src="+src) {
+			t.Errorf("no %s in %s", src, b.String())
+		}
+	}
+}
+
+func TestEPUBNav(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, parseBook(t, tocBook), EPUBOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	files := epubFiles(t, buf.Bytes())
+	nav := files["OEBPS/"+epubNav]
+	links := []struct{ href, id string }{
+		{"chapter-001.xhtml#one", "one"},
+		{"chapter-001.xhtml#toc-1-1", "toc-1-1"},
+		{"chapter-001.xhtml#toc-1-2", "toc-1-2"},
+		{"chapter-002.xhtml#toc-2-1", "toc-2-1"},
+		{"notes.xhtml#n1", "n1"},
+	}
+	for _, l := range links {
+		if !strings.Contains(nav, `href="`+l.href+`"`) {
+			t.Errorf("no link %s in nav:\n%s", l.href, nav)
+		}
+		file := "OEBPS/" + strings.SplitN(l.href, "#", 2)[0]
+		if !strings.Contains(files[file], `id="`+l.id+`"`) {
+			t.Errorf("no anchor %s in %s", l.id, file)
+		}
+	}
+}
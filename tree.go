@@ -0,0 +1,444 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Tree is a mutable view of book. It tracks parents of nodes and index
+// of ids, so nodes can be removed, inserted, moved and replaced in place.
+// While tree is used book should be changed only by its methods,
+// otherwise call Reindex. Text (CharData) is not a node of tree, change
+// it with ReplaceText or through its parent.
+type Tree struct {
+	fb      *FictionBook
+	parents map[Node]Node
+	ids     map[string]Contenter
+}
+
+// NewTree return tree of book bodies
+func NewTree(fb *FictionBook) *Tree {
+	t := &Tree{fb: fb}
+	t.Reindex()
+	return t
+}
+
+// Reindex rebuild parents and ids after book was changed directly
+func (t *Tree) Reindex() {
+	t.parents = map[Node]Node{}
+	t.ids = map[string]Contenter{}
+	if t.fb.Body != nil {
+		for _, c := range bodyChildren(t.fb.Body) {
+			t.index(t.fb.Body, c)
+		}
+	}
+	if t.fb.NotesBody != nil {
+		for _, c := range bodyChildren(&t.fb.NotesBody.Body) {
+			t.index(t.fb.NotesBody, c)
+		}
+	}
+}
+
+// index add parents and ids of n and its descendants, first node with id wins
+func (t *Tree) index(parent Node, n Contenter) {
+	node, ok := n.(Node)
+	if !ok {
+		return
+	}
+	t.parents[node] = parent
+	if id := nodeID(n); id != "" {
+		if _, ok := t.ids[id]; !ok {
+			t.ids[id] = n
+		}
+	}
+	for _, c := range children(n) {
+		t.index(node, c)
+	}
+}
+
+// unindex remove n and its descendants from parents and ids
+func (t *Tree) unindex(n Contenter) {
+	node, ok := n.(Node)
+	if !ok {
+		return
+	}
+	delete(t.parents, node)
+	if id := nodeID(n); id != "" && t.ids[id] == n {
+		delete(t.ids, id)
+	}
+	for _, c := range children(n) {
+		t.unindex(c)
+	}
+}
+
+// Parent return parent of node or nil if node is not in tree,
+// parent of top level section is *Body or *NotesBody
+func (t *Tree) Parent(n Contenter) Node {
+	if node, ok := n.(Node); ok {
+		return t.parents[node]
+	}
+	return nil
+}
+
+// ByID return node with id or nil
+func (t *Tree) ByID(id string) Contenter {
+	return t.ids[id]
+}
+
+// SetID change id of node, empty id remove it
+func (t *Tree) SetID(n Contenter, id string) error {
+	if other, ok := t.ids[id]; ok && other != n && id != "" {
+		return fmt.Errorf("duplicate id %s", id)
+	}
+	old := nodeID(n)
+	if !setNodeID(n, id) {
+		return fmt.Errorf("node %s can't have id", n.GetXMLName().Local)
+	}
+	if old != "" && t.ids[old] == n {
+		delete(t.ids, old)
+	}
+	if id != "" && t.Parent(n) != nil {
+		t.ids[id] = n
+	}
+	return nil
+}
+
+// childList is a field of parent holding child nodes
+type childList struct {
+	parent Node
+	// v is a slice or a pointer field
+	v reflect.Value
+	// name of node kind for lists of *P like text-author
+	name string
+}
+
+func (l childList) single() bool {
+	return l.v.Kind() == reflect.Ptr
+}
+
+func (l childList) get() []Contenter {
+	if l.single() {
+		if l.v.IsNil() {
+			return nil
+		}
+		return []Contenter{l.v.Interface().(Contenter)}
+	}
+	res := make([]Contenter, l.v.Len())
+	for i := range res {
+		res[i] = l.v.Index(i).Interface().(Contenter)
+	}
+	return res
+}
+
+// set replace nodes of list, nodes must be accepted by list
+func (l childList) set(cont []Contenter) {
+	if l.single() {
+		if len(cont) == 0 {
+			l.v.Set(reflect.Zero(l.v.Type()))
+		} else {
+			l.v.Set(reflect.ValueOf(cont[0]))
+		}
+		return
+	}
+	s := reflect.MakeSlice(l.v.Type(), len(cont), len(cont))
+	for i, c := range cont {
+		s.Index(i).Set(reflect.ValueOf(c))
+	}
+	l.v.Set(s)
+}
+
+func (l childList) isContent() bool {
+	return l.v.Type() == reflect.TypeOf([]Contenter{})
+}
+
+// accepts report whether n can be stored in list
+func (l childList) accepts(n Contenter) bool {
+	if !l.isContent() {
+		t := l.v.Type()
+		if !l.single() {
+			t = t.Elem()
+		}
+		return reflect.TypeOf(n) == t && (l.name == "" || n.GetXMLName().Local == l.name)
+	}
+	for _, other := range childLists(l.parent) {
+		if other.name != "" && other.name == n.GetXMLName().Local {
+			return false
+		}
+	}
+	// parse callbacks of empty parent know allowed content
+	probe := reflect.New(reflect.TypeOf(l.parent).Elem()).Interface().(Node)
+	if cd, ok := n.(CharData); ok {
+		if probe.charDataCallback(xml.CharData(cd)) != nil {
+			return false
+		}
+		content := reflect.ValueOf(probe).Elem().FieldByName("Content")
+		return content.Len() > 0
+	}
+	child, err := probe.tagCallback(xml.StartElement{Name: n.GetXMLName()})
+	return err == nil && reflect.TypeOf(child) == reflect.TypeOf(n)
+}
+
+// childLists return lists of children of parent, sections, epigraphs
+// and other structural elements go before content
+func childLists(parent Node) []childList {
+	list := func(ptr interface{}, name string) childList {
+		return childList{parent: parent, v: reflect.ValueOf(ptr).Elem(), name: name}
+	}
+	switch e := parent.(type) {
+	case *NotesBody:
+		return []childList{list(&e.Image, "image"), list(&e.Title, "title"), list(&e.Epigraphs, "epigraph"), list(&e.Sections, "section")}
+	case *Body:
+		return []childList{list(&e.Image, "image"), list(&e.Title, "title"), list(&e.Epigraphs, "epigraph"), list(&e.Sections, "section")}
+	case *Section:
+		return []childList{list(&e.Title, "title"), list(&e.Epigraphs, "epigraph"), list(&e.Image, ""),
+			list(&e.Annotation, "annotation"), list(&e.Sections, "section"), list(&e.Content, "")}
+	case *Poem:
+		return []childList{list(&e.Title, "title"), list(&e.Epigraphs, "epigraph"), list(&e.Content, "")}
+	case *Stanza:
+		return []childList{list(&e.Title, "title"), list(&e.Subtitle, "subtitle"), list(&e.V, "v")}
+	case *Cite:
+		return []childList{list(&e.Content, ""), list(&e.TextAuthor, "text-author")}
+	case *Epigraph:
+		return []childList{list(&e.Content, ""), list(&e.TextAuthor, "text-author")}
+	case *Table:
+		return []childList{list(&e.TR, "tr")}
+	}
+	if v := reflect.ValueOf(parent).Elem().FieldByName("Content"); v.IsValid() {
+		return []childList{{parent: parent, v: v}}
+	}
+	return nil
+}
+
+// position return list containing n and index of n in it
+func (t *Tree) position(n Contenter) (childList, int, error) {
+	parent := t.Parent(n)
+	if parent == nil {
+		return childList{}, 0, fmt.Errorf("node %s is not in tree", n.GetXMLName().Local)
+	}
+	for _, l := range childLists(parent) {
+		for i, c := range l.get() {
+			if c == n {
+				return l, i, nil
+			}
+		}
+	}
+	return childList{}, 0, fmt.Errorf("node %s is not found in parent", n.GetXMLName().Local)
+}
+
+// check return error if n can't be inserted to list
+func (t *Tree) check(l childList, n Contenter, replaced Contenter) error {
+	node, ok := n.(Node)
+	if !ok {
+		return fmt.Errorf("text can't be inserted, use parent node")
+	}
+	if !l.accepts(n) {
+		return fmt.Errorf("node %s is not allowed in %s", n.GetXMLName().Local, l.parent.GetXMLName().Local)
+	}
+	if l.single() && !l.v.IsNil() && l.v.Interface() != replaced {
+		return fmt.Errorf("%s already has %s", l.parent.GetXMLName().Local, n.GetXMLName().Local)
+	}
+	if s, ok := l.parent.(*Section); ok && replaced == nil {
+		if l.isContent() && len(s.Sections) > 0 || l.name == "section" && len(s.Content) > 0 {
+			return fmt.Errorf("section can't have both content and subsections")
+		}
+	}
+	for p := l.parent; p != nil; p = t.parents[p] {
+		if p == node {
+			return fmt.Errorf("node %s can't be inserted into itself", n.GetXMLName().Local)
+		}
+	}
+	var err error
+	walk([]Contenter{n}, func(c Contenter) bool {
+		if id := nodeID(c); id != "" && err == nil {
+			if other, ok := t.ids[id]; ok && other != c {
+				err = fmt.Errorf("duplicate id %s", id)
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// insert put n to list at index i, n is moved if it is in tree
+func (t *Tree) insert(l childList, i int, n Contenter) error {
+	if err := t.check(l, n, nil); err != nil {
+		return err
+	}
+	if t.Parent(n) != nil {
+		ol, oi, err := t.position(n)
+		if err != nil {
+			return err
+		}
+		cont := ol.get()
+		ol.set(append(cont[:oi:oi], cont[oi+1:]...))
+		if ol.v.UnsafeAddr() == l.v.UnsafeAddr() && oi < i {
+			i--
+		}
+		t.unindex(n)
+	}
+	cont := l.get()
+	res := make([]Contenter, 0, len(cont)+1)
+	res = append(append(append(res, cont[:i]...), n), cont[i:]...)
+	l.set(res)
+	t.index(l.parent, n)
+	return nil
+}
+
+// Remove delete node from tree
+func (t *Tree) Remove(n Contenter) error {
+	l, i, err := t.position(n)
+	if err != nil {
+		return err
+	}
+	cont := l.get()
+	l.set(append(cont[:i:i], cont[i+1:]...))
+	t.unindex(n)
+	return nil
+}
+
+// InsertBefore insert n before ref, n is moved if it is in tree
+func (t *Tree) InsertBefore(ref, n Contenter) error {
+	l, i, err := t.position(ref)
+	if err != nil {
+		return err
+	}
+	return t.insert(l, i, n)
+}
+
+// InsertAfter insert n after ref, n is moved if it is in tree
+func (t *Tree) InsertAfter(ref, n Contenter) error {
+	l, i, err := t.position(ref)
+	if err != nil {
+		return err
+	}
+	return t.insert(l, i+1, n)
+}
+
+// Append add n as the last child of parent, n is moved if it is in tree.
+// Titles, images and annotations fill empty fields of parent.
+func (t *Tree) Append(parent Node, n Contenter) error {
+	lists := childLists(parent)
+	for _, l := range lists {
+		if !l.single() && l.accepts(n) {
+			return t.insert(l, l.v.Len(), n)
+		}
+	}
+	for _, l := range lists {
+		if l.single() && l.accepts(n) {
+			return t.insert(l, 0, n)
+		}
+	}
+	return fmt.Errorf("node %s is not allowed in %s", n.GetXMLName().Local, parent.GetXMLName().Local)
+}
+
+// Replace put n instead of old
+func (t *Tree) Replace(old, n Contenter) error {
+	l, i, err := t.position(old)
+	if err != nil {
+		return err
+	}
+	if err := t.check(l, n, old); err != nil {
+		return err
+	}
+	if t.Parent(n) != nil {
+		if err := t.Remove(n); err != nil {
+			return err
+		}
+		if l, i, err = t.position(old); err != nil {
+			return err
+		}
+	}
+	cont := l.get()
+	cont[i] = n
+	l.set(cont)
+	t.unindex(old)
+	t.index(l.parent, n)
+	return nil
+}
+
+// Wrap put wrapper instead of n and n into wrapper,
+// e.g. wrap paragraph into cite or text style into emphasis
+func (t *Tree) Wrap(n, wrapper Contenter) error {
+	w, ok := wrapper.(Node)
+	if !ok {
+		return fmt.Errorf("text can't be a wrapper")
+	}
+	var inner *childList
+	for _, l := range childLists(w) {
+		if !l.single() && l.accepts(n) {
+			inner = &l
+			break
+		}
+	}
+	if inner == nil {
+		return fmt.Errorf("node %s is not allowed in %s", n.GetXMLName().Local, w.GetXMLName().Local)
+	}
+	if err := t.Replace(n, wrapper); err != nil {
+		return err
+	}
+	inner.set(append(inner.get(), n))
+	t.index(w, n)
+	return nil
+}
+
+// Unwrap put children of n instead of n. For section its subsections or
+// content are used, title, epigraphs and other fields are dropped.
+func (t *Tree) Unwrap(n Contenter) error {
+	l, i, err := t.position(n)
+	if err != nil {
+		return err
+	}
+	var cont []Contenter
+	for _, cl := range childLists(n.(Node)) {
+		if !cl.single() && (cl.isContent() || cl.name == "section") && cl.v.Len() > 0 {
+			cont = cl.get()
+			break
+		}
+	}
+	for _, c := range cont {
+		if !l.accepts(c) {
+			return fmt.Errorf("node %s is not allowed in %s", c.GetXMLName().Local, l.parent.GetXMLName().Local)
+		}
+	}
+	items := l.get()
+	res := make([]Contenter, 0, len(items)+len(cont))
+	res = append(append(append(res, items[:i]...), cont...), items[i+1:]...)
+	if l.single() && len(res) > 1 {
+		return fmt.Errorf("%s can have only one %s", l.parent.GetXMLName().Local, l.name)
+	}
+	t.unindex(n)
+	l.set(res)
+	for _, c := range cont {
+		t.index(l.parent, c)
+	}
+	return nil
+}
+
+// ReplaceText replace old text with new in n and its descendants, return
+// count of replacements. Text split by styles is not matched, empty old
+// text is not replaced.
+func (t *Tree) ReplaceText(n Contenter, old, new string) int {
+	if old == "" {
+		return 0
+	}
+	count := 0
+	walk([]Contenter{n}, func(c Contenter) bool {
+		if _, ok := c.(CharData); ok {
+			return false
+		}
+		content := reflect.ValueOf(c).Elem().FieldByName("Content")
+		if !content.IsValid() || content.Type() != reflect.TypeOf([]Contenter{}) {
+			return true
+		}
+		for i, cc := range content.Interface().([]Contenter) {
+			if cd, ok := cc.(CharData); ok && strings.Contains(string(cd), old) {
+				count += strings.Count(string(cd), old)
+				content.Index(i).Set(reflect.ValueOf(CharData(strings.Replace(string(cd), old, new, -1))))
+			}
+		}
+		return true
+	})
+	return count
+}
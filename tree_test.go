@@ -0,0 +1,122 @@
+package gofb2
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+const treeBook = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0">
+<body>
+<section id="s1"><title><p>One</p></title><p id="p1">first text</p><p id="p2">second <emphasis>text</emphasis></p></section>
+<section id="s2"><p id="p3">third</p></section>
+</body>
+<body name="notes"><section id="n1"><p>note text</p></section></body>
+</FictionBook>`
+
+func TestTreeIndex(t *testing.T) {
+	fb := parseBook(t, treeBook)
+	tr := NewTree(fb)
+	s1, p1 := fb.Body.Sections[0], tr.ByID("p1")
+	if p1 != s1.Content[0] {
+		t.Fatalf("ByID(p1) = %v", p1)
+	}
+	if tr.Parent(p1) != s1 || tr.Parent(s1) != fb.Body || tr.Parent(tr.ByID("n1")) != fb.NotesBody {
+		t.Error("wrong parents")
+	}
+	if tr.Parent(newP("p")) != nil {
+		t.Error("parent of node out of tree")
+	}
+
+	if err := tr.SetID(p1, "p3"); err == nil {
+		t.Error("duplicate id is set")
+	}
+	if err := tr.SetID(p1, "intro"); err != nil {
+		t.Fatal(err)
+	}
+	if tr.ByID("p1") != nil || tr.ByID("intro") != p1 {
+		t.Error("ids are not updated by SetID")
+	}
+	if err := tr.SetID(&EmptyLine{}, "x"); err == nil {
+		t.Error("id is set to empty-line")
+	}
+
+	if err := tr.Remove(p1); err != nil {
+		t.Fatal(err)
+	}
+	if len(s1.Content) != 1 || tr.ByID("intro") != nil || tr.Parent(p1) != nil {
+		t.Error("removed node is in tree")
+	}
+	if err := tr.Remove(p1); err == nil {
+		t.Error("node out of tree is removed")
+	}
+}
+
+func TestTreeMove(t *testing.T) {
+	fb := parseBook(t, treeBook)
+	tr := NewTree(fb)
+	s1, s2 := fb.Body.Sections[0], fb.Body.Sections[1]
+	p3 := tr.ByID("p3")
+
+	// node in tree is moved
+	if err := tr.InsertBefore(tr.ByID("p1"), p3); err != nil {
+		t.Fatal(err)
+	}
+	if len(s2.Content) != 0 || s1.Content[0] != p3 || tr.Parent(p3) != s1 {
+		t.Error("p3 is not moved")
+	}
+	if err := tr.InsertAfter(s1.Content[0], s1); err == nil {
+		t.Error("section is inserted into content")
+	}
+
+	cite := &Cite{}
+	cite.SetXMLName(xml.Name{Local: "cite"})
+	if err := tr.Wrap(p3, cite); err != nil {
+		t.Fatal(err)
+	}
+	if s1.Content[0] != cite || tr.Parent(p3) != cite || tr.Parent(cite) != s1 {
+		t.Error("p3 is not wrapped")
+	}
+	if err := tr.Unwrap(cite); err != nil {
+		t.Fatal(err)
+	}
+	if s1.Content[0] != p3 || tr.Parent(p3) != s1 || tr.Parent(cite) != nil {
+		t.Error("cite is not unwrapped")
+	}
+
+	p := newP("p")
+	p.ID = "new"
+	if err := tr.Replace(p3, p); err != nil {
+		t.Fatal(err)
+	}
+	if tr.ByID("p3") != nil || tr.ByID("new") != p || s1.Content[0] != p {
+		t.Error("p3 is not replaced")
+	}
+	if err := tr.Append(s2, p3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Append(p3.(*P), s1); err == nil {
+		t.Error("section is appended to paragraph")
+	}
+}
+
+func TestTreeReplaceText(t *testing.T) {
+	fb := parseBook(t, treeBook)
+	tr := NewTree(fb)
+	s1 := fb.Body.Sections[0]
+	if n := tr.ReplaceText(s1, "text", "word"); n != 2 {
+		t.Errorf("replaced %d, want 2", n)
+	}
+	if got := contentText(s1.Content); got != "first word second word" {
+		t.Errorf("text = %q", got)
+	}
+	if n := tr.ReplaceText(s1, "", "x"); n != 0 {
+		t.Errorf("empty text is replaced %d times", n)
+	}
+	if got := contentText(s1.Content); got != "first word second word" {
+		t.Errorf("text = %q after replacing empty text", got)
+	}
+	// text of title and other sections is not changed
+	if titleText(s1.Title) != "One" || contentText(fb.NotesBody.Sections[0].Content) != "note text" {
+		t.Error("text outside of section is changed")
+	}
+}
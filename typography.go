@@ -0,0 +1,211 @@
+package gofb2
+
+import (
+	"strings"
+	"unicode"
+)
+
+// typoRules is a set of typographic rules of language
+type typoRules struct {
+	// quotes of first and second nesting level
+	quotes [2][2]rune
+	// shortWords are bound to the next word with non-breaking space
+	shortWords map[string]bool
+	// spacedDash is set when dash is separated by spaces,
+	// otherwise spaces around dash are removed
+	spacedDash bool
+	// apostrophe replace straight single quotes
+	apostrophe bool
+}
+
+var typoLangs = map[string]*typoRules{
+	"ru": {
+		quotes:     [2][2]rune{{'«', '»'}, {'„', '“'}},
+		shortWords: wordSet("а в во и к ко о об обо от с со у на за по до из не ни но да"),
+		spacedDash: true,
+	},
+	"en": {
+		quotes:     [2][2]rune{{'“', '”'}, {'‘', '’'}},
+		shortWords: wordSet("a an i in on at to by of or"),
+		apostrophe: true,
+	},
+}
+
+func wordSet(words string) map[string]bool {
+	res := map[string]bool{}
+	for _, w := range strings.Fields(words) {
+		res[w] = true
+	}
+	return res
+}
+
+// typoLang return rules for language tag like "ru" or "en-US" or nil
+// for unsupported language
+func typoLang(lang string) *typoRules {
+	return typoLangs[baseLang(lang)]
+}
+
+// Typography replace straight quotes, hyphens used as dashes and three dots
+// with typographic characters and bind short words to the next word with
+// non-breaking space. Rules are chosen by effective language of paragraph,
+// text in unsupported languages and code spans are not changed.
+func Typography(fb *FictionBook) {
+	walkLang(fb, func(c Contenter, lang string) bool {
+		switch e := c.(type) {
+		case *P:
+			typographParagraph(e.Content, lang)
+			return false
+		case *TD:
+			typographParagraph(e.Content, lang)
+			return false
+		}
+		return true
+	})
+}
+
+// typoText is a text of paragraph joined from all its char data
+type typoText struct {
+	runes []rune
+	// refs point to char data nodes, spans are their offsets in runes
+	refs  []*Contenter
+	spans [][2]int
+}
+
+// objectRune replace code spans and images in text of paragraph
+const objectRune = '\uFFFC'
+
+func (t *typoText) add(cont []Contenter) {
+	for i, c := range cont {
+		if cd, ok := c.(CharData); ok {
+			start := len(t.runes)
+			t.runes = append(t.runes, []rune(string(cd))...)
+			t.refs = append(t.refs, &cont[i])
+			t.spans = append(t.spans, [2]int{start, len(t.runes)})
+			continue
+		}
+		if content := inlineContent(c); content != nil && !isCode(c) {
+			t.add(*content)
+			continue
+		}
+		t.runes = append(t.runes, objectRune)
+	}
+}
+
+// inlineContent return content of style or link node or nil for other nodes
+func inlineContent(c Contenter) *[]Contenter {
+	if l, ok := c.(*Link); ok {
+		return &l.Content
+	}
+	return styleContent(c)
+}
+
+func (t *typoText) at(i int) rune {
+	if i < 0 || i >= len(t.runes) {
+		return ' '
+	}
+	return t.runes[i]
+}
+
+func typographParagraph(cont []Contenter, lang string) {
+	rules := typoLang(lang)
+	if rules == nil {
+		return
+	}
+	t := &typoText{}
+	t.add(cont)
+	out := make([]string, len(t.runes))
+	for i, r := range t.runes {
+		out[i] = string(r)
+	}
+
+	depth := 0
+	for i, r := range t.runes {
+		prev, next := t.at(i-1), t.at(i+1)
+		switch {
+		case r == '"':
+			if i == 0 || unicode.IsSpace(prev) || strings.ContainsRune("([{/—–-", prev) || isOpenQuote(rules, prev) {
+				out[i] = string(rules.quotes[depth%2][0])
+				depth++
+			} else {
+				if depth > 0 {
+					depth--
+				}
+				out[i] = string(rules.quotes[depth%2][1])
+			}
+		case isOpenQuote(rules, r):
+			depth++
+		case depth > 0 && isCloseQuote(rules, r) && !isWordRune(next):
+			depth--
+		case r == '\'' && rules.apostrophe:
+			if !isWordRune(prev) && isWordRune(next) {
+				out[i] = "‘"
+			} else {
+				out[i] = "’"
+			}
+		case r == '.':
+			if prev != '.' && next == '.' && t.at(i+2) == '.' && t.at(i+3) != '.' {
+				out[i], out[i+1], out[i+2] = "…", "", ""
+			}
+		case r == '-' || r == '–' || r == '—':
+			end := i + 1
+			if r == '-' && next == '-' {
+				end++
+			}
+			if !unicode.IsSpace(prev) || !unicode.IsSpace(t.at(end)) {
+				break
+			}
+			out[i] = "—"
+			for j := i + 1; j < end; j++ {
+				out[j] = ""
+			}
+			typographDash(t, out, i, end, rules)
+		case r == ' ':
+			if rules.shortWords[strings.ToLower(t.wordBefore(i))] && !unicode.IsSpace(next) && next != objectRune {
+				out[i] = "\u00a0"
+			}
+		}
+	}
+
+	for k, ref := range t.refs {
+		*ref = CharData(strings.Join(out[t.spans[k][0]:t.spans[k][1]], ""))
+	}
+}
+
+// typographDash set spaces around dash at runes[i:end]
+func typographDash(t *typoText, out []string, i, end int, rules *typoRules) {
+	before, after := i > 0 && t.at(i-1) == ' ', t.at(end) == ' ' && end < len(t.runes)
+	switch {
+	case i == 0 && after && rules.spacedDash:
+		// dash of dialogue
+		out[end] = "\u00a0"
+	case i == 0:
+	case rules.spacedDash && before:
+		out[i-1] = "\u00a0"
+	case !rules.spacedDash && before && after:
+		out[i-1], out[end] = "", ""
+	}
+}
+
+func isOpenQuote(rules *typoRules, r rune) bool {
+	return r == rules.quotes[0][0] || r == rules.quotes[1][0]
+}
+
+func isCloseQuote(rules *typoRules, r rune) bool {
+	return r == rules.quotes[0][1] || r == rules.quotes[1][1]
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordBefore return word ending at runes[i-1]
+func (t *typoText) wordBefore(i int) string {
+	start := i
+	for start > 0 && unicode.IsLetter(t.runes[start-1]) {
+		start--
+	}
+	if start > 0 && isWordRune(t.runes[start-1]) {
+		return ""
+	}
+	return string(t.runes[start:i])
+}
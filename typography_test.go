@@ -0,0 +1,45 @@
+package gofb2
+
+import "testing"
+
+func TestTypography(t *testing.T) {
+	tests := []struct {
+		lang string
+		in   string
+		want string
+	}{
+		{"ru", `"Он сказал: "да"".`, "«Он сказал: „да“»."},
+		{"ru", "- Иди в дом - сказал он...", "—\u00a0Иди в\u00a0дом\u00a0— сказал он…"},
+		{"ru", "Всё -- ложь", "Всё\u00a0— ложь"},
+		{"ru", "кое-как", "кое-как"},
+		{"en", `He said "it's 'fine'".`, "He said “it’s ‘fine’”."},
+		{"en", "Wait - a moment", "Wait—a\u00a0moment"},
+		{"en-US", "go to bed", "go to\u00a0bed"},
+		{"fr", `"oui"`, `"oui"`},
+	}
+	for _, tt := range tests {
+		fb := parseBook(t, `<FictionBook><description><title-info><lang>`+tt.lang+
+			`</lang></title-info></description><body><section><p>`+tt.in+`</p></section></body></FictionBook>`)
+		Typography(fb)
+		if got := contentText(fb.Body.Sections[0].Content[0].(*P).Content); got != tt.want {
+			t.Errorf("%s: %q typographed to %q, want %q", tt.lang, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTypographyInline(t *testing.T) {
+	fb := parseBook(t, `<FictionBook><description><title-info><lang>en</lang></title-info></description>
+<body><section xml:lang="ru"><p>"Слово <emphasis>в</emphasis> тексте"</p><p>код <code>"x" - y</code> - "z"</p>
+<p xml:lang="en">"in" <strong>"out"</strong></p></section></body></FictionBook>`)
+	Typography(fb)
+	want := []string{"«Слово в\u00a0тексте»", "код \"x\" - y\u00a0— «z»", "“in” “out”"}
+	for i, c := range fb.Body.Sections[0].Content {
+		if got := contentText(c.(*P).Content); got != want[i] {
+			t.Errorf("paragraph %d typographed to %q, want %q", i, got, want[i])
+		}
+	}
+	em := fb.Body.Sections[0].Content[0].(*P).Content[1].(*StyleType)
+	if got := contentText(em.Content); got != "в" {
+		t.Errorf("emphasis typographed to %q, want %q", got, "в")
+	}
+}
@@ -0,0 +1,167 @@
+package gofb2
+
+// children return all child nodes of c including titles, epigraphs,
+// text-authors and other structural elements not returned by GetContent
+func children(c Contenter) []Contenter {
+	var res []Contenter
+	add := func(cs ...Contenter) {
+		res = append(res, cs...)
+	}
+	switch e := c.(type) {
+	case *Section:
+		if e.Title != nil {
+			add(e.Title)
+		}
+		for _, ep := range e.Epigraphs {
+			add(ep)
+		}
+		if e.Image != nil {
+			add(e.Image)
+		}
+		if e.Annotation != nil {
+			add(e.Annotation)
+		}
+		for _, s := range e.Sections {
+			add(s)
+		}
+		add(e.Content...)
+	case *Poem:
+		if e.Title != nil {
+			add(e.Title)
+		}
+		for _, ep := range e.Epigraphs {
+			add(ep)
+		}
+		add(e.Content...)
+	case *Stanza:
+		if e.Title != nil {
+			add(e.Title)
+		}
+		if e.Subtitle != nil {
+			add(e.Subtitle)
+		}
+		for _, v := range e.V {
+			add(v)
+		}
+	case *Cite:
+		add(e.Content...)
+		for _, a := range e.TextAuthor {
+			add(a)
+		}
+	case *Epigraph:
+		add(e.Content...)
+		for _, a := range e.TextAuthor {
+			add(a)
+		}
+	default:
+		add(c.GetContent()...)
+	}
+	return res
+}
+
+// bodyChildren return top level nodes of body
+func bodyChildren(b *Body) []Contenter {
+	var res []Contenter
+	if b.Image != nil {
+		res = append(res, b.Image)
+	}
+	if b.Title != nil {
+		res = append(res, b.Title)
+	}
+	for _, ep := range b.Epigraphs {
+		res = append(res, ep)
+	}
+	for _, s := range b.Sections {
+		res = append(res, s)
+	}
+	return res
+}
+
+// walk traverse nodes in depth-first order.
+// Children of a node are skipped if fn return false.
+func walk(cont []Contenter, fn func(Contenter) bool) {
+	for _, c := range cont {
+		if fn(c) {
+			walk(children(c), fn)
+		}
+	}
+}
+
+// nodeID return id attribute of node
+func nodeID(c Contenter) string {
+	switch e := c.(type) {
+	case *Section:
+		return e.ID
+	case *P:
+		return e.ID
+	case *Cite:
+		return e.ID
+	case *Epigraph:
+		return e.ID
+	case *Annotation:
+		return e.ID
+	case *Table:
+		return e.ID
+	case *TD:
+		return e.ID
+	case *Image:
+		return e.ID
+	}
+	return ""
+}
+
+// setNodeID change id attribute of node, return false if node has no id
+func setNodeID(c Contenter, id string) bool {
+	switch e := c.(type) {
+	case *Section:
+		e.ID = id
+	case *P:
+		e.ID = id
+	case *Cite:
+		e.ID = id
+	case *Epigraph:
+		e.ID = id
+	case *Annotation:
+		e.ID = id
+	case *Table:
+		e.ID = id
+	case *TD:
+		e.ID = id
+	case *Image:
+		e.ID = id
+	default:
+		return false
+	}
+	return true
+}
+
+// bookContent return top level nodes with text: bodies and annotations
+// of description
+func bookContent(fb *FictionBook) []Contenter {
+	var res []Contenter
+	if fb.Description != nil {
+		res = append(res, descriptionContent(fb.Description)...)
+	}
+	if fb.Body != nil {
+		res = append(res, bodyChildren(fb.Body)...)
+	}
+	if fb.NotesBody != nil {
+		res = append(res, bodyChildren(&fb.NotesBody.Body)...)
+	}
+	return res
+}
+
+// descriptionContent return annotations of title-info and history
+// of document-info
+func descriptionContent(d *Description) []Contenter {
+	var res []Contenter
+	for _, ti := range []*TitleInfo{d.TitleInfo, d.SrcTitleInfo} {
+		if ti != nil && ti.Annotation != nil {
+			res = append(res, ti.Annotation)
+		}
+	}
+	if d.DocumentInfo != nil && d.DocumentInfo.History != nil {
+		res = append(res, d.DocumentInfo.History)
+	}
+	return res
+}
@@ -1,7 +1,10 @@
 package gofb2
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -9,19 +12,148 @@ const (
 	dateFormat = "2006-01-02"
 )
 
-// XMLDate is a time.Time wrapper for correct unmarshalling
+// DatePrecision is a precision of XMLDate
+type DatePrecision int
+
+// Precisions of dates, day is the default one. Values are not ordered
+// by precision, from the least precise it is year, month, day and time.
+const (
+	PrecisionDay DatePrecision = iota
+	PrecisionMonth
+	PrecisionYear
+	PrecisionTime
+)
+
+// rank return order of precision from 0 for year to 3 for time
+func (p DatePrecision) rank() int {
+	switch p {
+	case PrecisionYear:
+		return 0
+	case PrecisionMonth:
+		return 1
+	case PrecisionTime:
+		return 3
+	}
+	return 2
+}
+
+// dateLayouts are layouts of dates by precision
+var dateLayouts = []struct {
+	layout    string
+	precision DatePrecision
+}{
+	{"2006", PrecisionYear},
+	{"2006-01", PrecisionMonth},
+	{"2006-01-02", PrecisionDay},
+	{"2006-01-02Z07:00", PrecisionDay},
+	{time.RFC3339Nano, PrecisionTime},
+	{"2006-01-02T15:04:05", PrecisionTime},
+	{"2006-01-02T15:04", PrecisionTime},
+	{"2006-01-02 15:04:05", PrecisionTime},
+}
+
+// XMLDate is a time.Time wrapper for correct unmarshalling, it keeps
+// precision of date: year, month, day or full date and time
 type XMLDate struct {
 	time.Time
+	Precision DatePrecision
 }
 
-// UnmarshalXMLAttr unmarshal xml xs:date to golang time.Time
-func (d *XMLDate) UnmarshalXMLAttr(attr xml.Attr) error {
-	parse, err := time.Parse(dateFormat, attr.Value)
+// ParseDate parse date like "1999", "1999-05", "1999-05-12" or date and time
+// in RFC 3339 format
+func ParseDate(s string) (XMLDate, error) {
+	s = strings.TrimSpace(s)
+	for _, l := range dateLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return XMLDate{Time: t, Precision: l.precision}, nil
+		}
+	}
+	return XMLDate{}, fmt.Errorf("invalid date %q", s)
+}
+
+// String return date in format of its precision
+func (d XMLDate) String() string {
+	switch d.Precision {
+	case PrecisionYear:
+		return d.Format("2006")
+	case PrecisionMonth:
+		return d.Format("2006-01")
+	case PrecisionTime:
+		return d.Format(time.RFC3339Nano)
+	}
+	return d.Format(dateFormat)
+}
 
+// End return the last moment of period of date, e.g. end of year for date
+// with year precision
+func (d XMLDate) End() time.Time {
+	switch d.Precision {
+	case PrecisionYear:
+		return d.AddDate(1, 0, 0).Add(-time.Nanosecond)
+	case PrecisionMonth:
+		return d.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	case PrecisionDay:
+		return d.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return d.Time
+}
+
+// Compare return -1, 0 or 1 when date is before, equal or after other date,
+// dates starting at the same time are ordered from less precise ones
+func (d XMLDate) Compare(o XMLDate) int {
+	switch {
+	case d.Before(o.Time):
+		return -1
+	case d.After(o.Time):
+		return 1
+	case d.Precision.rank() > o.Precision.rank():
+		return 1
+	case d.Precision.rank() < o.Precision.rank():
+		return -1
+	}
+	return 0
+}
+
+// UnmarshalXMLAttr unmarshal xml xs:date or partial date to XMLDate
+func (d *XMLDate) UnmarshalXMLAttr(attr xml.Attr) error {
+	parse, err := ParseDate(attr.Value)
 	if err != nil {
 		return err
 	}
+	*d = parse
+	return nil
+}
+
+// MarshalXMLAttr marshal date in format of its precision
+func (d XMLDate) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: d.String()}, nil
+}
+
+// MarshalText marshal date in format of its precision
+func (d XMLDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
 
-	d.Time = parse
+// UnmarshalText unmarshal date in any supported format
+func (d *XMLDate) UnmarshalText(data []byte) error {
+	parse, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parse
 	return nil
 }
+
+// MarshalJSON marshal date to JSON string
+func (d XMLDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON unmarshal date from JSON string
+func (d *XMLDate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}